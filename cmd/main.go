@@ -13,6 +13,7 @@ import (
 	"github.com/sirini/goapi/internal/routers"
 	"github.com/sirini/goapi/internal/services"
 	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
 )
 
 func main() {
@@ -32,7 +33,104 @@ func main() {
 
 	repo := repositories.NewRepository(db)
 	service := services.NewService(repo)
+
+	if len(os.Args) > 2 && os.Args[1] == "backfill" && os.Args[2] == "variants" {
+		var boardUid uint
+		if len(os.Args) > 3 {
+			if boardUid = repo.Board.GetBoardUidById(os.Args[3]); boardUid < 1 {
+				log.Fatalf("💣 Board not found: %s\n", os.Args[3])
+			}
+		}
+		count := service.Job.BackfillVariants(boardUid)
+		log.Printf("🖼️  %d image variant jobs have been queued, they will be processed by the running server\n", count)
+		return
+	}
+
+	if len(os.Args) > 3 && os.Args[1] == "export" && os.Args[2] == "board" {
+		boardUid := repo.Board.GetBoardUidById(os.Args[3])
+		if boardUid < 1 {
+			log.Fatalf("💣 Board not found: %s\n", os.Args[3])
+		}
+		var formatName string
+		if len(os.Args) > 4 {
+			formatName = os.Args[4]
+		}
+		format, isValid := utils.ParseExportFormat(formatName)
+		if !isValid {
+			log.Fatalf("💣 Unknown export format: %s (markdown or json)\n", formatName)
+		}
+		result, err := service.Export.ExportBoard(boardUid, format)
+		if err != nil {
+			log.Fatalf("💣 Failed to export board %s: %s\n", os.Args[3], err.Error())
+		}
+		log.Printf("📦 Board %s has been exported to .%s (%d bytes)\n", os.Args[3], result.Path, result.Size)
+		return
+	}
+
+	if len(os.Args) > 3 && os.Args[1] == "import" {
+		format, isValid := utils.ParseImportFormat(os.Args[2])
+		if !isValid {
+			log.Fatalf("💣 Unknown import format: %s (gnuboard, xe or wxr)\n", os.Args[2])
+		}
+		param := models.ImportParameter{Format: format, Path: os.Args[3]}
+		for i := 4; i < len(os.Args); i++ {
+			switch os.Args[i] {
+			case "--dry-run":
+				param.DryRun = true
+			case "--files", "--prefix":
+				if i+1 >= len(os.Args) {
+					log.Fatalf("💣 Missing value for %s\n", os.Args[i])
+				}
+				if os.Args[i] == "--files" {
+					param.FileRoot = os.Args[i+1]
+				} else {
+					param.Prefix = os.Args[i+1]
+				}
+				i++
+			default:
+				log.Fatalf("💣 Unknown import option: %s\n", os.Args[i])
+			}
+		}
+
+		report, err := service.Import.Import(param)
+		if err != nil {
+			log.Fatalf("💣 Failed to import %s: %s\n", os.Args[3], err.Error())
+		}
+		if report.DryRun {
+			log.Println("🔍 Dry run, nothing has been saved")
+		}
+		for _, item := range []struct {
+			name  string
+			count models.ImportCount
+		}{
+			{"users", report.Users},
+			{"boards", report.Boards},
+			{"categories", report.Categories},
+			{"posts", report.Posts},
+			{"comments", report.Comments},
+			{"files", report.Files},
+			{"hashtags", report.Tags},
+		} {
+			log.Printf("📥 %-10s found %d, created %d, mapped %d, skipped %d\n",
+				item.name, item.count.Found, item.count.Created, item.count.Mapped, item.count.Skipped)
+		}
+		for _, warning := range report.Warnings {
+			log.Printf("⚠️  %s\n", warning)
+		}
+		log.Printf("🔑 %d imported users must reset their password before signing in\n", report.ResetUsers)
+		return
+	}
+
+	if len(os.Args) > 2 && os.Args[1] == "backfill" && os.Args[2] == "exif" {
+		count := utils.StripMetadataInDir(fmt.Sprintf("./upload/%s", models.UPLOAD_ATTACH))
+		log.Printf("🧹 Removed location and sensitive metadata from %d attached images\n", count)
+		return
+	}
+
 	handler := handlers.NewHandler(service)
+	service.Job.Start()
+	service.Trash.Start()
+	service.View.Start()
 
 	sizeLimit := configs.GetFileSizeLimit()
 	app := fiber.New(fiber.Config{
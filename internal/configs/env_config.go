@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,6 +21,21 @@ type Config struct {
 	ThumbnailSize     string
 	FullSize          string
 	FileSizeLimit     string
+	VariantWidths     string
+	VariantAvif       string
+	StorageQuota      string
+	StorageQuotaLevel string
+	JobWorkers        string
+	JobMaxAttempts    string
+	TrashRetention    string
+	ViewDedupMinutes  string
+	RelatedFulltext   string
+	SpamLinkLimit     string
+	SpamLinkLevel     string
+	SpamRateLimit     string
+	SpamRateMinutes   string
+	SpamChatRateLimit string
+	SpamDupMinutes    string
 	DBHost            string
 	DBUser            string
 	DBPass            string
@@ -40,6 +57,15 @@ type Config struct {
 	OAuthKakaoID      string
 	OAuthKakaoSecret  string
 	OpenaiKey         string
+	ImageDescProvider string
+	ImageDescEndpoint string
+	ImageDescKey      string
+	ImageDescModel    string
+	ImageDescPrompt   string
+	ImageDescLanguage string
+	SpamClassifier    string
+	SpamEndpoint      string
+	SpamKey           string
 }
 
 // 환경변수에 기본값을 설정해주는 함수
@@ -70,6 +96,21 @@ func LoadConfig() {
 		ThumbnailSize:     getEnv("GOAPI_THUMBNAIL_SIZE", "512"),
 		FullSize:          getEnv("GOAPI_FULL_SIZE", "2400"),
 		FileSizeLimit:     getEnv("GOAPI_FILE_SIZE_LIMIT", "104857600"),
+		VariantWidths:     getEnv("GOAPI_VARIANT_WIDTHS", ""),
+		VariantAvif:       getEnv("GOAPI_VARIANT_AVIF", "false"),
+		StorageQuota:      getEnv("GOAPI_STORAGE_QUOTA", "0"),
+		StorageQuotaLevel: getEnv("GOAPI_STORAGE_QUOTA_LEVEL", ""),
+		JobWorkers:        getEnv("GOAPI_JOB_WORKERS", "4"),
+		JobMaxAttempts:    getEnv("GOAPI_JOB_MAX_ATTEMPTS", "3"),
+		TrashRetention:    getEnv("GOAPI_TRASH_RETENTION_DAYS", "30"),
+		ViewDedupMinutes:  getEnv("GOAPI_VIEW_DEDUP_MINUTES", "30"),
+		RelatedFulltext:   getEnv("GOAPI_RELATED_FULLTEXT", "false"),
+		SpamLinkLimit:     getEnv("GOAPI_SPAM_LINK_LIMIT", "3"),
+		SpamLinkLevel:     getEnv("GOAPI_SPAM_LINK_LEVEL", "1"),
+		SpamRateLimit:     getEnv("GOAPI_SPAM_RATE_LIMIT", "5"),
+		SpamRateMinutes:   getEnv("GOAPI_SPAM_RATE_MINUTES", "1"),
+		SpamChatRateLimit: getEnv("GOAPI_SPAM_CHAT_RATE_LIMIT", "30"),
+		SpamDupMinutes:    getEnv("GOAPI_SPAM_DUPLICATE_MINUTES", "60"),
 		DBHost:            getEnv("DB_HOST", "localhost"),
 		DBUser:            getEnv("DB_USER", ""),
 		DBPass:            getEnv("DB_PASS", ""),
@@ -91,6 +132,15 @@ func LoadConfig() {
 		OAuthKakaoID:      getEnv("OAUTH_KAKAO_CLIENT_ID", ""),
 		OAuthKakaoSecret:  getEnv("OAUTH_KAKAO_SECRET", ""),
 		OpenaiKey:         getEnv("OPENAI_API_KEY", ""),
+		ImageDescProvider: getEnv("IMAGE_DESC_PROVIDER", "openai"),
+		ImageDescEndpoint: getEnv("IMAGE_DESC_ENDPOINT", "http://localhost:11434/v1"),
+		ImageDescKey:      getEnv("IMAGE_DESC_API_KEY", ""),
+		ImageDescModel:    getEnv("IMAGE_DESC_MODEL", ""),
+		ImageDescPrompt:   getEnv("IMAGE_DESC_PROMPT", ""),
+		ImageDescLanguage: getEnv("IMAGE_DESC_LANGUAGE", "Korean"),
+		SpamClassifier:    getEnv("SPAM_CLASSIFIER_PROVIDER", ""),
+		SpamEndpoint:      getEnv("SPAM_CLASSIFIER_ENDPOINT", ""),
+		SpamKey:           getEnv("SPAM_CLASSIFIER_API_KEY", ""),
 	}
 }
 
@@ -144,6 +194,110 @@ func GetFileSizeLimit() int {
 	return int(size)
 }
 
+// 사용자 레벨에 맞는 저장 공간 한도 반환 (0 은 무제한)
+func GetStorageQuota(level int) uint64 {
+	quota, err := strconv.ParseUint(Env.StorageQuota, 10, 64)
+	if err != nil {
+		quota = 0
+	}
+	if len(Env.StorageQuotaLevel) < 1 {
+		return quota
+	}
+
+	levels := strings.Split(Env.StorageQuotaLevel, ",")
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(levels) {
+		level = len(levels) - 1
+	}
+	levelQuota, err := strconv.ParseUint(strings.TrimSpace(levels[level]), 10, 64)
+	if err != nil {
+		return quota
+	}
+	return levelQuota
+}
+
+// 백그라운드 작업 처리기 개수와 최대 시도 횟수 반환
+func GetJobWorkerConfig() (int, int) {
+	workers, err := strconv.ParseInt(Env.JobWorkers, 10, 32)
+	if err != nil || workers < 1 {
+		workers = 4
+	}
+	maxAttempts, err := strconv.ParseInt(Env.JobMaxAttempts, 10, 32)
+	if err != nil || maxAttempts < 1 {
+		maxAttempts = 3
+	}
+	return int(workers), int(maxAttempts)
+}
+
+// 휴지통에 보관할 기간 반환 (기본 30일)
+func GetTrashRetention() time.Duration {
+	days, err := strconv.ParseInt(Env.TrashRetention, 10, 32)
+	if err != nil || days < 1 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// 중복 조회로 보고 조회수를 올리지 않는 기간 반환 (기본 30분)
+func GetViewDedupWindow() time.Duration {
+	minutes, err := strconv.ParseInt(Env.ViewDedupMinutes, 10, 32)
+	if err != nil || minutes < 1 {
+		minutes = 30
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// 링크 개수 제한을 받는 레벨(미만)과 허용하는 링크 개수 반환 (기본 레벨 1 미만, 3개)
+func GetSpamLinkLimit() (int, int) {
+	level, err := strconv.ParseInt(Env.SpamLinkLevel, 10, 32)
+	if err != nil || level < 0 {
+		level = 1
+	}
+	limit, err := strconv.ParseInt(Env.SpamLinkLimit, 10, 32)
+	if err != nil || limit < 0 {
+		limit = 3
+	}
+	return int(level), int(limit)
+}
+
+// 회원별로 기간 내에 작성할 수 있는 게시글 혹은 댓글 개수와 그 기간 반환 (기본 1분에 각각 5개)
+func GetSpamRateLimit() (int, time.Duration) {
+	limit, err := strconv.ParseInt(Env.SpamRateLimit, 10, 32)
+	if err != nil || limit < 1 {
+		limit = 5
+	}
+	return int(limit), getSpamRateWindow()
+}
+
+// 회원별로 기간 내에 보낼 수 있는 쪽지 개수와 그 기간 반환 (기본 1분에 30개)
+func GetSpamChatRateLimit() (int, time.Duration) {
+	limit, err := strconv.ParseInt(Env.SpamChatRateLimit, 10, 32)
+	if err != nil || limit < 1 {
+		limit = 30
+	}
+	return int(limit), getSpamRateWindow()
+}
+
+// 작성 빈도를 세는 기간 반환 (기본 1분)
+func getSpamRateWindow() time.Duration {
+	minutes, err := strconv.ParseInt(Env.SpamRateMinutes, 10, 32)
+	if err != nil || minutes < 1 {
+		minutes = 1
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// 같은 회원이 같은 내용을 다시 올리면 중복으로 보는 기간 반환 (기본 60분)
+func GetSpamDuplicateWindow() time.Duration {
+	minutes, err := strconv.ParseInt(Env.SpamDupMinutes, 10, 32)
+	if err != nil || minutes < 1 {
+		minutes = 60
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
 // JWT 유효 기간 (access: hours, refresh: days) 반환
 func GetJWTAccessRefresh() (int, int) {
 	var access, refresh int
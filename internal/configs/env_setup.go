@@ -112,6 +112,216 @@ func Update(db *sql.DB, prefix string) {
 	}
 
 	fmt.Printf(" → created a new table: %s\n", green("trade"))
+
+	if err := createUserStorageTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("user_storage"))
+
+	if err := createUserUsageTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("user_usage"))
+
+	if err := createJobTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("job"))
+
+	if err := createBoardDescriberTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("board_describer"))
+
+	if err := createBoardVariantTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("board_variant"))
+
+	if err := createFileVariantTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("file_variant"))
+
+	if err := createDraftTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("draft"))
+
+	if err := createDraftFileTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("draft_file"))
+
+	if err := createPostRevisionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("post_revision"))
+
+	if err := createTrashTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("trash"))
+
+	if err := createPollTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("poll"))
+
+	if err := createPollOptionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("poll_option"))
+
+	if err := createPollVoterTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("poll_voter"))
+
+	if err := createPollVoteTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("poll_vote"))
+
+	if err := createBoardReactionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("board_reaction"))
+
+	if err := createPostReactionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("post_reaction"))
+
+	if err := createCommentReactionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("comment_reaction"))
+
+	if err := migrateLikesToReactions(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → migrated existing likes to: %s\n", green("post_reaction, comment_reaction"))
+
+	if err := createBookmarkTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("bookmark"))
+
+	if err := createPostViewDailyTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("post_view_daily"))
+
+	if err := createSeriesTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("series"))
+
+	if err := createSeriesPostTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("series_post"))
+
+	if err := createMentionTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("mention"))
+
+	if err := createLinkPreviewTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("link_preview"))
+
+	if err := createBoardFieldTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("board_field"))
+
+	if err := createPostFieldTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("post_field"))
+
+	if err := createBoardModerationTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("board_moderation"))
+
+	if err := createModerationTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("moderation"))
+
+	if err := createSpamRuleTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("spam_rule"))
+
+	if err := createSpamLogTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("spam_log"))
+
+	if err := createExportTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("export"))
+
+	if err := createImportMapTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → created a new table: %s\n", green("import_map"))
+
+	if err := alterExifTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("exif"))
+
+	if err := alterPostTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("post"))
+
+	if err := alterPostExpired(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("post"))
+
+	if err := alterPostFulltext(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added a full-text index to: %s\n", green("post"))
+
+	if err := alterPostFormat(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("post"))
+
+	if err := alterPostContent(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → widened the content column of: %s\n", green("post"))
+
+	if err := alterPostCreated(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("post"))
+
+	if err := alterHashtagTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("hashtag"))
+
+	if err := alterBoardCategoryTable(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("board_category"))
+
+	if err := alterImageDraft(db, prefix); err != nil {
+		fmt.Printf("%s\n", red(err.Error()))
+	}
+	fmt.Printf(" → added new columns to: %s\n", green("image"))
 	fmt.Println(` → Now tsboard starts a backend service`)
 	fmt.Println("⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯⎯")
 }
@@ -419,6 +629,37 @@ func createTables(db *sql.DB, dbInfo DBInfo) {
 	createExifTable(db, dbInfo.Prefix)
 	createImageDescriptionTable(db, dbInfo.Prefix)
 	createTradeTable(db, dbInfo.Prefix)
+	createUserStorageTable(db, dbInfo.Prefix)
+	createUserUsageTable(db, dbInfo.Prefix)
+	createJobTable(db, dbInfo.Prefix)
+	createBoardDescriberTable(db, dbInfo.Prefix)
+	createBoardVariantTable(db, dbInfo.Prefix)
+	createFileVariantTable(db, dbInfo.Prefix)
+	createDraftTable(db, dbInfo.Prefix)
+	createDraftFileTable(db, dbInfo.Prefix)
+	createPostRevisionTable(db, dbInfo.Prefix)
+	createTrashTable(db, dbInfo.Prefix)
+	createPollTable(db, dbInfo.Prefix)
+	createPollOptionTable(db, dbInfo.Prefix)
+	createPollVoterTable(db, dbInfo.Prefix)
+	createPollVoteTable(db, dbInfo.Prefix)
+	createBoardReactionTable(db, dbInfo.Prefix)
+	createPostReactionTable(db, dbInfo.Prefix)
+	createCommentReactionTable(db, dbInfo.Prefix)
+	createBookmarkTable(db, dbInfo.Prefix)
+	createPostViewDailyTable(db, dbInfo.Prefix)
+	createSeriesTable(db, dbInfo.Prefix)
+	createSeriesPostTable(db, dbInfo.Prefix)
+	createMentionTable(db, dbInfo.Prefix)
+	createLinkPreviewTable(db, dbInfo.Prefix)
+	createBoardFieldTable(db, dbInfo.Prefix)
+	createPostFieldTable(db, dbInfo.Prefix)
+	createBoardModerationTable(db, dbInfo.Prefix)
+	createModerationTable(db, dbInfo.Prefix)
+	createSpamRuleTable(db, dbInfo.Prefix)
+	createSpamLogTable(db, dbInfo.Prefix)
+	createExportTable(db, dbInfo.Prefix)
+	createImportMapTable(db, dbInfo.Prefix)
 }
 
 // 기본 레코드들 추가하기
@@ -591,9 +832,14 @@ func createBoardCategoryTable(db *sql.DB, prefix string) {
 	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_category (
   uid INT UNSIGNED NOT NULL auto_increment,
   board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+  parent_uid INT UNSIGNED NOT NULL DEFAULT 0,
   name VARCHAR(30) NOT NULL DEFAULT '',
+  description VARCHAR(300) NOT NULL DEFAULT '',
+  sort_order INT UNSIGNED NOT NULL DEFAULT 0,
+  level_write TINYINT UNSIGNED NOT NULL DEFAULT 0,
   PRIMARY KEY (uid),
-  KEY (board_uid)
+  KEY (board_uid),
+  KEY (parent_uid)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
 	db.Exec(query)
 }
@@ -622,11 +868,19 @@ func createPostTable(db *sql.DB, prefix string) {
   user_uid INT UNSIGNED NOT NULL DEFAULT 0,
   category_uid INT UNSIGNED NOT NULL DEFAULT 0,
   title VARCHAR(300) NOT NULL DEFAULT '',
-  content TEXT,
+  content MEDIUMTEXT,
+  format TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  source MEDIUMTEXT,
   submitted BIGINT UNSIGNED NOT NULL DEFAULT 0,
   modified BIGINT UNSIGNED NOT NULL DEFAULT 0,
   hit INT UNSIGNED NOT NULL DEFAULT 0,
   status TINYINT NOT NULL DEFAULT 0,
+  publish_at BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  expire_at BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  reserved_status TINYINT NOT NULL DEFAULT 0,
+  expire_hide TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  expired TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  created BIGINT UNSIGNED NOT NULL DEFAULT 0,
   PRIMARY KEY (uid),
   KEY (board_uid),
   KEY (user_uid),
@@ -634,6 +888,10 @@ func createPostTable(db *sql.DB, prefix string) {
   KEY (submitted),
   KEY (hit),
   KEY (status),
+  KEY (publish_at),
+  KEY (expire_at),
+  KEY (user_uid, created),
+  FULLTEXT KEY ft_title (title) WITH PARSER ngram,
   CONSTRAINT fk_pb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
   CONSTRAINT fk_pu FOREIGN KEY (user_uid) REFERENCES %suser(uid),
   CONSTRAINT fk_pc FOREIGN KEY (category_uid) REFERENCES %sboard_category(uid)
@@ -647,8 +905,10 @@ func createHashtagTable(db *sql.DB, prefix string) {
   uid INT UNSIGNED NOT NULL auto_increment,
   name VARCHAR(30) NOT NULL DEFAULT '',
   used INT UNSIGNED NOT NULL DEFAULT 0,
+  blocked TINYINT UNSIGNED NOT NULL DEFAULT 0,
   timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
-  PRIMARY KEY (uid)
+  PRIMARY KEY (uid),
+  KEY (name)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
 	db.Exec(query)
 }
@@ -771,10 +1031,12 @@ func createImageTable(db *sql.DB, prefix string) {
   uid INT UNSIGNED NOT NULL auto_increment,
   board_uid INT UNSIGNED NOT NULL DEFAULT 0,
   user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+  draft_uid INT UNSIGNED NOT NULL DEFAULT 0,
   path VARCHAR(300) NOT NULL DEFAULT '',
   timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
   PRIMARY KEY (uid),
   KEY (user_uid),
+  KEY (draft_uid),
   CONSTRAINT fk_ib FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
   CONSTRAINT fk_iu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
@@ -811,12 +1073,16 @@ func createExifTable(db *sql.DB, prefix string) {
   post_uid INT UNSIGNED NOT NULL DEFAULT 0,
   make VARCHAR(20) NOT NULL DEFAULT '',
   model VARCHAR(20) NOT NULL DEFAULT '',
+  lens VARCHAR(50) NOT NULL DEFAULT '',
   aperture INT UNSIGNED NOT NULL DEFAULT 0,
   iso INT UNSIGNED NOT NULL DEFAULT 0,
   focal_length INT UNSIGNED NOT NULL DEFAULT 0,
   exposure INT UNSIGNED NOT NULL DEFAULT 0,
   width INT UNSIGNED NOT NULL DEFAULT 0,
   height INT UNSIGNED NOT NULL DEFAULT 0,
+  orientation TINYINT UNSIGNED NOT NULL DEFAULT 0,
+  latitude DOUBLE NOT NULL DEFAULT 0,
+  longitude DOUBLE NOT NULL DEFAULT 0,
   date BIGINT UNSIGNED NOT NULL DEFAULT 0,
   PRIMARY KEY (uid),
   KEY (file_uid),
@@ -865,6 +1131,731 @@ func createTradeTable(db *sql.DB, prefix string) error {
 	return err
 }
 
+// user_storage 테이블 생성 (v1.0.4)
+func createUserStorageTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %suser_storage (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	quota BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (user_uid),
+	CONSTRAINT fk_usu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// user_usage 테이블 생성 (v1.0.4)
+func createUserUsageTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %suser_usage (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	attachment BIGINT NOT NULL DEFAULT 0,
+	image BIGINT NOT NULL DEFAULT 0,
+	profile BIGINT NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (user_uid),
+	CONSTRAINT fk_usg FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// job 테이블 생성 (v1.0.4)
+func createJobTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sjob (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	type TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	status TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	file_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	path VARCHAR(300) NOT NULL DEFAULT '',
+	attempts TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	message VARCHAR(300) NOT NULL DEFAULT '',
+	run_after BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	updated BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (status, run_after),
+	KEY (post_uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_describer 테이블 생성 (v1.0.4)
+func createBoardDescriberTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_describer (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	model VARCHAR(100) NOT NULL DEFAULT '',
+	prompt VARCHAR(500) NOT NULL DEFAULT '',
+	language VARCHAR(30) NOT NULL DEFAULT '',
+	PRIMARY KEY (uid),
+	UNIQUE KEY (board_uid),
+	CONSTRAINT fk_bdb FOREIGN KEY (board_uid) REFERENCES %sboard(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// draft 테이블 생성 (v1.0.4)
+func createDraftTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sdraft (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	category_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	title VARCHAR(300) NOT NULL DEFAULT '',
+	content MEDIUMTEXT,
+	format TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	tags VARCHAR(300) NOT NULL DEFAULT '',
+	version INT UNSIGNED NOT NULL DEFAULT 1,
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	updated BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (user_uid, board_uid),
+	CONSTRAINT fk_drb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_dru FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// draft_file 테이블 생성 (v1.0.4)
+func createDraftFileTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sdraft_file (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	draft_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	name VARCHAR(100) NOT NULL DEFAULT '',
+	path VARCHAR(300) NOT NULL DEFAULT '',
+	size BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (draft_uid),
+	KEY (user_uid),
+	CONSTRAINT fk_dfd FOREIGN KEY (draft_uid) REFERENCES %sdraft(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post_revision 테이블 생성 (v1.0.4)
+func createPostRevisionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spost_revision (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	editor_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	title VARCHAR(300) NOT NULL DEFAULT '',
+	content MEDIUMTEXT,
+	format TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	tags VARCHAR(300) NOT NULL DEFAULT '',
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	restored_from INT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (post_uid),
+	CONSTRAINT fk_prp FOREIGN KEY (post_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_pre FOREIGN KEY (editor_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// trash 테이블 생성 (v1.0.4)
+func createTrashTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %strash (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	parent_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	type TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	target_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	removed_by INT UNSIGNED NOT NULL DEFAULT 0,
+	prev_status TINYINT NOT NULL DEFAULT 0,
+	backup VARCHAR(10000) NOT NULL DEFAULT '',
+	removed BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (parent_uid),
+	KEY (type, target_uid),
+	KEY (removed)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// poll 테이블 생성 (v1.0.4)
+func createPollTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spoll (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	question VARCHAR(300) NOT NULL DEFAULT '',
+	multiple TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	anonymous TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	open_at BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	close_at BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	notified TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid),
+	KEY (close_at),
+	CONSTRAINT fk_pollb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_pollp FOREIGN KEY (post_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_pollu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// poll_option 테이블 생성 (v1.0.4)
+func createPollOptionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spoll_option (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	poll_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	content VARCHAR(100) NOT NULL DEFAULT '',
+	PRIMARY KEY (uid),
+	KEY (poll_uid),
+	CONSTRAINT fk_pop FOREIGN KEY (poll_uid) REFERENCES %spoll(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// poll_voter 테이블 생성, 투표마다 회원당 한 번만 참여 가능 (v1.0.4)
+func createPollVoterTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spoll_voter (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	poll_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (poll_uid, user_uid),
+	CONSTRAINT fk_pvrp FOREIGN KEY (poll_uid) REFERENCES %spoll(uid),
+	CONSTRAINT fk_pvru FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// poll_vote 테이블 생성 (v1.0.4)
+func createPollVoteTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spoll_vote (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	poll_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	option_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (option_uid, user_uid),
+	KEY (poll_uid),
+	CONSTRAINT fk_pvp FOREIGN KEY (poll_uid) REFERENCES %spoll(uid),
+	CONSTRAINT fk_pvo FOREIGN KEY (option_uid) REFERENCES %spoll_option(uid),
+	CONSTRAINT fk_pvu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_reaction 테이블 생성 (v1.0.4)
+func createBoardReactionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_reaction (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	emojis VARCHAR(200) NOT NULL DEFAULT '',
+	multiple TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (board_uid),
+	CONSTRAINT fk_brb FOREIGN KEY (board_uid) REFERENCES %sboard(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post_reaction 테이블 생성 (v1.0.4, 이모지끼리 구분되도록 emoji 컬럼은 utf8mb4_bin 사용)
+func createPostReactionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spost_reaction (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	target_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	emoji VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '',
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (target_uid, user_uid, emoji),
+	KEY (user_uid),
+	CONSTRAINT fk_rpb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_rpp FOREIGN KEY (target_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_rpu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// comment_reaction 테이블 생성 (v1.0.4, 이모지끼리 구분되도록 emoji 컬럼은 utf8mb4_bin 사용)
+func createCommentReactionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %scomment_reaction (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	target_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	emoji VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '',
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (target_uid, user_uid, emoji),
+	KEY (user_uid),
+	CONSTRAINT fk_rcb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_rcc FOREIGN KEY (target_uid) REFERENCES %scomment(uid),
+	CONSTRAINT fk_rcu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// 기존 게시글/댓글 좋아요들을 기본 반응(👍)으로 옮기기 (v1.0.4, 이미 옮긴 좋아요는 건너뜀)
+func migrateLikesToReactions(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`INSERT IGNORE INTO %spost_reaction (board_uid, target_uid, user_uid, emoji, timestamp)
+	SELECT board_uid, post_uid, user_uid, ?, timestamp FROM %spost_like WHERE liked = 1`, prefix, prefix)
+	if _, err := db.Exec(query, "👍"); err != nil {
+		return err
+	}
+	query = fmt.Sprintf(`INSERT IGNORE INTO %scomment_reaction (board_uid, target_uid, user_uid, emoji, timestamp)
+	SELECT board_uid, comment_uid, user_uid, ?, timestamp FROM %scomment_like WHERE liked = 1`, prefix, prefix)
+	_, err := db.Exec(query, "👍")
+	return err
+}
+
+// bookmark 테이블 생성 (v1.0.4)
+func createBookmarkTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sbookmark (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	folder VARCHAR(50) NOT NULL DEFAULT '',
+	note VARCHAR(300) NOT NULL DEFAULT '',
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (user_uid, post_uid),
+	KEY (user_uid, folder),
+	CONSTRAINT fk_bmu FOREIGN KEY (user_uid) REFERENCES %suser(uid),
+	CONSTRAINT fk_bmb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_bmp FOREIGN KEY (post_uid) REFERENCES %spost(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post_view_daily 테이블 생성 (v1.0.4, date 는 해당 일자 0시의 유닉스 밀리초)
+func createPostViewDailyTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spost_view_daily (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	date BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	views INT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid, date),
+	KEY (date),
+	CONSTRAINT fk_pvdb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_pvdp FOREIGN KEY (post_uid) REFERENCES %spost(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// series 테이블 생성 (v1.0.4)
+func createSeriesTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sseries (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	name VARCHAR(100) NOT NULL DEFAULT '',
+	info VARCHAR(300) NOT NULL DEFAULT '',
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	modified BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (board_uid),
+	KEY (user_uid),
+	CONSTRAINT fk_srb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_sru FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// series_post 테이블 생성 (v1.0.4, 게시글 하나는 하나의 시리즈에만 속함)
+func createSeriesPostTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sseries_post (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	series_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	position INT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid),
+	KEY (series_uid, position),
+	CONSTRAINT fk_sps FOREIGN KEY (series_uid) REFERENCES %sseries(uid),
+	CONSTRAINT fk_spp FOREIGN KEY (post_uid) REFERENCES %spost(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// mention 테이블 생성 (v1.0.4, 게시글 본문에서 언급한 경우 comment_uid 는 0)
+func createMentionTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %smention (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	comment_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	from_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	to_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid, comment_uid, to_uid),
+	KEY (to_uid),
+	CONSTRAINT fk_mnb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_mnp FOREIGN KEY (post_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_mnf FOREIGN KEY (from_uid) REFERENCES %suser(uid),
+	CONSTRAINT fk_mnt FOREIGN KEY (to_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// link_preview 테이블 생성 (v1.0.4, url_hash 는 링크 주소의 sha256 값)
+func createLinkPreviewTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %slink_preview (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	url_hash CHAR(64) NOT NULL DEFAULT '',
+	url VARCHAR(2000) NOT NULL DEFAULT '',
+	title VARCHAR(300) NOT NULL DEFAULT '',
+	description VARCHAR(500) NOT NULL DEFAULT '',
+	site_name VARCHAR(100) NOT NULL DEFAULT '',
+	image VARCHAR(300) NOT NULL DEFAULT '',
+	type VARCHAR(30) NOT NULL DEFAULT '',
+	failed TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	fetched BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (url_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_field 테이블 생성 (v1.0.4, options 는 선택형 필드의 선택지들을 쉼표로 구분)
+func createBoardFieldTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_field (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	name VARCHAR(50) NOT NULL DEFAULT '',
+	type TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	required TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	options VARCHAR(1000) NOT NULL DEFAULT '',
+	PRIMARY KEY (uid),
+	KEY (board_uid),
+	CONSTRAINT fk_bfb FOREIGN KEY (board_uid) REFERENCES %sboard(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post_field 테이블 생성 (v1.0.4)
+func createPostFieldTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %spost_field (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	field_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	value VARCHAR(300) NOT NULL DEFAULT '',
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid, field_uid),
+	KEY (field_uid, value),
+	CONSTRAINT fk_pfp FOREIGN KEY (post_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_pff FOREIGN KEY (field_uid) REFERENCES %sboard_field(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_moderation 테이블 생성 (v1.0.4, threshold 는 모드에 따라 승인이 필요한 글 개수 혹은 레벨)
+func createBoardModerationTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_moderation (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	mode TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	threshold INT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (board_uid),
+	CONSTRAINT fk_bmdb FOREIGN KEY (board_uid) REFERENCES %sboard(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// moderation 테이블 생성 (v1.0.4, 게시글을 검토하는 경우 comment_uid 는 0)
+func createModerationTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %smoderation (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	comment_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	decision TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	reason VARCHAR(300) NOT NULL DEFAULT '',
+	moderator_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	submitted BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	decided BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (post_uid, comment_uid),
+	KEY (decision, uid),
+	KEY (board_uid, user_uid),
+	CONSTRAINT fk_mdb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_mdp FOREIGN KEY (post_uid) REFERENCES %spost(uid),
+	CONSTRAINT fk_mdu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// spam_rule 테이블 생성 (v1.0.4, type 은 단어 혹은 정규식)
+func createSpamRuleTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sspam_rule (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	type TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	pattern VARCHAR(200) NOT NULL DEFAULT '',
+	action TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// spam_log 테이블 생성 (v1.0.4, 거부된 글은 target_uid 가 0, 쪽지는 board_uid 가 0)
+func createSpamLogTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sspam_log (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	target TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	target_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	check_type TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	rule_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	action TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	detail VARCHAR(300) NOT NULL DEFAULT '',
+	excerpt VARCHAR(300) NOT NULL DEFAULT '',
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (user_uid),
+	CONSTRAINT fk_slu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// export 테이블 생성 (v1.0.4, 게시판 전체를 내보낼 때는 post_uid 가 0)
+func createExportTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sexport (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	user_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	format TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	status TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	path VARCHAR(300) NOT NULL DEFAULT '',
+	size BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	message VARCHAR(300) NOT NULL DEFAULT '',
+	created BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	completed BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	KEY (board_uid),
+	KEY (user_uid),
+	CONSTRAINT fk_exb FOREIGN KEY (board_uid) REFERENCES %sboard(uid),
+	CONSTRAINT fk_exu FOREIGN KEY (user_uid) REFERENCES %suser(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// import_map 테이블 생성 (v1.0.4, 다른 게시판 엔진에서 옮겨온 고유번호 대응표, legacy_password 는 회원만 사용)
+func createImportMapTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %simport_map (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	format TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	kind TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	source_id VARCHAR(100) NOT NULL DEFAULT '',
+	target_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	legacy_password VARCHAR(300) NOT NULL DEFAULT '',
+	timestamp BIGINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (format, kind, source_id),
+	KEY (kind, target_uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// exif 테이블에 렌즈, 방향, 위치 컬럼 추가 (v1.0.4)
+func alterExifTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %sexif
+	ADD COLUMN lens VARCHAR(50) NOT NULL DEFAULT '' AFTER model,
+	ADD COLUMN orientation TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER height,
+	ADD COLUMN latitude DOUBLE NOT NULL DEFAULT 0 AFTER orientation,
+	ADD COLUMN longitude DOUBLE NOT NULL DEFAULT 0 AFTER latitude`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post 테이블에 예약 발행, 게시 종료 컬럼 추가 (v1.0.4)
+func alterPostTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %spost
+	ADD COLUMN publish_at BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER status,
+	ADD COLUMN expire_at BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER publish_at,
+	ADD COLUMN reserved_status TINYINT NOT NULL DEFAULT 0 AFTER expire_at,
+	ADD COLUMN expire_hide TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER reserved_status,
+	ADD KEY (publish_at),
+	ADD KEY (expire_at)`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post 테이블에 게시 종료로 숨겨진 글 표시 컬럼 추가 (v1.0.4)
+func alterPostExpired(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %spost
+	ADD COLUMN expired TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER expire_hide`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post 테이블에 실제 작성 시각 컬럼 추가 (v1.0.4, 발행 예약 글은 submitted 에 발행 시각이 저장되므로 따로 기록)
+func alterPostCreated(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %spost
+	ADD COLUMN created BIGINT UNSIGNED NOT NULL DEFAULT 0 AFTER expired,
+	ADD KEY (user_uid, created)`, prefix)
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+	query = fmt.Sprintf("UPDATE %spost SET created = LEAST(submitted, ?) WHERE created = 0", prefix)
+	_, err := db.Exec(query, time.Now().UnixMilli())
+	return err
+}
+
+// post 테이블 제목에 관련 글 추천용 전문 검색 인덱스 추가 (v1.0.4, ngram 인덱스가 이미 있으면 건너뛰고 아니면 교체)
+func alterPostFulltext(db *sql.DB, prefix string) error {
+	var table, create string
+	if err := db.QueryRow(fmt.Sprintf("SHOW CREATE TABLE %spost", prefix)).Scan(&table, &create); err != nil {
+		return err
+	}
+	rows, err := db.Query(`SELECT DISTINCT INDEX_NAME FROM information_schema.STATISTICS
+	WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_TYPE = 'FULLTEXT' AND COLUMN_NAME = 'title'`, prefix+"post")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	alters := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		alters = append(alters, fmt.Sprintf("DROP INDEX `%s`", name))
+	}
+	if len(alters) == 1 && strings.Contains(create, "ngram") {
+		return nil
+	}
+
+	alters = append(alters, "ADD FULLTEXT KEY ft_title (title) WITH PARSER ngram")
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %spost %s", prefix, strings.Join(alters, ", ")))
+	return err
+}
+
+// post 테이블에 본문 형식, 마크다운 원문 컬럼 추가 (v1.0.4)
+func alterPostFormat(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %spost
+	ADD COLUMN format TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER content,
+	ADD COLUMN source MEDIUMTEXT AFTER format`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// post 테이블 본문 컬럼을 마크다운 원문과 같은 크기로 늘리기 (v1.0.4)
+func alterPostContent(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf("ALTER TABLE %spost MODIFY COLUMN content MEDIUMTEXT", prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// hashtag 테이블에 차단 여부 컬럼과 이름 인덱스 추가 (v1.0.4)
+func alterHashtagTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %shashtag
+	ADD COLUMN blocked TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER used,
+	ADD KEY (name)`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// image 테이블에 임시저장 글 고유번호 컬럼 추가 (v1.0.4)
+func alterImageDraft(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %simage
+	ADD COLUMN draft_uid INT UNSIGNED NOT NULL DEFAULT 0 AFTER user_uid,
+	ADD KEY (draft_uid)`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_category 테이블에 상위 분류, 정렬 순서, 설명, 글쓰기 레벨 컬럼 추가 (v1.0.4)
+func alterBoardCategoryTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`ALTER TABLE %sboard_category
+	ADD COLUMN parent_uid INT UNSIGNED NOT NULL DEFAULT 0 AFTER board_uid,
+	ADD COLUMN description VARCHAR(300) NOT NULL DEFAULT '' AFTER name,
+	ADD COLUMN sort_order INT UNSIGNED NOT NULL DEFAULT 0 AFTER description,
+	ADD COLUMN level_write TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER sort_order,
+	ADD KEY (parent_uid)`, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// board_variant 테이블 생성 (v1.0.4)
+func createBoardVariantTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sboard_variant (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	board_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	widths VARCHAR(100) NOT NULL DEFAULT '',
+	avif TINYINT UNSIGNED NOT NULL DEFAULT 0,
+	PRIMARY KEY (uid),
+	UNIQUE KEY (board_uid),
+	CONSTRAINT fk_bvb FOREIGN KEY (board_uid) REFERENCES %sboard(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
+// file_variant 테이블 생성 (v1.0.4)
+func createFileVariantTable(db *sql.DB, prefix string) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %sfile_variant (
+	uid INT UNSIGNED NOT NULL auto_increment,
+	file_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	post_uid INT UNSIGNED NOT NULL DEFAULT 0,
+	path VARCHAR(300) NOT NULL DEFAULT '',
+	width INT UNSIGNED NOT NULL DEFAULT 0,
+	height INT UNSIGNED NOT NULL DEFAULT 0,
+	format VARCHAR(10) NOT NULL DEFAULT '',
+	PRIMARY KEY (uid),
+	KEY (file_uid),
+	KEY (post_uid),
+	CONSTRAINT fk_fvf FOREIGN KEY (file_uid) REFERENCES %sfile(uid),
+	CONSTRAINT fk_fvp FOREIGN KEY (post_uid) REFERENCES %spost(uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci`, prefix, prefix, prefix)
+	_, err := db.Exec(query)
+	return err
+}
+
 // 기본 그룹 생성
 func insertDefaultGroup(db *sql.DB, prefix string) {
 	query := fmt.Sprintf(`INSERT INTO %sgroup (id, admin_uid, timestamp) VALUES (?, ?, ?)`, prefix)
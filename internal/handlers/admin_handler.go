@@ -14,17 +14,30 @@ import (
 
 type AdminHandler interface {
 	AddBoardCategoryHandler(c fiber.Ctx) error
+	AddBoardFieldHandler(c fiber.Ctx) error
+	AddSpamRuleHandler(c fiber.Ctx) error
+	ApproveModerationHandler(c fiber.Ctx) error
+	BlockHashtagHandler(c fiber.Ctx) error
+	BoardDescriberLoadHandler(c fiber.Ctx) error
+	BoardFieldLoadHandler(c fiber.Ctx) error
 	BoardGeneralLoadHandler(c fiber.Ctx) error
 	BoardLevelLoadHandler(c fiber.Ctx) error
+	BoardModerationLoadHandler(c fiber.Ctx) error
 	BoardPointLoadHandler(c fiber.Ctx) error
+	BoardReactionLoadHandler(c fiber.Ctx) error
+	BoardVariantLoadHandler(c fiber.Ctx) error
 	ChangeBoardAdminHandler(c fiber.Ctx) error
+	ChangeBoardDescriberHandler(c fiber.Ctx) error
 	ChangeBoardGroupHandler(c fiber.Ctx) error
 	ChangeBoardInfoHandler(c fiber.Ctx) error
 	ChangeBoardLevelHandler(c fiber.Ctx) error
+	ChangeBoardModerationHandler(c fiber.Ctx) error
 	ChangeBoardNameHandler(c fiber.Ctx) error
 	ChangeBoardPointHandler(c fiber.Ctx) error
+	ChangeBoardReactionHandler(c fiber.Ctx) error
 	ChangeBoardRowHandler(c fiber.Ctx) error
 	ChangeBoardTypeHandler(c fiber.Ctx) error
+	ChangeBoardVariantHandler(c fiber.Ctx) error
 	ChangeBoardWidthHandler(c fiber.Ctx) error
 	ChangeGroupAdminHandler(c fiber.Ctx) error
 	ChangeGroupIdHandler(c fiber.Ctx) error
@@ -33,26 +46,50 @@ type AdminHandler interface {
 	DashboardItemLoadHandler(c fiber.Ctx) error
 	DashboardLatestLoadHandler(c fiber.Ctx) error
 	DashboardStatisticLoadHandler(c fiber.Ctx) error
+	DashboardViewLoadHandler(c fiber.Ctx) error
 	GetAdminCandidatesHandler(c fiber.Ctx) error
 	GroupGeneralLoadHandler(c fiber.Ctx) error
 	GroupListLoadHandler(c fiber.Ctx) error
+	HashtagListLoadHandler(c fiber.Ctx) error
+	JobListLoadHandler(c fiber.Ctx) error
 	LatestCommentLoadHandler(c fiber.Ctx) error
 	LatestCommentSearchHandler(c fiber.Ctx) error
 	LatestPostLoadHandler(c fiber.Ctx) error
 	LatestPostSearchHandler(c fiber.Ctx) error
+	MergeHashtagHandler(c fiber.Ctx) error
+	ModerationListLoadHandler(c fiber.Ctx) error
+	ModifyBoardCategoryHandler(c fiber.Ctx) error
+	ModifyBoardFieldHandler(c fiber.Ctx) error
+	MoveBoardCategoryHandler(c fiber.Ctx) error
+	PurgeTrashHandler(c fiber.Ctx) error
+	RecountHashtagHandler(c fiber.Ctx) error
+	RegenerateDescriptionHandler(c fiber.Ctx) error
+	RejectModerationHandler(c fiber.Ctx) error
 	RemoveBoardCategoryHandler(c fiber.Ctx) error
+	RemoveBoardFieldHandler(c fiber.Ctx) error
 	RemoveBoardHandler(c fiber.Ctx) error
 	RemoveCommentHandler(c fiber.Ctx) error
 	RemovePostHandler(c fiber.Ctx) error
 	RemoveGroupHandler(c fiber.Ctx) error
+	RemoveHashtagHandler(c fiber.Ctx) error
+	RemoveSpamRuleHandler(c fiber.Ctx) error
+	ReorderBoardCategoryHandler(c fiber.Ctx) error
+	RenameHashtagHandler(c fiber.Ctx) error
 	ReportListLoadHandler(c fiber.Ctx) error
 	ReportListSearchHandler(c fiber.Ctx) error
+	RestoreTrashHandler(c fiber.Ctx) error
+	RetryJobHandler(c fiber.Ctx) error
 	ShowSimilarBoardIdHandler(c fiber.Ctx) error
 	ShowSimilarGroupIdHandler(c fiber.Ctx) error
+	SpamLogListLoadHandler(c fiber.Ctx) error
+	SpamRuleLoadHandler(c fiber.Ctx) error
+	TrashListLoadHandler(c fiber.Ctx) error
 	UseBoardCategoryHandler(c fiber.Ctx) error
 	UserInfoLoadHandler(c fiber.Ctx) error
 	UserInfoModifyHandler(c fiber.Ctx) error
 	UserListLoadHandler(c fiber.Ctx) error
+	UserStorageLoadHandler(c fiber.Ctx) error
+	UserStorageModifyHandler(c fiber.Ctx) error
 }
 
 type TsboardAdminHandler struct {
@@ -71,15 +108,150 @@ func (h *TsboardAdminHandler) AddBoardCategoryHandler(c fiber.Ctx) error {
 		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
 	}
 
+	parentUid, err := strconv.ParseUint(c.FormValue("parentUid"), 10, 32)
+	if err != nil {
+		parentUid = 0
+	}
+
 	categoryName := c.FormValue("newCategory")
 	if len(categoryName) < 2 {
 		return utils.Err(c, "Invalid category name, too short", models.CODE_INVALID_PARAMETER)
 	}
 
-	insertId := h.service.Admin.AddBoardCategory(uint(uid), categoryName)
+	insertId := h.service.Admin.AddBoardCategory(uint(uid), uint(parentUid), categoryName)
 	return utils.Ok(c, insertId)
 }
 
+// 게시판에 사용자 정의 필드 추가하는 핸들러
+func (h *TsboardAdminHandler) AddBoardFieldHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	field, err := utils.CheckFieldDefinition(c)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_INVALID_PARAMETER)
+	}
+
+	insertId, err := h.service.Admin.AddBoardField(uint(boardUid), field)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, insertId)
+}
+
+// 게시판별 이미지 설명글 생성 옵션 불러오는 핸들러
+func (h *TsboardAdminHandler) BoardDescriberLoadHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Admin.GetBoardDescribeOption(uint(boardUid))
+	return utils.Ok(c, result)
+}
+
+// 게시판별 사용자 정의 필드 목록 불러오는 핸들러
+func (h *TsboardAdminHandler) BoardFieldLoadHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Admin.GetBoardFields(uint(boardUid))
+	return utils.Ok(c, result)
+}
+
+// 스팸 필터에 금지어 규칙 추가하는 핸들러
+func (h *TsboardAdminHandler) AddSpamRuleHandler(c fiber.Ctx) error {
+	ruleType, err := strconv.ParseUint(c.FormValue("type"), 10, 8)
+	if err != nil {
+		return utils.Err(c, "Invalid type, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	action, err := strconv.ParseUint(c.FormValue("action"), 10, 8)
+	if err != nil {
+		return utils.Err(c, "Invalid action, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	pattern := strings.TrimSpace(c.FormValue("pattern"))
+
+	ruleUid, err := h.service.Spam.AddSpamRule(models.SpamRule{
+		Type:    models.SpamRuleType(ruleType),
+		Pattern: pattern,
+		Action:  models.SpamAction(action),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, ruleUid)
+}
+
+// 승인 대기중인 게시글 혹은 댓글을 승인하는 핸들러
+func (h *TsboardAdminHandler) ApproveModerationHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	moderationUid, err := strconv.ParseUint(c.FormValue("moderationUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid moderation uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	mention, err := h.service.Moderation.Approve(uint(moderationUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	h.service.Mention.SaveMentions(mention)
+	return utils.Ok(c, nil)
+}
+
+// 해시태그 차단 혹은 차단 해제하는 핸들러 (차단하면 게시글들과의 연결도 끊김)
+func (h *TsboardAdminHandler) BlockHashtagHandler(c fiber.Ctx) error {
+	hashtagUid, err := strconv.ParseUint(c.FormValue("hashtagUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid hashtag uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	blocked, err := strconv.ParseBool(c.FormValue("blocked"))
+	if err != nil {
+		return utils.Err(c, "Invalid blocked, it should be 'true' or 'false'", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Hashtag.BlockHashtag(uint(hashtagUid), blocked)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판별 승인 대기 옵션 불러오는 핸들러
+func (h *TsboardAdminHandler) BoardModerationLoadHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Moderation.GetModerationOption(uint(boardUid))
+	return utils.Ok(c, result)
+}
+
+// 게시판별 반응 이모지 옵션 불러오는 핸들러
+func (h *TsboardAdminHandler) BoardReactionLoadHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Admin.GetBoardReactionOption(uint(boardUid))
+	return utils.Ok(c, result)
+}
+
+// 게시판별 반응형 변형 이미지 생성 옵션 불러오는 핸들러
+func (h *TsboardAdminHandler) BoardVariantLoadHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Admin.GetBoardVariantOption(uint(boardUid))
+	return utils.Ok(c, result)
+}
+
 // 게시판 관리화면 > 일반 기존 내용 불러오는 핸들러
 func (h *TsboardAdminHandler) BoardGeneralLoadHandler(c fiber.Ctx) error {
 	id := c.FormValue("id")
@@ -157,6 +329,102 @@ func (h *TsboardAdminHandler) ChangeBoardAdminHandler(c fiber.Ctx) error {
 	return utils.Ok(c, nil)
 }
 
+// 게시판별 이미지 설명글 생성 옵션 변경하는 핸들러 (빈 값은 사이트 기본값 사용)
+func (h *TsboardAdminHandler) ChangeBoardDescriberHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	opt := models.ImageDescribeOption{
+		Model:    utils.CutString(strings.TrimSpace(c.FormValue("model")), 100),
+		Prompt:   utils.CutString(strings.TrimSpace(c.FormValue("prompt")), 500),
+		Language: utils.CutString(strings.TrimSpace(c.FormValue("language")), 30),
+	}
+	err = h.service.Admin.ChangeBoardDescribeOption(uint(boardUid), opt)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판별 승인 대기 옵션 변경하는 핸들러 (threshold 는 모드에 따라 글 개수 혹은 레벨)
+func (h *TsboardAdminHandler) ChangeBoardModerationHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	mode, err := strconv.ParseUint(c.FormValue("mode"), 10, 8)
+	if err != nil {
+		return utils.Err(c, "Invalid mode, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	threshold, err := strconv.ParseUint(c.FormValue("threshold"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid threshold, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Moderation.ChangeModerationOption(uint(boardUid), models.BoardModerationOption{
+		Mode:      models.ModerationMode(mode),
+		Threshold: uint(threshold),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판별 반응 이모지 옵션 변경하는 핸들러 (emojis 는 쉼표로 구분)
+func (h *TsboardAdminHandler) ChangeBoardReactionHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	useDefault, err := strconv.ParseBool(c.FormValue("useDefault"))
+	if err != nil {
+		return utils.Err(c, "Invalid useDefault, it should be 0 or 1", models.CODE_INVALID_PARAMETER)
+	}
+	multiple, err := strconv.ParseBool(c.FormValue("multiple"))
+	if err != nil {
+		return utils.Err(c, "Invalid multiple, it should be 0 or 1", models.CODE_INVALID_PARAMETER)
+	}
+	opt := models.BoardReactionOption{
+		Emojis:   utils.ParseReactionEmojis(utils.CutString(c.FormValue("emojis"), 200)),
+		Multiple: multiple,
+	}
+
+	err = h.service.Admin.ChangeBoardReactionOption(uint(boardUid), opt, useDefault)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판별 반응형 변형 이미지 생성 옵션 변경하는 핸들러 (widths 는 쉼표로 구분)
+func (h *TsboardAdminHandler) ChangeBoardVariantHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	useDefault, err := strconv.ParseBool(c.FormValue("useDefault"))
+	if err != nil {
+		return utils.Err(c, "Invalid useDefault, it should be 0 or 1", models.CODE_INVALID_PARAMETER)
+	}
+	useAvif, err := strconv.ParseBool(c.FormValue("avif"))
+	if err != nil {
+		return utils.Err(c, "Invalid avif, it should be 0 or 1", models.CODE_INVALID_PARAMETER)
+	}
+	opt := models.BoardVariantOption{
+		Widths: utils.ParseVariantWidths(utils.CutString(c.FormValue("widths"), 100)),
+		Avif:   useAvif,
+	}
+
+	err = h.service.Admin.ChangeBoardVariantOption(uint(boardUid), opt, useDefault)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 게시판 소속 그룹 변경하기 핸들러
 func (h *TsboardAdminHandler) ChangeBoardGroupHandler(c fiber.Ctx) error {
 	groupUid := c.FormValue("groupUid")
@@ -439,6 +707,21 @@ func (h *TsboardAdminHandler) DashboardStatisticLoadHandler(c fiber.Ctx) error {
 	return utils.Ok(c, statistics)
 }
 
+// 대시보드에서 게시글의 일자별 조회수 불러오는 핸들러
+func (h *TsboardAdminHandler) DashboardViewLoadHandler(c fiber.Ctx) error {
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("limit"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid limit, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	statistic := h.service.View.GetViewStatistic(uint(postUid), int(bunch))
+	return utils.Ok(c, statistic)
+}
+
 // 관리자 변경 시 후보군 출력하는 핸들러
 func (h *TsboardAdminHandler) GetAdminCandidatesHandler(c fiber.Ctx) error {
 	name, err := url.QueryUnescape(c.FormValue("name"))
@@ -479,6 +762,52 @@ func (h *TsboardAdminHandler) GroupListLoadHandler(c fiber.Ctx) error {
 	return utils.Ok(c, list)
 }
 
+// 해시태그 목록 불러오는 핸들러
+func (h *TsboardAdminHandler) HashtagListLoadHandler(c fiber.Ctx) error {
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Hashtag.GetHashtagList(models.HashtagListParameter{
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	return utils.Ok(c, result)
+}
+
+// 백그라운드 작업 목록 가져오는 핸들러 (status 가 없으면 전체)
+func (h *TsboardAdminHandler) JobListLoadHandler(c fiber.Ctx) error {
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	parameter := models.JobListParameter{
+		Page:     uint(page),
+		Bunch:    uint(bunch),
+		AnyState: true,
+	}
+	if status := c.FormValue("status"); len(status) > 0 {
+		statusNum, err := strconv.ParseUint(status, 10, 8)
+		if err != nil {
+			return utils.Err(c, "Invalid status, not a valid number", models.CODE_INVALID_PARAMETER)
+		}
+		parameter.Status = models.JobStatus(statusNum)
+		parameter.AnyState = false
+	}
+
+	result := h.service.Job.GetJobList(parameter)
+	return utils.Ok(c, result)
+}
+
 // 최근 댓글 불러오는 핸들러
 func (h *TsboardAdminHandler) LatestCommentLoadHandler(c fiber.Ctx) error {
 	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
@@ -578,6 +907,118 @@ func (h *TsboardAdminHandler) LatestPostSearchHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 해시태그를 다른 해시태그로 합치는 핸들러
+func (h *TsboardAdminHandler) MergeHashtagHandler(c fiber.Ctx) error {
+	fromUid, err := strconv.ParseUint(c.FormValue("fromUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid from uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	toUid, err := strconv.ParseUint(c.FormValue("toUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid to uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Hashtag.MergeHashtag(uint(fromUid), uint(toUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 승인 대기 목록 불러오는 핸들러
+func (h *TsboardAdminHandler) ModerationListLoadHandler(c fiber.Ctx) error {
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Moderation.GetModerationList(models.ModerationListParameter{
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	return utils.Ok(c, result)
+}
+
+// 게시판 카테고리의 이름, 설명, 글쓰기 레벨 수정하는 핸들러
+func (h *TsboardAdminHandler) ModifyBoardCategoryHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	catUid, err := strconv.ParseUint(c.FormValue("categoryUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid category uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	levelWrite, err := strconv.ParseUint(c.FormValue("levelWrite"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid level, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	name := utils.Escape(strings.TrimSpace(c.FormValue("name")))
+	if len(name) < 2 {
+		return utils.Err(c, "Invalid category name, too short", models.CODE_INVALID_PARAMETER)
+	}
+	description := utils.Escape(strings.TrimSpace(c.FormValue("description")))
+
+	err = h.service.Admin.ModifyBoardCategory(models.CategoryModifyParameter{
+		BoardUid:    uint(boardUid),
+		CategoryUid: uint(catUid),
+		Name:        utils.CutString(name, models.CATEGORY_MAX_NAME),
+		Description: utils.CutString(description, models.CATEGORY_MAX_DESCRIPTION),
+		LevelWrite:  int(levelWrite),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판의 사용자 정의 필드 수정하기 핸들러
+func (h *TsboardAdminHandler) ModifyBoardFieldHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	field, err := utils.CheckFieldDefinition(c)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_INVALID_PARAMETER)
+	}
+	if field.Uid < 1 {
+		return utils.Err(c, "Invalid field uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Admin.ModifyBoardField(uint(boardUid), field)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시판 카테고리를 다른 상위 카테고리 아래로 옮기는 핸들러 (parentUid 가 0 이면 최상위로)
+func (h *TsboardAdminHandler) MoveBoardCategoryHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	catUid, err := strconv.ParseUint(c.FormValue("categoryUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid category uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	parentUid, err := strconv.ParseUint(c.FormValue("parentUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid parent uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Admin.MoveBoardCategory(uint(boardUid), uint(catUid), uint(parentUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 게시판에 특정 카테고리 제거하기 핸들러
 func (h *TsboardAdminHandler) RemoveBoardCategoryHandler(c fiber.Ctx) error {
 	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
@@ -594,6 +1035,24 @@ func (h *TsboardAdminHandler) RemoveBoardCategoryHandler(c fiber.Ctx) error {
 	return utils.Ok(c, nil)
 }
 
+// 게시판의 사용자 정의 필드 삭제하기 핸들러
+func (h *TsboardAdminHandler) RemoveBoardFieldHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	fieldUid, err := strconv.ParseUint(c.FormValue("fieldUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid field uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Admin.RemoveBoardField(uint(boardUid), uint(fieldUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 게시판 삭제하기 핸들러
 func (h *TsboardAdminHandler) RemoveBoardHandler(c fiber.Ctx) error {
 	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
@@ -610,26 +1069,28 @@ func (h *TsboardAdminHandler) RemoveBoardHandler(c fiber.Ctx) error {
 
 // 댓글 삭제하기 핸들러
 func (h *TsboardAdminHandler) RemoveCommentHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
 	targets := strings.Split(c.FormValue("targets"), ",")
 	for _, target := range targets {
 		commentUid, err := strconv.ParseUint(target, 10, 32)
 		if err != nil {
 			return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 		}
-		h.service.Admin.RemoveComment(uint(commentUid))
+		h.service.Admin.RemoveComment(uint(commentUid), uint(actionUserUid))
 	}
 	return utils.Ok(c, nil)
 }
 
 // 게시글 삭제하기 핸들러
 func (h *TsboardAdminHandler) RemovePostHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
 	targets := strings.Split(c.FormValue("targets"), ",")
 	for _, target := range targets {
 		postUid, err := strconv.ParseUint(target, 10, 32)
 		if err != nil {
 			return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 		}
-		h.service.Admin.RemovePost(uint(postUid))
+		h.service.Admin.RemovePost(uint(postUid), uint(actionUserUid))
 	}
 	return utils.Ok(c, nil)
 }
@@ -648,6 +1109,80 @@ func (h *TsboardAdminHandler) RemoveGroupHandler(c fiber.Ctx) error {
 	return utils.Ok(c, nil)
 }
 
+// 해시태그 삭제하는 핸들러
+func (h *TsboardAdminHandler) RemoveHashtagHandler(c fiber.Ctx) error {
+	hashtagUid, err := strconv.ParseUint(c.FormValue("hashtagUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid hashtag uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Hashtag.RemoveHashtag(uint(hashtagUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 스팸 필터의 금지어 규칙 삭제하는 핸들러
+func (h *TsboardAdminHandler) RemoveSpamRuleHandler(c fiber.Ctx) error {
+	ruleUid, err := strconv.ParseUint(c.FormValue("ruleUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid rule uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Spam.RemoveSpamRule(uint(ruleUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 같은 상위 카테고리 아래의 카테고리 순서 바꾸는 핸들러 (order 는 쉼표로 구분된 카테고리 번호들)
+func (h *TsboardAdminHandler) ReorderBoardCategoryHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	parentUid, err := strconv.ParseUint(c.FormValue("parentUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid parent uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	order := make([]uint, 0)
+	for _, uidStr := range strings.Split(c.FormValue("order"), ",") {
+		uid, err := strconv.ParseUint(strings.TrimSpace(uidStr), 10, 32)
+		if err != nil {
+			return utils.Err(c, "Invalid order, not a valid list of category uids", models.CODE_INVALID_PARAMETER)
+		}
+		order = append(order, uint(uid))
+	}
+
+	err = h.service.Admin.ReorderBoardCategory(models.CategoryReorderParameter{
+		BoardUid:  uint(boardUid),
+		ParentUid: uint(parentUid),
+		Order:     order,
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 해시태그 이름 변경하는 핸들러
+func (h *TsboardAdminHandler) RenameHashtagHandler(c fiber.Ctx) error {
+	hashtagUid, err := strconv.ParseUint(c.FormValue("hashtagUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid hashtag uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	name := utils.Escape(strings.TrimSpace(c.FormValue("name")))
+
+	err = h.service.Hashtag.RenameHashtag(uint(hashtagUid), name)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 신고 목록 가져오기 핸들러
 func (h *TsboardAdminHandler) ReportListLoadHandler(c fiber.Ctx) error {
 	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
@@ -705,6 +1240,88 @@ func (h *TsboardAdminHandler) ReportListSearchHandler(c fiber.Ctx) error {
 	return utils.Ok(c, reports)
 }
 
+// 휴지통 항목을 되살리는 핸들러
+func (h *TsboardAdminHandler) RestoreTrashHandler(c fiber.Ctx) error {
+	trashUid, err := strconv.ParseUint(c.FormValue("trashUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid trash uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Trash.RestoreTrash(uint(trashUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 실패한 백그라운드 작업을 다시 시도하는 핸들러
+func (h *TsboardAdminHandler) RetryJobHandler(c fiber.Ctx) error {
+	jobUid, err := strconv.ParseUint(c.FormValue("jobUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid job uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Job.RetryJob(uint(jobUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 휴지통 항목을 바로 완전히 삭제하는 핸들러
+func (h *TsboardAdminHandler) PurgeTrashHandler(c fiber.Ctx) error {
+	trashUid, err := strconv.ParseUint(c.FormValue("trashUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid trash uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Trash.PurgeTrash(uint(trashUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 해시태그 사용 횟수를 다시 계산하고 쓰이지 않는 태그 정리하는 핸들러
+func (h *TsboardAdminHandler) RecountHashtagHandler(c fiber.Ctx) error {
+	result := h.service.Hashtag.RecountHashtags()
+	return utils.Ok(c, result)
+}
+
+// 승인 대기중인 게시글 혹은 댓글을 반려하는 핸들러 (reason 은 작성자에게 전달됨)
+func (h *TsboardAdminHandler) RejectModerationHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	moderationUid, err := strconv.ParseUint(c.FormValue("moderationUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid moderation uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	reason := utils.CutString(utils.Escape(strings.TrimSpace(c.FormValue("reason"))), models.MODERATION_MAX_REASON)
+
+	err = h.service.Moderation.Reject(uint(moderationUid), uint(actionUserUid), reason)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 기존 이미지들의 설명글을 다시 생성하는 핸들러 (postUid 가 0이면 게시판 전체)
+func (h *TsboardAdminHandler) RegenerateDescriptionHandler(c fiber.Ctx) error {
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	count, err := h.service.Admin.RegenerateImageDescriptions(uint(boardUid), uint(postUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, count)
+}
+
 // 게시판 아이디 중복 방지를 위해 입력된 아이디와 유사한 목록 출력하는 핸들러
 func (h *TsboardAdminHandler) ShowSimilarBoardIdHandler(c fiber.Ctx) error {
 	boardId := c.FormValue("id")
@@ -729,6 +1346,48 @@ func (h *TsboardAdminHandler) ShowSimilarGroupIdHandler(c fiber.Ctx) error {
 	return utils.Ok(c, list)
 }
 
+// 스팸 검사 기록 불러오는 핸들러
+func (h *TsboardAdminHandler) SpamLogListLoadHandler(c fiber.Ctx) error {
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Spam.GetSpamLogList(models.SpamLogListParameter{
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	return utils.Ok(c, result)
+}
+
+// 스팸 필터의 금지어 규칙 목록 불러오는 핸들러
+func (h *TsboardAdminHandler) SpamRuleLoadHandler(c fiber.Ctx) error {
+	result := h.service.Spam.GetSpamRules()
+	return utils.Ok(c, result)
+}
+
+// 휴지통 목록 가져오는 핸들러
+func (h *TsboardAdminHandler) TrashListLoadHandler(c fiber.Ctx) error {
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Trash.GetTrashList(models.TrashListParameter{
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	return utils.Ok(c, result)
+}
+
 // 게시판에서 카테고리 기능 사용 or 사용 해제하는 핸들러
 func (h *TsboardAdminHandler) UseBoardCategoryHandler(c fiber.Ctx) error {
 	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
@@ -834,3 +1493,39 @@ func (h *TsboardAdminHandler) UserListLoadHandler(c fiber.Ctx) error {
 	result := h.service.Admin.GetUserList(parameter)
 	return utils.Ok(c, result)
 }
+
+// 사용자의 저장 공간 사용량 및 한도 가져오는 핸들러
+func (h *TsboardAdminHandler) UserStorageLoadHandler(c fiber.Ctx) error {
+	userUid, err := strconv.ParseUint(c.FormValue("userUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid user uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.User.GetUserStorage(uint(userUid))
+	return utils.Ok(c, result)
+}
+
+// 사용자의 저장 공간 한도를 개별 지정하는 핸들러
+func (h *TsboardAdminHandler) UserStorageModifyHandler(c fiber.Ctx) error {
+	userUid, err := strconv.ParseUint(c.FormValue("userUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid user uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	useDefault, err := strconv.ParseBool(c.FormValue("useDefault"))
+	if err != nil {
+		return utils.Err(c, "Invalid useDefault, it should be 0 or 1", models.CODE_INVALID_PARAMETER)
+	}
+	var quota uint64
+	if !useDefault {
+		quota, err = strconv.ParseUint(c.FormValue("quota"), 10, 64)
+		if err != nil {
+			return utils.Err(c, "Invalid quota, not a valid number", models.CODE_INVALID_PARAMETER)
+		}
+	}
+
+	err = h.service.Admin.UpdateUserStorageQuota(uint(userUid), quota, useDefault)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
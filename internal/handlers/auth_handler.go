@@ -123,6 +123,9 @@ func (h *TsboardAuthHandler) SigninHandler(c fiber.Ctx) error {
 
 	user := h.service.Auth.Signin(id, pw)
 	if user.Uid < 1 {
+		if h.service.Auth.IsPasswordResetRequired(id) {
+			return utils.Err(c, "Password reset required, this account was imported from another board", models.CODE_FAILED_OPERATION)
+		}
 		return utils.Err(c, "Unable to get an information, invalid ID or password", models.CODE_FAILED_OPERATION)
 	}
 
@@ -49,13 +49,18 @@ func (h *TsboardBlogHandler) BlogRssLoadHandler(c fiber.Ctx) error {
 			return c.SendString(`<error>Unable to find the information of writer.</error>`)
 		}
 
+		category := ""
+		if series := h.service.Blog.GetSeriesName(post.Uid); len(series) > 0 {
+			category = fmt.Sprintf("\n          <category>%s</category>", series)
+		}
+
 		t := time.UnixMilli(int64(post.Submitted))
 		pubDate := t.Format(time.RFC1123)
 		item := fmt.Sprintf(`<item>
           <title>%s</title>
           <link>%s%s/blog/%s/%d</link>
           <description>%s</description>
-          <author>%s</author>
+          <author>%s</author>%s
           <pubDate>%s</pubDate>
           <guid isPermaLink="true">%s%s/blog/%s/%d</guid>
         </item>`,
@@ -63,6 +68,7 @@ func (h *TsboardBlogHandler) BlogRssLoadHandler(c fiber.Ctx) error {
 			configs.Env.URL, configs.Env.URLPrefix, id, post.Uid,
 			utils.Unescape(post.Content),
 			writer.Name,
+			category,
 			pubDate,
 			configs.Env.URL, configs.Env.URLPrefix, id, post.Uid,
 		)
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/sirini/goapi/internal/services"
@@ -13,13 +14,31 @@ import (
 type BoardHandler interface {
 	BoardListHandler(c fiber.Ctx) error
 	BoardViewHandler(c fiber.Ctx) error
+	CreateSeriesHandler(c fiber.Ctx) error
 	DownloadHandler(c fiber.Ctx) error
+	ExportDownloadHandler(c fiber.Ctx) error
+	ExportHandler(c fiber.Ctx) error
+	ExportStatusHandler(c fiber.Ctx) error
 	GalleryListHandler(c fiber.Ctx) error
 	GalleryLoadPhotoHandler(c fiber.Ctx) error
 	LikePostHandler(c fiber.Ctx) error
 	ListForMoveHandler(c fiber.Ctx) error
+	ModifySeriesHandler(c fiber.Ctx) error
 	MovePostHandler(c fiber.Ctx) error
+	PollResultHandler(c fiber.Ctx) error
+	PostReactionHandler(c fiber.Ctx) error
+	PostReactionUsersHandler(c fiber.Ctx) error
+	RelatedPostListHandler(c fiber.Ctx) error
 	RemovePostHandler(c fiber.Ctx) error
+	RemoveSeriesHandler(c fiber.Ctx) error
+	RestorePostHandler(c fiber.Ctx) error
+	RestoreRevisionHandler(c fiber.Ctx) error
+	RevisionDiffHandler(c fiber.Ctx) error
+	RevisionListHandler(c fiber.Ctx) error
+	SeriesListHandler(c fiber.Ctx) error
+	SeriesViewHandler(c fiber.Ctx) error
+	UpdateSeriesPostsHandler(c fiber.Ctx) error
+	VotePollHandler(c fiber.Ctx) error
 }
 
 type TsboardBoardHandler struct {
@@ -72,6 +91,7 @@ func (h *TsboardBoardHandler) BoardListHandler(c fiber.Ctx) error {
 	parameter.UserUid = uint(actionUserUid)
 	parameter.Page = uint(page)
 	parameter.Direction = models.Paging(paging)
+	parameter.Filters = utils.ParseFieldFilters(c.FormValue("filters"), h.service.Board.GetBoardFields(parameter.BoardUid))
 
 	result, err := h.service.Board.GetListItem(parameter)
 	if err != nil {
@@ -107,17 +127,45 @@ func (h *TsboardBoardHandler) BoardViewHandler(c fiber.Ctx) error {
 			PostUid:  uint(postUid),
 			UserUid:  uint(actionUserUid),
 		},
-		UpdateHit: updateHit,
-		Limit:     uint(limit),
+		Limit: uint(limit),
 	}
 
 	result, err := h.service.Board.GetViewItem(parameter)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
+	if updateHit && result.Post.Status != models.CONTENT_PENDING {
+		h.service.View.CountView(models.ViewCountParameter{
+			BoardUid:  boardUid,
+			PostUid:   uint(postUid),
+			UserUid:   uint(actionUserUid),
+			Ip:        c.IP(),
+			UserAgent: c.Get("User-Agent"),
+		})
+	}
 	return utils.Ok(c, result)
 }
 
+// 게시판에 새 시리즈 만들기 핸들러
+func (h *TsboardBoardHandler) CreateSeriesHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	seriesUid, err := h.service.Series.CreateSeries(models.SeriesParameter{
+		BoardUid: uint(boardUid),
+		UserUid:  uint(actionUserUid),
+		Name:     utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("name")), models.SERIES_MAX_NAME)),
+		Info:     utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("info")), models.SERIES_MAX_INFO)),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, seriesUid)
+}
+
 // 첨부파일 다운로드 핸들러
 func (h *TsboardBoardHandler) DownloadHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -136,6 +184,62 @@ func (h *TsboardBoardHandler) DownloadHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 내보낸 압축 파일 다운로드 핸들러 (게시판 관리자만 가능)
+func (h *TsboardBoardHandler) ExportDownloadHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	exportUid, err := strconv.ParseUint(c.FormValue("exportUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid export uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	result, err := h.service.Export.GetArchive(uint(exportUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return c.Download(result.Path, result.Name)
+}
+
+// 게시글 혹은 게시판 전체 내보내기 요청 핸들러 (postUid 가 없으면 게시판 전체)
+func (h *TsboardBoardHandler) ExportHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		postUid = 0
+	}
+	format, isValid := utils.ParseExportFormat(c.FormValue("format"))
+	if !isValid {
+		return utils.Err(c, "Invalid format, must be markdown or json", models.CODE_INVALID_PARAMETER)
+	}
+
+	exportUid, err := h.service.Export.RequestExport(models.ExportInsertParameter{
+		BoardUid: uint(boardUid),
+		PostUid:  uint(postUid),
+		UserUid:  uint(actionUserUid),
+		Format:   format,
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, exportUid)
+}
+
+// 내보내기 진행 상태 가져오기 핸들러
+func (h *TsboardBoardHandler) ExportStatusHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	exportUid, err := strconv.ParseUint(c.FormValue("exportUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid export uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	result, err := h.service.Export.GetExport(uint(exportUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
 // 갤러리 리스트 핸들러
 func (h *TsboardBoardHandler) GalleryListHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -177,6 +281,7 @@ func (h *TsboardBoardHandler) GalleryListHandler(c fiber.Ctx) error {
 	parameter.UserUid = uint(actionUserUid)
 	parameter.Page = uint(page)
 	parameter.Direction = models.Paging(paging)
+	parameter.Filters = utils.ParseFieldFilters(c.FormValue("filters"), h.service.Board.GetBoardFields(parameter.BoardUid))
 
 	result := h.service.Board.GetGalleryList(parameter)
 	return utils.Ok(c, result)
@@ -242,6 +347,26 @@ func (h *TsboardBoardHandler) ListForMoveHandler(c fiber.Ctx) error {
 	return utils.Ok(c, boards)
 }
 
+// 시리즈 이름, 설명 수정하기 핸들러
+func (h *TsboardBoardHandler) ModifySeriesHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	seriesUid, err := strconv.ParseUint(c.FormValue("seriesUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid series uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Series.ModifySeries(models.SeriesParameter{
+		SeriesUid: uint(seriesUid),
+		UserUid:   uint(actionUserUid),
+		Name:      utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("name")), models.SERIES_MAX_NAME)),
+		Info:      utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("info")), models.SERIES_MAX_INFO)),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 게시글 이동하기 핸들러
 func (h *TsboardBoardHandler) MovePostHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -269,6 +394,117 @@ func (h *TsboardBoardHandler) MovePostHandler(c fiber.Ctx) error {
 	return utils.Ok(c, nil)
 }
 
+// 게시글에 등록된 투표 결과 가져오기 핸들러
+func (h *TsboardBoardHandler) PollResultHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Poll.GetPollResult(uint(boardUid), uint(postUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시글에 이모지 반응 남기기/취소하기 핸들러
+func (h *TsboardBoardHandler) PostReactionHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Reaction.React(models.ReactionParameter{
+		BoardUid:  uint(boardUid),
+		TargetUid: uint(postUid),
+		UserUid:   uint(actionUserUid),
+		Table:     models.TABLE_POST_REACTION,
+		Emoji:     c.FormValue("emoji"),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시글에 반응을 남긴 회원 목록 가져오기 핸들러 (emoji 가 없으면 전체)
+func (h *TsboardBoardHandler) PostReactionUsersHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil || page < 1 {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil || bunch < 1 || bunch > 100 {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Reaction.GetReactionUsers(models.ReactionUserParameter{
+		ReactionParameter: models.ReactionParameter{
+			BoardUid:  uint(boardUid),
+			TargetUid: uint(postUid),
+			UserUid:   uint(actionUserUid),
+			Table:     models.TABLE_POST_REACTION,
+			Emoji:     c.FormValue("emoji"),
+		},
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시글과 관련된 글 목록 가져오기 핸들러
+func (h *TsboardBoardHandler) RelatedPostListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	limit, err := strconv.ParseUint(c.FormValue("limit"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid limit, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Board.GetRelatedPosts(models.BoardViewParameter{
+		BoardViewCommonParameter: models.BoardViewCommonParameter{
+			BoardUid: uint(boardUid),
+			PostUid:  uint(postUid),
+			UserUid:  uint(actionUserUid),
+		},
+		Limit: uint(limit),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
 // 게시글 삭제하기 핸들러
 func (h *TsboardBoardHandler) RemovePostHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -284,3 +520,196 @@ func (h *TsboardBoardHandler) RemovePostHandler(c fiber.Ctx) error {
 	h.service.Board.RemovePost(uint(boardUid), uint(postUid), uint(actionUserUid))
 	return utils.Ok(c, nil)
 }
+
+// 시리즈 삭제하기 핸들러
+func (h *TsboardBoardHandler) RemoveSeriesHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	seriesUid, err := strconv.ParseUint(c.FormValue("seriesUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid series uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	if err := h.service.Series.RemoveSeries(uint(seriesUid), uint(actionUserUid)); err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 휴지통에서 게시글 되살리기 핸들러
+func (h *TsboardBoardHandler) RestorePostHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Trash.RestorePost(uint(boardUid), uint(postUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시글을 이전 수정 이력으로 복원하기 핸들러
+func (h *TsboardBoardHandler) RestoreRevisionHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	revisionUid, err := strconv.ParseUint(c.FormValue("revisionUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid revision uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Board.RestoreRevision(uint(boardUid), uint(postUid), uint(revisionUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 게시글의 두 수정 이력 비교하기 핸들러
+func (h *TsboardBoardHandler) RevisionDiffHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	fromUid, err := strconv.ParseUint(c.FormValue("from"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid from, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	toUid, err := strconv.ParseUint(c.FormValue("to"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid to, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Board.GetRevisionDiff(uint(boardUid), uint(postUid), uint(fromUid), uint(toUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시글 수정 이력 목록 가져오기 핸들러
+func (h *TsboardBoardHandler) RevisionListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Board.GetRevisions(uint(boardUid), uint(postUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_NO_PERMISSION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시판에 등록된 시리즈 목록 가져오기 핸들러
+func (h *TsboardBoardHandler) SeriesListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Series.GetSeriesList(uint(boardUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 시리즈 정보와 속한 게시글들 가져오기 핸들러
+func (h *TsboardBoardHandler) SeriesViewHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	seriesUid, err := strconv.ParseUint(c.FormValue("seriesUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid series uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Series.GetSeriesView(uint(seriesUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 시리즈에 속할 게시글들을 순서대로 지정하기 핸들러 (게시글 번호들은 쉼표로 구분)
+func (h *TsboardBoardHandler) UpdateSeriesPostsHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	seriesUid, err := strconv.ParseUint(c.FormValue("seriesUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid series uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUids := make([]uint, 0)
+	if posts := strings.TrimSpace(c.FormValue("postUids")); len(posts) > 0 {
+		for _, post := range strings.Split(posts, ",") {
+			postUid, err := strconv.ParseUint(strings.TrimSpace(post), 10, 32)
+			if err != nil {
+				return utils.Err(c, "Invalid post uids, not a valid number", models.CODE_INVALID_PARAMETER)
+			}
+			postUids = append(postUids, uint(postUid))
+		}
+	}
+
+	err = h.service.Series.UpdateSeriesPosts(models.SeriesPostParameter{
+		SeriesUid: uint(seriesUid),
+		UserUid:   uint(actionUserUid),
+		PostUids:  postUids,
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 투표하기 핸들러 (선택지 번호들은 쉼표로 구분)
+func (h *TsboardBoardHandler) VotePollHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	optionUids := make([]uint, 0)
+	for _, option := range strings.Split(c.FormValue("options"), ",") {
+		optionUid, err := strconv.ParseUint(option, 10, 32)
+		if err != nil {
+			return utils.Err(c, "Invalid options, not a valid number", models.CODE_INVALID_PARAMETER)
+		}
+		optionUids = append(optionUids, uint(optionUid))
+	}
+
+	err = h.service.Poll.Vote(models.PollVoteParameter{
+		BoardUid:   uint(boardUid),
+		PostUid:    uint(postUid),
+		UserUid:    uint(actionUserUid),
+		OptionUids: optionUids,
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
@@ -11,10 +11,13 @@ import (
 
 type CommentHandler interface {
 	CommentListHandler(c fiber.Ctx) error
+	CommentReactionHandler(c fiber.Ctx) error
+	CommentReactionUsersHandler(c fiber.Ctx) error
 	LikeCommentHandler(c fiber.Ctx) error
 	ModifyCommentHandler(c fiber.Ctx) error
 	RemoveCommentHandler(c fiber.Ctx) error
 	ReplyCommentHandler(c fiber.Ctx) error
+	RestoreCommentHandler(c fiber.Ctx) error
 	WriteCommentHandler(c fiber.Ctx) error
 }
 
@@ -68,6 +71,68 @@ func (h *TsboardCommentHandler) CommentListHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 댓글에 이모지 반응 남기기/취소하기 핸들러
+func (h *TsboardCommentHandler) CommentReactionHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	commentUid, err := strconv.ParseUint(c.FormValue("commentUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid comment uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Reaction.React(models.ReactionParameter{
+		BoardUid:  uint(boardUid),
+		TargetUid: uint(commentUid),
+		UserUid:   uint(actionUserUid),
+		Table:     models.TABLE_COMMENT_REACTION,
+		Emoji:     c.FormValue("emoji"),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 댓글에 반응을 남긴 회원 목록 가져오기 핸들러 (emoji 가 없으면 전체)
+func (h *TsboardCommentHandler) CommentReactionUsersHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	commentUid, err := strconv.ParseUint(c.FormValue("commentUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid comment uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil || page < 1 {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil || bunch < 1 || bunch > 100 {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Reaction.GetReactionUsers(models.ReactionUserParameter{
+		ReactionParameter: models.ReactionParameter{
+			BoardUid:  uint(boardUid),
+			TargetUid: uint(commentUid),
+			UserUid:   uint(actionUserUid),
+			Table:     models.TABLE_COMMENT_REACTION,
+			Emoji:     c.FormValue("emoji"),
+		},
+		Page:  uint(page),
+		Bunch: uint(bunch),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
 // 댓글에 좋아요 누르기 핸들러
 func (h *TsboardCommentHandler) LikeCommentHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -104,13 +169,16 @@ func (h *TsboardCommentHandler) ModifyCommentHandler(c fiber.Ctx) error {
 		return utils.Err(c, "Invalid modify target uid, not a valid number", models.CODE_INVALID_PARAMETER)
 	}
 
-	err = h.service.Comment.Modify(models.CommentModifyParameter{
+	mention, err := h.service.Comment.Modify(models.CommentModifyParameter{
 		CommentWriteParameter: parameter,
 		CommentUid:            uint(commentUid),
 	})
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
+
+	h.service.Mention.SaveMentions(mention)
+	h.service.Unfurl.UnfurlContent(mention.Content)
 	return utils.Ok(c, nil)
 }
 
@@ -144,14 +212,36 @@ func (h *TsboardCommentHandler) ReplyCommentHandler(c fiber.Ctx) error {
 		return utils.Err(c, "Invalid reply target uid, not a valid number", models.CODE_INVALID_PARAMETER)
 	}
 
-	insertId, err := h.service.Comment.Reply(models.CommentReplyParameter{
+	mention, err := h.service.Comment.Reply(models.CommentReplyParameter{
 		CommentWriteParameter: parameter,
 		ReplyTargetUid:        uint(replyTargetUid),
 	})
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
-	return utils.Ok(c, insertId)
+
+	h.service.Mention.SaveMentions(mention)
+	h.service.Unfurl.UnfurlContent(mention.Content)
+	return utils.Ok(c, mention.CommentUid)
+}
+
+// 휴지통에서 댓글 되살리기 핸들러
+func (h *TsboardCommentHandler) RestoreCommentHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	commentUid, err := strconv.ParseUint(c.FormValue("commentUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid comment uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Trash.RestoreComment(uint(boardUid), uint(commentUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
 }
 
 // 새 댓글 작성하기 핸들러
@@ -161,9 +251,12 @@ func (h *TsboardCommentHandler) WriteCommentHandler(c fiber.Ctx) error {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
 
-	insertId, err := h.service.Comment.Write(parameter)
+	mention, err := h.service.Comment.Write(parameter)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
-	return utils.Ok(c, insertId)
+
+	h.service.Mention.SaveMentions(mention)
+	h.service.Unfurl.UnfurlContent(mention.Content)
+	return utils.Ok(c, mention.CommentUid)
 }
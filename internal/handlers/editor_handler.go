@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/sirini/goapi/internal/configs"
@@ -13,12 +14,20 @@ import (
 
 type EditorHandler interface {
 	GetEditorConfigHandler(c fiber.Ctx) error
+	LoadDraftHandler(c fiber.Ctx) error
+	LoadDraftListHandler(c fiber.Ctx) error
 	LoadInsertImageHandler(c fiber.Ctx) error
+	LoadJobStatusHandler(c fiber.Ctx) error
 	LoadPostHandler(c fiber.Ctx) error
 	ModifyPostHandler(c fiber.Ctx) error
 	RemoveInsertImageHandler(c fiber.Ctx) error
 	RemoveAttachedFileHandler(c fiber.Ctx) error
+	RemoveDraftHandler(c fiber.Ctx) error
+	RemoveDraftFileHandler(c fiber.Ctx) error
+	SaveDraftHandler(c fiber.Ctx) error
 	SuggestionHashtagHandler(c fiber.Ctx) error
+	SuggestionMentionHandler(c fiber.Ctx) error
+	UnfurlLinkHandler(c fiber.Ctx) error
 	UploadInsertImageHandler(c fiber.Ctx) error
 	WritePostHandler(c fiber.Ctx) error
 }
@@ -41,6 +50,33 @@ func (h *TsboardEditorHandler) GetEditorConfigHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 임시저장한 글 불러오기 핸들러
+func (h *TsboardEditorHandler) LoadDraftHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	draftUid, err := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid draft uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Draft.LoadDraft(uint(draftUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, result)
+}
+
+// 게시판에 임시저장한 글 목록 불러오기 핸들러
+func (h *TsboardEditorHandler) LoadDraftListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Draft.GetDraftList(uint(boardUid), uint(actionUserUid))
+	return utils.Ok(c, result)
+}
+
 // 게시글에 내가 삽입한 이미지들 불러오기 핸들러
 func (h *TsboardEditorHandler) LoadInsertImageHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -70,6 +106,25 @@ func (h *TsboardEditorHandler) LoadInsertImageHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 게시글에 첨부한 이미지들의 후처리 작업 상태 가져오기
+func (h *TsboardEditorHandler) LoadJobStatusHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	jobs, err := h.service.Job.GetPostJobs(uint(boardUid), uint(postUid), uint(actionUserUid))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_NO_PERMISSION)
+	}
+	return utils.Ok(c, jobs)
+}
+
 // 글 수정을 위해 내가 작성한 게시글 정보 불러오기
 func (h *TsboardEditorHandler) LoadPostHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -95,18 +150,25 @@ func (h *TsboardEditorHandler) ModifyPostHandler(c fiber.Ctx) error {
 	if err != nil {
 		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
 	}
-	parameter, err := utils.CheckWriteParameters(c)
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	storageLeft := h.service.User.GetUserStorageLeft(uint(actionUserUid))
+	boardUid, _ := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	fields := h.service.Board.GetBoardFields(uint(boardUid))
+	parameter, err := utils.CheckWriteParameters(c, storageLeft, fields)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
 
-	err = h.service.Board.ModifyPost(models.EditorModifyParameter{
+	mention, err := h.service.Board.ModifyPost(models.EditorModifyParameter{
 		EditorWriteParameter: parameter,
 		PostUid:              uint(postUid),
 	})
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
+
+	h.service.Mention.SaveMentions(mention)
+	h.service.Unfurl.UnfurlContent(mention.Content)
 	return utils.Ok(c, nil)
 }
 
@@ -162,6 +224,92 @@ func (h *TsboardEditorHandler) SuggestionHashtagHandler(c fiber.Ctx) error {
 	return utils.Ok(c, suggestions)
 }
 
+// 언급할 회원 이름 추천하기 핸들러
+func (h *TsboardEditorHandler) SuggestionMentionHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	input, err := url.QueryUnescape(c.FormValue("name"))
+	if err != nil {
+		return utils.Err(c, "Invalid name", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("limit"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid limit, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	suggestions := h.service.Mention.GetSuggestionNames(utils.Escape(input), uint(actionUserUid), uint(bunch))
+	return utils.Ok(c, suggestions)
+}
+
+// 링크 미리보기 가져오기 핸들러
+func (h *TsboardEditorHandler) UnfurlLinkHandler(c fiber.Ctx) error {
+	link, err := url.QueryUnescape(c.FormValue("url"))
+	if err != nil {
+		return utils.Err(c, "Invalid url", models.CODE_INVALID_PARAMETER)
+	}
+	link = strings.TrimSpace(link)
+	target, err := url.ParseRequestURI(link)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || len(link) > 2000 {
+		return utils.Err(c, "Invalid url, only http(s) links are allowed", models.CODE_INVALID_PARAMETER)
+	}
+
+	preview, ok := h.service.Unfurl.Unfurl(link)
+	if !ok {
+		return utils.Err(c, "Unable to get a preview of the link", models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, preview)
+}
+
+// 임시저장한 글 삭제하기 핸들러
+func (h *TsboardEditorHandler) RemoveDraftHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	draftUid, err := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid draft uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	if err := h.service.Draft.RemoveDraft(uint(draftUid), uint(actionUserUid)); err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 임시저장한 글에 미리 올려둔 첨부파일 삭제하기 핸들러
+func (h *TsboardEditorHandler) RemoveDraftFileHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	draftUid, err := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid draft uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	fileUid, err := strconv.ParseUint(c.FormValue("fileUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid file uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	if err := h.service.Draft.RemoveStagedFile(uint(draftUid), uint(fileUid), uint(actionUserUid)); err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 작성 중인 글을 임시저장하기 핸들러 (자동 저장 포함)
+func (h *TsboardEditorHandler) SaveDraftHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	storageLeft := h.service.User.GetUserStorageLeft(uint(actionUserUid))
+	parameter, err := utils.CheckDraftParameters(c, storageLeft)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_INVALID_PARAMETER)
+	}
+
+	result, err := h.service.Draft.SaveDraft(parameter)
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	if result.Conflict {
+		return utils.ErrWithResult(c, "The draft has been saved elsewhere, please reload it first", models.CODE_CONFLICT, result)
+	}
+	return utils.Ok(c, result)
+}
+
 // 게시글 내용에 이미지 삽입하는 핸들러
 func (h *TsboardEditorHandler) UploadInsertImageHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -186,8 +334,13 @@ func (h *TsboardEditorHandler) UploadInsertImageHandler(c fiber.Ctx) error {
 	if totalFileSize > fileSizeLimit {
 		return utils.Err(c, "Uploaded files exceed size limitation", models.CODE_EXCEED_SIZE)
 	}
+	storageLeft := h.service.User.GetUserStorageLeft(uint(actionUserUid))
+	if storageLeft != models.STORAGE_UNLIMITED && totalFileSize > storageLeft {
+		return utils.Err(c, "Uploaded files exceed your storage quota", models.CODE_EXCEED_SIZE)
+	}
 
-	uploadedImages, err := h.service.Board.UploadInsertImage(uint(boardUid), uint(actionUserUid), images)
+	draftUid, _ := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	uploadedImages, err := h.service.Board.UploadInsertImage(uint(boardUid), uint(actionUserUid), uint(draftUid), images)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
@@ -196,14 +349,21 @@ func (h *TsboardEditorHandler) UploadInsertImageHandler(c fiber.Ctx) error {
 
 // 게시글 작성하기 핸들러
 func (h *TsboardEditorHandler) WritePostHandler(c fiber.Ctx) error {
-	parameter, err := utils.CheckWriteParameters(c)
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	storageLeft := h.service.User.GetUserStorageLeft(uint(actionUserUid))
+	boardUid, _ := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	fields := h.service.Board.GetBoardFields(uint(boardUid))
+	parameter, err := utils.CheckWriteParameters(c, storageLeft, fields)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
 
-	postUid, err := h.service.Board.WritePost(parameter)
+	mention, err := h.service.Board.WritePost(parameter)
 	if err != nil {
 		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
 	}
-	return utils.Ok(c, postUid)
+
+	h.service.Mention.SaveMentions(mention)
+	h.service.Unfurl.UnfurlContent(mention.Content)
+	return utils.Ok(c, mention.PostUid)
 }
@@ -24,6 +24,8 @@ type HomeHandler interface {
 	LoadMainPageHandler(c fiber.Ctx) error
 	LoadPostsByIdHandler(c fiber.Ctx) error
 	LoadSitemapHandler(c fiber.Ctx) error
+	LoadTagCloudHandler(c fiber.Ctx) error
+	LoadTagPostsHandler(c fiber.Ctx) error
 }
 
 type TsboardHomeHandler struct {
@@ -193,3 +195,60 @@ func (h *TsboardHomeHandler) LoadSitemapHandler(c fiber.Ctx) error {
 	}
 	return nil
 }
+
+// 최근 며칠 동안 많이 사용된 해시태그 목록 가져오는 핸들러
+func (h *TsboardHomeHandler) LoadTagCloudHandler(c fiber.Ctx) error {
+	days, err := strconv.ParseUint(c.FormValue("days"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid days, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Hashtag.GetTrendingTags(uint(days), uint(bunch))
+	return utils.Ok(c, result)
+}
+
+// 해시태그 정보와 이 태그가 달린 전체 게시판의 최근 게시글들 가져오는 핸들러
+func (h *TsboardHomeHandler) LoadTagPostsHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	name, err := url.QueryUnescape(c.FormValue("name"))
+	if err != nil {
+		return utils.Err(c, "Invalid name, failed to unescape", models.CODE_INVALID_PARAMETER)
+	}
+	sinceUid64, err := strconv.ParseUint(c.FormValue("sinceUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid since uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil || bunch < 1 || bunch > 100 {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	tag, err := h.service.Hashtag.GetHashtagDetail(utils.Escape(name))
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	sinceUid := uint(sinceUid64)
+	if sinceUid < 1 {
+		sinceUid = h.service.Board.GetMaxUid() + 1
+	}
+	posts, err := h.service.Home.GetLatestPosts(models.HomePostParameter{
+		SinceUid: sinceUid,
+		Bunch:    uint(bunch),
+		Option:   models.SEARCH_TAG,
+		Keyword:  tag.Name,
+		UserUid:  uint(actionUserUid),
+		BoardUid: 0,
+	})
+	if err != nil {
+		return utils.Err(c, "Failed to get posts with this hashtag", models.CODE_FAILED_OPERATION)
+	}
+
+	return utils.Ok(c, models.HashtagDetailResult{
+		Tag:   tag,
+		Posts: posts,
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/sirini/goapi/internal/services"
@@ -10,10 +11,15 @@ import (
 )
 
 type UserHandler interface {
+	AddBookmarkHandler(c fiber.Ctx) error
+	BookmarkFolderListHandler(c fiber.Ctx) error
+	BookmarkListHandler(c fiber.Ctx) error
 	ChangePasswordHandler(c fiber.Ctx) error
 	LoadUserInfoHandler(c fiber.Ctx) error
 	LoadUserPermissionHandler(c fiber.Ctx) error
+	LoadUserStorageHandler(c fiber.Ctx) error
 	ManageUserPermissionHandler(c fiber.Ctx) error
+	RemoveBookmarkHandler(c fiber.Ctx) error
 	ReportUserHandler(c fiber.Ctx) error
 }
 
@@ -26,6 +32,59 @@ func NewTsboardUserHandler(service *services.Service) *TsboardUserHandler {
 	return &TsboardUserHandler{service: service}
 }
 
+// 게시글을 북마크에 추가하기 (이미 추가한 글이면 폴더와 메모 변경)
+func (h *TsboardUserHandler) AddBookmarkHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid board uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	err = h.service.Bookmark.AddBookmark(models.BookmarkParameter{
+		BoardUid: uint(boardUid),
+		PostUid:  uint(postUid),
+		UserUid:  uint(actionUserUid),
+		Folder:   utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("folder")), models.BOOKMARK_MAX_FOLDER)),
+		Note:     utils.Escape(utils.CutString(strings.TrimSpace(c.FormValue("note")), models.BOOKMARK_MAX_NOTE)),
+	})
+	if err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
+// 북마크 폴더 목록 가져오기
+func (h *TsboardUserHandler) BookmarkFolderListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	result := h.service.Bookmark.GetBookmarkFolders(uint(actionUserUid))
+	return utils.Ok(c, result)
+}
+
+// 북마크 목록 가져오기 (folder 가 없으면 전체)
+func (h *TsboardUserHandler) BookmarkListHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	page, err := strconv.ParseUint(c.FormValue("page"), 10, 32)
+	if err != nil || page < 1 {
+		return utils.Err(c, "Invalid page, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+	bunch, err := strconv.ParseUint(c.FormValue("bunch"), 10, 32)
+	if err != nil || bunch < 1 || bunch > 100 {
+		return utils.Err(c, "Invalid bunch, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	result := h.service.Bookmark.GetBookmarks(models.BookmarkListParameter{
+		UserUid: uint(actionUserUid),
+		Folder:  utils.Escape(strings.TrimSpace(c.FormValue("folder"))),
+		Page:    uint(page),
+		Bunch:   uint(bunch),
+	})
+	return utils.Ok(c, result)
+}
+
 // 비밀번호 변경하기
 func (h *TsboardUserHandler) ChangePasswordHandler(c fiber.Ctx) error {
 	userCode := c.FormValue("code")
@@ -73,6 +132,16 @@ func (h *TsboardUserHandler) LoadUserPermissionHandler(c fiber.Ctx) error {
 	return utils.Ok(c, result)
 }
 
+// 내 저장 공간 사용량 및 한도 가져오기
+func (h *TsboardUserHandler) LoadUserStorageHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	if actionUserUid < 1 {
+		return utils.Err(c, "Invalid user uid, please login first", models.CODE_INVALID_TOKEN)
+	}
+	result := h.service.User.GetUserStorage(uint(actionUserUid))
+	return utils.Ok(c, result)
+}
+
 // 사용자 권한 수정하기
 func (h *TsboardUserHandler) ManageUserPermissionHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -121,6 +190,20 @@ func (h *TsboardUserHandler) ManageUserPermissionHandler(c fiber.Ctx) error {
 	return utils.Ok(c, nil)
 }
 
+// 북마크에서 게시글 삭제하기
+func (h *TsboardUserHandler) RemoveBookmarkHandler(c fiber.Ctx) error {
+	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
+	postUid, err := strconv.ParseUint(c.FormValue("postUid"), 10, 32)
+	if err != nil {
+		return utils.Err(c, "Invalid post uid, not a valid number", models.CODE_INVALID_PARAMETER)
+	}
+
+	if err := h.service.Bookmark.RemoveBookmark(uint(postUid), uint(actionUserUid)); err != nil {
+		return utils.Err(c, err.Error(), models.CODE_FAILED_OPERATION)
+	}
+	return utils.Ok(c, nil)
+}
+
 // 사용자 신고하기
 func (h *TsboardUserHandler) ReportUserHandler(c fiber.Ctx) error {
 	actionUserUid := utils.ExtractUserUid(c.Get(models.AUTH_KEY))
@@ -3,6 +3,8 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirini/goapi/internal/configs"
@@ -10,7 +12,6 @@ import (
 )
 
 type AdminRepository interface {
-	CheckCategoryInBoard(boardUid uint, catUid uint) bool
 	CreateBoard(groupUid uint, newBoardId string) uint
 	CreateDefaultCategories(boardUid uint, cats []string)
 	CreateGroup(newGroupId string) uint
@@ -22,7 +23,10 @@ type AdminRepository interface {
 	FindGroupUidIdById(inputId string, bunch uint) []models.Pair
 	FindLikeByUid(table models.Table, targetUid uint) uint
 	FindThumbPathByPostUid(postUid uint) []string
+	FindVariantPathByPostUid(postUid uint) []string
+	FindVariantTargets(boardUid uint) []models.AdminVariantTarget
 	FindCountByBoardUid(table models.Table, boardUid uint) uint
+	FindDescribeTargets(boardUid uint, postUid uint) []models.AdminDescribeTarget
 	FindWriterByUid(userUid uint) models.BoardWriter
 	FindWriterUidByName(name string) uint
 	GetAdminCandidates(name string, bunch uint) ([]models.BoardWriter, error)
@@ -39,6 +43,7 @@ type AdminRepository interface {
 	GetLowestCategoryUid(boardUid uint) uint
 	GetReportList(param models.AdminReportParameter) []models.AdminReportItem
 	GetMemberList(bunch uint) []models.BoardWriter
+	GetNextCategoryOrder(boardUid uint, parentUid uint) uint
 	GetPointPolicy(boardUid uint) (models.BoardActionPoint, error)
 	GetPostList(param models.AdminLatestParameter) []models.AdminLatestPost
 	GetRemoveFilePaths(boardUid uint) []string
@@ -47,10 +52,17 @@ type AdminRepository interface {
 	GetTotalCount(table models.Table) uint
 	GetUserList(param models.AdminUserParameter) []models.AdminUserItem
 	GetUserInfo(userUid uint) models.AdminUserInfo
-	InsertCategory(boardUid uint, name string) uint
+	InsertCategory(boardUid uint, parentUid uint, name string) uint
 	IsAddedCategory(boardUid uint, name string) bool
 	IsAdded(table models.Table, boardId string) bool
 	UpdateBoardSetting(boardUid uint, column string, value string) error
+	UpdateCategory(param models.CategoryModifyParameter) error
+	UpdateCategoryOrder(categoryUid uint, sortOrder uint) error
+	UpdateCategoryParent(categoryUid uint, parentUid uint, sortOrder uint) error
+	UpdateChildCategories(boardUid uint, oldParentUid uint, newParentUid uint) error
+	UpdateDescribeOption(boardUid uint, opt models.ImageDescribeOption) error
+	UpdateReactionOption(boardUid uint, opt models.BoardReactionOption) error
+	UpdateVariantOption(boardUid uint, opt models.BoardVariantOption) error
 	UpdateGroupBoardAdmin(table models.Table, targetUid uint, newAdminUid uint) error
 	UpdateGroupId(groupUid uint, newGroupId string) error
 	UpdateGroupUid(newGroupUid uint, oldGroupUid uint) error
@@ -64,7 +76,9 @@ type AdminRepository interface {
 	RemoveCategory(boardUid uint, catUid uint) error
 	RemoveGroup(groupUid uint) error
 	RemoveFileRecords(boardUid uint) error
+	RemoveReactionOption(boardUid uint) error
 	RemoveRecordByFileUid(table models.Table, fileUid uint) error
+	RemoveVariantOption(boardUid uint) error
 }
 
 type TsboardAdminRepository struct {
@@ -76,14 +90,6 @@ func NewTsboardAdminRepository(db *sql.DB) *TsboardAdminRepository {
 	return &TsboardAdminRepository{db: db}
 }
 
-// 카테고리가 게시판에 속해 있는 것인지 확인
-func (r *TsboardAdminRepository) CheckCategoryInBoard(boardUid uint, catUid uint) bool {
-	var uid uint
-	query := fmt.Sprintf("SELECT board_uid FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_BOARD_CAT)
-	r.db.QueryRow(query, catUid).Scan(&uid)
-	return boardUid == uid
-}
-
 // 새 게시판 만들기
 func (r *TsboardAdminRepository) CreateBoard(groupUid uint, newBoardId string) uint {
 	query := fmt.Sprintf(`INSERT INTO %s%s 
@@ -125,9 +131,9 @@ func (r *TsboardAdminRepository) CreateBoard(groupUid uint, newBoardId string) u
 
 // 새 게시판 생성 시 함께 생성되는 기본 분류들 생성 처리
 func (r *TsboardAdminRepository) CreateDefaultCategories(boardUid uint, cats []string) {
-	for _, cat := range cats {
-		query := fmt.Sprintf("INSERT INTO %s%s (board_uid, name) VALUES (?, ?)", configs.Env.Prefix, models.TABLE_BOARD_CAT)
-		r.db.Exec(query, boardUid, cat)
+	for order, cat := range cats {
+		query := fmt.Sprintf("INSERT INTO %s%s (board_uid, name, sort_order) VALUES (?, ?, ?)", configs.Env.Prefix, models.TABLE_BOARD_CAT)
+		r.db.Exec(query, boardUid, cat, order)
 	}
 }
 
@@ -263,6 +269,57 @@ func (r *TsboardAdminRepository) FindThumbPathByPostUid(postUid uint) []string {
 	return paths
 }
 
+// 게시판 삭제 시 게시글에 딸린 변형 이미지들 삭제를 위한 경로 반환
+func (r *TsboardAdminRepository) FindVariantPathByPostUid(postUid uint) []string {
+	var paths []string
+	query := fmt.Sprintf("SELECT path FROM %s%s WHERE post_uid = ? AND path != ''", configs.Env.Prefix, models.TABLE_FILE_VARIANT)
+	rows, err := r.db.Query(query, postUid)
+	if err != nil {
+		return paths
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		err = rows.Scan(&path)
+		if err != nil {
+			return paths
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// 변형 이미지가 아직 없는 첨부 이미지 목록 가져오기 (boardUid 가 0이면 전체 게시판, 만들 필요가 없었던 이미지는 표시된 너비 포함)
+func (r *TsboardAdminRepository) FindVariantTargets(boardUid uint) []models.AdminVariantTarget {
+	targets := make([]models.AdminVariantTarget, 0)
+	whereQuery := ""
+	if boardUid > 0 {
+		whereQuery = fmt.Sprintf("AND f.board_uid = %d", boardUid)
+	}
+	query := fmt.Sprintf(`SELECT f.board_uid, f.post_uid, f.uid, f.path, COALESCE(v.width, 0) FROM %s%s AS f
+												JOIN %s%s AS t ON t.file_uid = f.uid
+												LEFT JOIN %s%s AS v ON v.file_uid = f.uid
+												WHERE (v.uid IS NULL OR v.path = '') %s`,
+		configs.Env.Prefix, models.TABLE_FILE, configs.Env.Prefix, models.TABLE_FILE_THUMB,
+		configs.Env.Prefix, models.TABLE_FILE_VARIANT, whereQuery)
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return targets
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		target := models.AdminVariantTarget{}
+		if err := rows.Scan(&target.BoardUid, &target.PostUid, &target.FileUid, &target.Path, &target.SkippedWidth); err != nil {
+			return targets
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
 // 게시판 번호에 해당하는 총 레코드 수 반환
 func (r *TsboardAdminRepository) FindCountByBoardUid(table models.Table, boardUid uint) uint {
 	var count uint
@@ -271,6 +328,33 @@ func (r *TsboardAdminRepository) FindCountByBoardUid(table models.Table, boardUi
 	return count
 }
 
+// 이미지 설명글을 다시 생성할 썸네일 목록 가져오기 (postUid 가 0이면 게시판 전체)
+func (r *TsboardAdminRepository) FindDescribeTargets(boardUid uint, postUid uint) []models.AdminDescribeTarget {
+	targets := make([]models.AdminDescribeTarget, 0)
+	whereQuery := ""
+	if postUid > 0 {
+		whereQuery = fmt.Sprintf("AND t.post_uid = %d", postUid)
+	}
+	query := fmt.Sprintf(`SELECT t.post_uid, t.file_uid, t.path FROM %s%s AS t
+												JOIN %s%s AS f ON t.file_uid = f.uid WHERE f.board_uid = ? %s`,
+		configs.Env.Prefix, models.TABLE_FILE_THUMB, configs.Env.Prefix, models.TABLE_FILE, whereQuery)
+
+	rows, err := r.db.Query(query, boardUid)
+	if err != nil {
+		return targets
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		target := models.AdminDescribeTarget{}
+		if err := rows.Scan(&target.PostUid, &target.FileUid, &target.Path); err != nil {
+			return targets
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
 // 게시글 작성자 기본 정보 반환하기
 func (r *TsboardAdminRepository) FindWriterByUid(userUid uint) models.BoardWriter {
 	result := models.BoardWriter{}
@@ -535,10 +619,10 @@ func (r *TsboardAdminRepository) GetLevelPolicy(boardUid uint) (models.AdminBoar
 	return result, nil
 }
 
-// 가장 낮은 카테고리 고유 번호값 가져오기
+// 가장 앞선 최상위 카테고리 고유 번호값 가져오기
 func (r *TsboardAdminRepository) GetLowestCategoryUid(boardUid uint) uint {
 	var uid uint
-	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE board_uid = ? ORDER BY uid ASC LIMIT 1",
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE board_uid = ? AND parent_uid = 0 ORDER BY sort_order ASC, uid ASC LIMIT 1",
 		configs.Env.Prefix, models.TABLE_BOARD_CAT)
 	r.db.QueryRow(query, boardUid).Scan(&uid)
 	return uid
@@ -608,6 +692,15 @@ func (r *TsboardAdminRepository) GetMemberList(bunch uint) []models.BoardWriter
 	return items
 }
 
+// 같은 상위 카테고리 아래에 새로 추가할 카테고리의 정렬 순서 가져오기
+func (r *TsboardAdminRepository) GetNextCategoryOrder(boardUid uint, parentUid uint) uint {
+	var order uint
+	query := fmt.Sprintf("SELECT IFNULL(MAX(sort_order) + 1, 0) FROM %s%s WHERE board_uid = ? AND parent_uid = ?",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	r.db.QueryRow(query, boardUid, parentUid).Scan(&order)
+	return order
+}
+
 // 게시판 포인트 정책 가져오기
 func (r *TsboardAdminRepository) GetPointPolicy(boardUid uint) (models.BoardActionPoint, error) {
 	result := models.BoardActionPoint{}
@@ -678,8 +771,10 @@ func (r *TsboardAdminRepository) GetRemoveFilePaths(boardUid uint) []string {
 
 		attaches := r.FindPathByUid(models.TABLE_FILE, postUid)
 		thumbs := r.FindThumbPathByPostUid(postUid)
+		variants := r.FindVariantPathByPostUid(postUid)
 		paths = append(paths, attaches...)
 		paths = append(paths, thumbs...)
+		paths = append(paths, variants...)
 	}
 
 	inserted := r.FindPathByUid(models.TABLE_IMAGE, boardUid)
@@ -780,10 +875,11 @@ func (r *TsboardAdminRepository) GetUserInfo(userUid uint) models.AdminUserInfo
 	return result
 }
 
-// 카테고리 추가하기
-func (r *TsboardAdminRepository) InsertCategory(boardUid uint, name string) uint {
-	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, name) VALUES (?, ?)", configs.Env.Prefix, models.TABLE_BOARD_CAT)
-	result, err := r.db.Exec(query, boardUid, name)
+// 카테고리 추가하기 (같은 상위 카테고리의 마지막 순서로 추가)
+func (r *TsboardAdminRepository) InsertCategory(boardUid uint, parentUid uint, name string) uint {
+	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, parent_uid, name, sort_order) VALUES (?, ?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	result, err := r.db.Exec(query, boardUid, parentUid, name, r.GetNextCategoryOrder(boardUid, parentUid))
 	if err != nil {
 		return models.FAILED
 	}
@@ -819,6 +915,68 @@ func (r *TsboardAdminRepository) UpdateBoardSetting(boardUid uint, column string
 	return err
 }
 
+// 카테고리 이름, 설명, 글쓰기 레벨 수정하기
+func (r *TsboardAdminRepository) UpdateCategory(param models.CategoryModifyParameter) error {
+	query := fmt.Sprintf("UPDATE %s%s SET name = ?, description = ?, level_write = ? WHERE uid = ? AND board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	_, err := r.db.Exec(query, param.Name, param.Description, param.LevelWrite, param.CategoryUid, param.BoardUid)
+	return err
+}
+
+// 카테고리 정렬 순서 변경하기
+func (r *TsboardAdminRepository) UpdateCategoryOrder(categoryUid uint, sortOrder uint) error {
+	query := fmt.Sprintf("UPDATE %s%s SET sort_order = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	_, err := r.db.Exec(query, sortOrder, categoryUid)
+	return err
+}
+
+// 카테고리를 다른 상위 카테고리 아래로 옮기기
+func (r *TsboardAdminRepository) UpdateCategoryParent(categoryUid uint, parentUid uint, sortOrder uint) error {
+	query := fmt.Sprintf("UPDATE %s%s SET parent_uid = ?, sort_order = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	_, err := r.db.Exec(query, parentUid, sortOrder, categoryUid)
+	return err
+}
+
+// 하위 카테고리들을 다른 상위 카테고리 아래로 옮기기 (카테고리 삭제 시 사용)
+func (r *TsboardAdminRepository) UpdateChildCategories(boardUid uint, oldParentUid uint, newParentUid uint) error {
+	query := fmt.Sprintf("UPDATE %s%s SET parent_uid = ?, sort_order = sort_order + ? WHERE board_uid = ? AND parent_uid = ?",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	_, err := r.db.Exec(query, newParentUid, r.GetNextCategoryOrder(boardUid, newParentUid), boardUid, oldParentUid)
+	return err
+}
+
+// 게시판별 이미지 설명글 생성 옵션 저장하기
+func (r *TsboardAdminRepository) UpdateDescribeOption(boardUid uint, opt models.ImageDescribeOption) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, model, prompt, language) VALUES (?, ?, ?, ?)
+												ON DUPLICATE KEY UPDATE model = VALUES(model), prompt = VALUES(prompt), language = VALUES(language)`,
+		configs.Env.Prefix, models.TABLE_BOARD_DESC)
+	_, err := r.db.Exec(query, boardUid, opt.Model, opt.Prompt, opt.Language)
+	return err
+}
+
+// 게시판별 반응 이모지 옵션 저장하기
+func (r *TsboardAdminRepository) UpdateReactionOption(boardUid uint, opt models.BoardReactionOption) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, emojis, multiple) VALUES (?, ?, ?)
+												ON DUPLICATE KEY UPDATE emojis = VALUES(emojis), multiple = VALUES(multiple)`,
+		configs.Env.Prefix, models.TABLE_BOARD_REACTION)
+	_, err := r.db.Exec(query, boardUid, strings.Join(opt.Emojis, ","), opt.Multiple)
+	return err
+}
+
+// 게시판별 변형 이미지 생성 옵션 저장하기
+func (r *TsboardAdminRepository) UpdateVariantOption(boardUid uint, opt models.BoardVariantOption) error {
+	widths := make([]string, 0)
+	for _, width := range opt.Widths {
+		widths = append(widths, strconv.FormatUint(uint64(width), 10))
+	}
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, widths, avif) VALUES (?, ?, ?)
+												ON DUPLICATE KEY UPDATE widths = VALUES(widths), avif = VALUES(avif)`,
+		configs.Env.Prefix, models.TABLE_BOARD_VARIANT)
+	_, err := r.db.Exec(query, boardUid, strings.Join(widths, ","), opt.Avif)
+	return err
+}
+
 // 그룹 or 게시판 관리자 변경하기
 func (r *TsboardAdminRepository) UpdateGroupBoardAdmin(table models.Table, targetUid uint, newAdminUid uint) error {
 	query := fmt.Sprintf("UPDATE %s%s SET admin_uid = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, table)
@@ -934,6 +1092,10 @@ func (r *TsboardAdminRepository) RemoveFileRecords(boardUid uint) error {
 		if err != nil {
 			return err
 		}
+		err = r.RemoveRecordByFileUid(models.TABLE_FILE_VARIANT, fileUid)
+		if err != nil {
+			return err
+		}
 	}
 
 	query = fmt.Sprintf("DELETE FROM %s%s WHERE board_uid = ?", configs.Env.Prefix, models.TABLE_FILE)
@@ -941,9 +1103,23 @@ func (r *TsboardAdminRepository) RemoveFileRecords(boardUid uint) error {
 	return err
 }
 
+// 게시판별 반응 이모지 옵션 삭제하기 (기본 이모지 목록 사용)
+func (r *TsboardAdminRepository) RemoveReactionOption(boardUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE board_uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_BOARD_REACTION)
+	_, err := r.db.Exec(query, boardUid)
+	return err
+}
+
 // 게시판 삭제 시 레코드 삭제 필요한 테이블 작업 처리
 func (r *TsboardAdminRepository) RemoveRecordByFileUid(table models.Table, fileUid uint) error {
 	query := fmt.Sprintf("DELETE FROM %s%s WHERE file_uid = ?", configs.Env.Prefix, table)
 	_, err := r.db.Exec(query, fileUid)
 	return err
 }
+
+// 게시판별 변형 이미지 생성 옵션 삭제하기 (사이트 기본값 사용)
+func (r *TsboardAdminRepository) RemoveVariantOption(boardUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE board_uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_BOARD_VARIANT)
+	_, err := r.db.Exec(query, boardUid)
+	return err
+}
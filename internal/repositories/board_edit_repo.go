@@ -12,22 +12,27 @@ import (
 
 type BoardEditRepository interface {
 	CheckWriterForBlog(boardUid uint, actionUserUid uint) bool
-	FindTagUidByName(name string) uint
+	ExpirePosts(now int64) uint
 	FindAttachedPathByUid(fileUid uint) string
 	GetInsertedImages(param models.EditorInsertImageParameter) ([]models.Pair, error)
 	GetMaxImageUid(boardUid uint, actionUserUid uint) uint
+	GetPostSchedule(postUid uint) models.PostSchedule
+	GetPostSource(postUid uint) (models.ContentFormat, string)
 	GetSuggestionTags(input string, bunch uint) []models.EditorTagItem
 	GetTotalImageCount(boardUid uint, actionUserUid uint) uint
 	InsertExif(fileUid uint, postUid uint, exif models.BoardExif)
 	InsertFile(param models.EditorSaveFileParameter) uint
 	InsertFileThumbnail(param models.EditorSaveThumbnailParameter)
 	InsertImageDescription(fileUid uint, postUid uint, description string)
-	InsertImagePaths(boardUid uint, userUid uint, paths []string)
+	InsertImagePaths(boardUid uint, userUid uint, draftUid uint, paths []string)
+	InsertImageVariants(fileUid uint, postUid uint, variants []models.BoardImageVariant)
 	InsertPost(param models.EditorWriteParameter) uint
 	InsertPostHashtag(boardUid uint, postUid uint, hashtagUid uint)
-	InsertTag(boardUid uint, postUid uint, tag string) uint
+	InsertTag(tag string) uint
+	PublishScheduledPosts(now int64) []models.MentionParameter
 	RemoveInsertedImage(imageUid uint, actionUserUid uint) string
 	UpdatePost(param models.EditorModifyParameter)
+	UpdatePostContent(postUid uint, title string, content string, format models.ContentFormat, source string)
 	UpdateTag(hashtagUid uint)
 }
 
@@ -52,6 +57,29 @@ func (r *TsboardBoardEditRepository) CheckWriterForBlog(boardUid uint, actionUse
 	return boardType != uint8(models.BOARD_BLOG) || actionUserUid == adminUid
 }
 
+// 게시 종료 시각이 지난 게시글들 처리하기 (공지글은 일반글로 내리고, 숨기기 설정된 글만 게시 종료 표시 후 숨김)
+func (r *TsboardBoardEditRepository) ExpirePosts(now int64) uint {
+	query := fmt.Sprintf(`UPDATE %s%s SET status = IF(status = ?, ?, status), expire_at = 0
+												WHERE status IN (?, ?, ?) AND expire_hide = 0 AND expire_at > 0 AND expire_at <= ?`,
+		configs.Env.Prefix, models.TABLE_POST)
+	result, err := r.db.Exec(query, models.CONTENT_NOTICE, models.CONTENT_NORMAL,
+		models.CONTENT_NORMAL, models.CONTENT_NOTICE, models.CONTENT_SECRET, now)
+	var count int64
+	if err == nil {
+		count, _ = result.RowsAffected()
+	}
+
+	query = fmt.Sprintf(`UPDATE %s%s SET reserved_status = status, status = ?, publish_at = 0, expire_at = 0, expired = 1
+											WHERE status IN (?, ?, ?) AND expire_hide = 1 AND expire_at > 0 AND expire_at <= ?`,
+		configs.Env.Prefix, models.TABLE_POST)
+	result, err = r.db.Exec(query, models.CONTENT_PENDING, models.CONTENT_NORMAL, models.CONTENT_NOTICE, models.CONTENT_SECRET, now)
+	if err == nil {
+		hidden, _ := result.RowsAffected()
+		count += hidden
+	}
+	return uint(count)
+}
+
 // 게시글 수정에서 삭제할 파일의 경로 가져오기
 func (r *TsboardBoardEditRepository) FindAttachedPathByUid(fileUid uint) string {
 	var path string
@@ -61,15 +89,6 @@ func (r *TsboardBoardEditRepository) FindAttachedPathByUid(fileUid uint) string
 	return path
 }
 
-// 태그명에 해당하는 고유 번호 반환하기
-func (r *TsboardBoardEditRepository) FindTagUidByName(name string) uint {
-	var uid uint
-	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE name = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
-
-	r.db.QueryRow(query, name).Scan(&uid)
-	return uid
-}
-
 // 게시글에 삽입했던 이미지들 가져오기
 func (r *TsboardBoardEditRepository) GetInsertedImages(param models.EditorInsertImageParameter) ([]models.Pair, error) {
 	images := make([]models.Pair, 0)
@@ -103,10 +122,29 @@ func (r *TsboardBoardEditRepository) GetMaxImageUid(boardUid uint, actionUserUid
 	return uid
 }
 
+// 게시글의 예약 발행 및 게시 종료 일정 가져오기
+func (r *TsboardBoardEditRepository) GetPostSchedule(postUid uint) models.PostSchedule {
+	schedule := models.PostSchedule{}
+	query := fmt.Sprintf("SELECT publish_at, expire_at, expire_hide, expired FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.QueryRow(query, postUid).Scan(&schedule.PublishAt, &schedule.ExpireAt, &schedule.HideOnExpire, &schedule.Expired)
+	return schedule
+}
+
+// 게시글 본문 형식과 마크다운 원문 가져오기 (HTML 형식이면 원문은 빈 문자열)
+func (r *TsboardBoardEditRepository) GetPostSource(postUid uint) (models.ContentFormat, string) {
+	var format models.ContentFormat
+	var source string
+	query := fmt.Sprintf("SELECT format, IFNULL(source, '') FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.QueryRow(query, postUid).Scan(&format, &source)
+	return format, source
+}
+
 // 태그 추천하기 목록 가져오기
 func (r *TsboardBoardEditRepository) GetSuggestionTags(input string, bunch uint) []models.EditorTagItem {
 	items := make([]models.EditorTagItem, 0)
-	query := fmt.Sprintf("SELECT uid, name, used FROM %s%s WHERE name LIKE ? LIMIT ?",
+	query := fmt.Sprintf("SELECT uid, name, used FROM %s%s WHERE name LIKE ? AND blocked = 0 LIMIT ?",
 		configs.Env.Prefix, models.TABLE_HASHTAG)
 
 	rows, err := r.db.Query(query, "%"+input+"%", bunch)
@@ -136,12 +174,13 @@ func (r *TsboardBoardEditRepository) GetTotalImageCount(boardUid uint, actionUse
 // EXIF 정보 저장하기
 func (r *TsboardBoardEditRepository) InsertExif(fileUid uint, postUid uint, exif models.BoardExif) {
 	query := fmt.Sprintf(`INSERT INTO %s%s (
-		file_uid, post_uid, make, model, aperture, iso, focal_length, exposure, width, height, date) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_EXIF)
+		file_uid, post_uid, make, model, lens, aperture, iso, focal_length, exposure, width, height,
+		orientation, latitude, longitude, date) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_EXIF)
 
 	r.db.Exec(query, fileUid, postUid,
-		exif.Make, exif.Model, exif.Aperture, exif.ISO, exif.FocalLength,
-		exif.Exposure, exif.Width, exif.Height, exif.Date)
+		exif.Make, exif.Model, utils.CutString(exif.Lens, 50), exif.Aperture, exif.ISO, exif.FocalLength,
+		exif.Exposure, exif.Width, exif.Height, exif.Orientation, exif.Latitude, exif.Longitude, exif.Date)
 }
 
 // 첨부파일 경로 저장하기
@@ -174,16 +213,34 @@ func (r *TsboardBoardEditRepository) InsertImageDescription(fileUid uint, postUi
 }
 
 // 게시글에 삽입한 이미지 정보들을 한 번에 저장하기
-func (r *TsboardBoardEditRepository) InsertImagePaths(boardUid uint, userUid uint, paths []string) {
-	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, user_uid, path, timestamp) VALUES ",
+func (r *TsboardBoardEditRepository) InsertImagePaths(boardUid uint, userUid uint, draftUid uint, paths []string) {
+	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, user_uid, draft_uid, path, timestamp) VALUES ",
 		configs.Env.Prefix, models.TABLE_IMAGE)
 
 	values := make([]interface{}, 0)
 	now := time.Now().UnixMilli()
 
 	for _, path := range paths {
-		query += "(?, ?, ?, ?),"
-		values = append(values, boardUid, userUid, path, now)
+		query += "(?, ?, ?, ?, ?),"
+		values = append(values, boardUid, userUid, draftUid, path, now)
+	}
+
+	query = query[:len(query)-1]
+	r.db.Exec(query, values...)
+}
+
+// 반응형 이미지용 변형 이미지 정보들을 한 번에 저장하기
+func (r *TsboardBoardEditRepository) InsertImageVariants(fileUid uint, postUid uint, variants []models.BoardImageVariant) {
+	if len(variants) < 1 {
+		return
+	}
+	query := fmt.Sprintf("INSERT INTO %s%s (file_uid, post_uid, path, width, height, format) VALUES ",
+		configs.Env.Prefix, models.TABLE_FILE_VARIANT)
+
+	values := make([]interface{}, 0)
+	for _, variant := range variants {
+		query += "(?, ?, ?, ?, ?, ?),"
+		values = append(values, fileUid, postUid, variant.Path, variant.Width, variant.Height, variant.Format)
 	}
 
 	query = query[:len(query)-1]
@@ -193,10 +250,20 @@ func (r *TsboardBoardEditRepository) InsertImagePaths(boardUid uint, userUid uin
 // 새 게시글 작성하기
 func (r *TsboardBoardEditRepository) InsertPost(param models.EditorWriteParameter) uint {
 	query := fmt.Sprintf(`INSERT INTO %s%s 
-												(board_uid, user_uid, category_uid, title, content, submitted, modified, hit, status) 
-												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_POST)
+												(board_uid, user_uid, category_uid, title, content, format, source, submitted, modified, hit, status,
+												publish_at, expire_at, reserved_status, expire_hide, created) 
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_POST)
 
 	status := utils.GetContentStatus(param.IsNotice, param.IsSecret)
+	reserved := status
+	created := uint64(time.Now().UnixMilli())
+	submitted := created
+	if param.PublishAt > 0 {
+		submitted = param.PublishAt
+	}
+	if param.PublishAt > 0 || param.IsPending {
+		status = models.CONTENT_PENDING
+	}
 	result, _ := r.db.Exec(
 		query,
 		param.BoardUid,
@@ -204,10 +271,17 @@ func (r *TsboardBoardEditRepository) InsertPost(param models.EditorWriteParamete
 		param.CategoryUid,
 		param.Title,
 		param.Content,
-		time.Now().UnixMilli(),
+		param.Format,
+		param.Source,
+		submitted,
 		0,
 		0,
 		status,
+		param.PublishAt,
+		param.ExpireAt,
+		reserved,
+		param.HideOnExpire,
+		created,
 	)
 
 	insertId, err := result.LastInsertId()
@@ -225,12 +299,15 @@ func (r *TsboardBoardEditRepository) InsertPostHashtag(boardUid uint, postUid ui
 	r.db.Exec(query, boardUid, postUid, hashtagUid)
 }
 
-// 신규 태그 저장하기
-func (r *TsboardBoardEditRepository) InsertTag(boardUid uint, postUid uint, tag string) uint {
+// 신규 태그 저장하기 (태그는 게시판 구분 없이 공유됨)
+func (r *TsboardBoardEditRepository) InsertTag(tag string) uint {
 	query := fmt.Sprintf("INSERT INTO %s%s (name, used, timestamp) VALUES (?, ?, ?)",
 		configs.Env.Prefix, models.TABLE_HASHTAG)
 
-	result, _ := r.db.Exec(query, tag, 1, time.Now().UnixMilli())
+	result, err := r.db.Exec(query, tag, 1, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
 	hashtagUid, err := result.LastInsertId()
 	if err != nil {
 		return models.FAILED
@@ -238,6 +315,41 @@ func (r *TsboardBoardEditRepository) InsertTag(boardUid uint, postUid uint, tag
 	return uint(hashtagUid)
 }
 
+// 발행 예약 시각이 지난 게시글들을 원래 상태로 공개하고 공개된 게시글들 반환하기 (승인 대기중인 게시글은 제외)
+func (r *TsboardBoardEditRepository) PublishScheduledPosts(now int64) []models.MentionParameter {
+	posts := make([]models.MentionParameter, 0)
+	query := fmt.Sprintf(`SELECT uid, board_uid, user_uid, content FROM %s%s
+												WHERE status = ? AND publish_at > 0 AND publish_at <= ?
+												AND uid NOT IN (SELECT post_uid FROM %s%s WHERE comment_uid = 0 AND decision = ?)`,
+		configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_MODERATION)
+	rows, err := r.db.Query(query, models.CONTENT_PENDING, now, models.MODERATION_WAITING)
+	if err != nil {
+		return posts
+	}
+	defer rows.Close()
+
+	candidates := make([]models.MentionParameter, 0)
+	for rows.Next() {
+		post := models.MentionParameter{}
+		rows.Scan(&post.PostUid, &post.BoardUid, &post.UserUid, &post.Content)
+		candidates = append(candidates, post)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s%s SET status = reserved_status
+											WHERE uid = ? AND status = ? AND publish_at > 0 AND publish_at <= ? LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_POST)
+	for _, post := range candidates {
+		result, err := r.db.Exec(query, post.PostUid, models.CONTENT_PENDING, now)
+		if err != nil {
+			continue
+		}
+		if count, _ := result.RowsAffected(); count > 0 {
+			posts = append(posts, post)
+		}
+	}
+	return posts
+}
+
 // 게시글에 삽입한 이미지 삭제하기
 func (r *TsboardBoardEditRepository) RemoveInsertedImage(imageUid uint, actionUserUid uint) string {
 	query := fmt.Sprintf("SELECT user_uid, path FROM %s%s WHERE uid = ? LIMIT 1",
@@ -256,20 +368,32 @@ func (r *TsboardBoardEditRepository) RemoveInsertedImage(imageUid uint, actionUs
 	return path
 }
 
-// 기존 게시글 수정하기
+// 기존 게시글 수정하기 (휴지통에 있는 게시글은 제외)
 func (r *TsboardBoardEditRepository) UpdatePost(param models.EditorModifyParameter) {
-	query := fmt.Sprintf(`UPDATE %s%s SET category_uid = ?, title = ?, content = ?, modified = ?, status = ? 
-												WHERE uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_POST)
+	query := fmt.Sprintf(`UPDATE %s%s SET category_uid = ?, title = ?, content = ?, format = ?, source = ?, modified = ?, status = ?,
+												publish_at = ?, expire_at = ?, reserved_status = ?, expire_hide = ?, expired = 0 
+												WHERE uid = ? AND status != ? LIMIT 1`, configs.Env.Prefix, models.TABLE_POST)
 
 	status := utils.GetContentStatus(param.IsNotice, param.IsSecret)
+	reserved := status
+	if param.PublishAt > 0 || param.IsPending {
+		status = models.CONTENT_PENDING
+	}
 	r.db.Exec(
 		query,
 		param.CategoryUid,
 		param.Title,
 		param.Content,
+		param.Format,
+		param.Source,
 		time.Now().UnixMilli(),
 		status,
+		param.PublishAt,
+		param.ExpireAt,
+		reserved,
+		param.HideOnExpire,
 		param.PostUid,
+		models.CONTENT_REMOVED,
 	)
 }
 
@@ -280,3 +404,10 @@ func (r *TsboardBoardEditRepository) UpdateTag(hashtagUid uint) {
 
 	r.db.Exec(query, hashtagUid)
 }
+
+// 게시글 제목과 내용만 수정하기 (수정 이력 복원 시 사용)
+func (r *TsboardBoardEditRepository) UpdatePostContent(postUid uint, title string, content string, format models.ContentFormat, source string) {
+	query := fmt.Sprintf("UPDATE %s%s SET title = ?, content = ?, format = ?, source = ?, modified = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.Exec(query, title, content, format, source, time.Now().UnixMilli(), postUid)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/sirini/goapi/internal/configs"
 	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
 )
 
 type BoardRepository interface {
@@ -19,11 +20,18 @@ type BoardRepository interface {
 	FindPostsByHashtag(param models.BoardListParameter) ([]models.BoardListItem, error)
 	GetBoardConfig(boardUid uint) models.BoardConfig
 	GetBoardUidById(id string) uint
-	GetBoardCategories(boardUid uint) []models.Pair
+	GetBoardCategories(boardUid uint) []models.BoardCategory
+	GetCategory(boardUid uint, categoryUid uint) models.BoardCategory
+	GetCategoryUidByName(boardUid uint, name string) uint
 	GetCategoryByUidForLoop(stmt *sql.Stmt, categoryUid uint) models.Pair
 	GetCategoryByUid(categoryUid uint) models.Pair
 	GetCoverImageForLoop(stmt *sql.Stmt, postUid uint) string
 	GetCoverImage(postUid uint) string
+	GetDescribeOption(boardUid uint) models.ImageDescribeOption
+	GetFieldValuesForLoop(stmt *sql.Stmt, postUid uint) []models.BoardFieldValue
+	GetFieldValues(postUid uint) []models.BoardFieldValue
+	GetVariantOption(boardUid uint) (models.BoardVariantOption, bool)
+	GetReactionOption(boardUid uint) (models.BoardReactionOption, bool)
 	GetCommentCount(postUid uint) uint
 	GetCommentLikeCount(postUid uint) uint
 	GetLikeCount(postUid uint) uint
@@ -33,6 +41,8 @@ type BoardRepository interface {
 	GetNoticePosts(boardUid uint, actionUserUid uint) ([]models.BoardListItem, error)
 	GetNormalPosts(param models.BoardListParameter) ([]models.BoardListItem, error)
 	GetMaxUid(table models.Table) uint
+	GetReactionsForLoop(stmt *sql.Stmt, targetUid uint, userUid uint) []models.ReactionCount
+	GetReactions(table models.Table, targetUid uint, userUid uint) []models.ReactionCount
 	GetTagUids(names string) (string, int)
 	GetTotalPostCount(boardUid uint) uint
 	GetUidByTable(table models.Table, name string) uint
@@ -101,18 +111,15 @@ func (r *TsboardBoardRepository) FindPostsByTitleContent(param models.BoardListP
 	option := param.Option.String()
 	keyword := "%" + param.Keyword + "%"
 	arrow, order := param.Direction.Query()
-	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status = ? AND %s LIKE ? AND uid %s ?
+	filter, filterValues := makeFieldFilterQuery("uid", param.Filters)
+	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status = ? AND %s LIKE ? %s AND uid %s ?
 												ORDER BY uid %s LIMIT ?`,
-		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, option, arrow, order)
-
-	rows, err := r.db.Query(
-		query,
-		param.BoardUid,
-		models.CONTENT_NORMAL,
-		keyword,
-		param.SinceUid,
-		param.Bunch-param.NoticeCount,
-	)
+		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, option, filter, arrow, order)
+
+	values := []interface{}{param.BoardUid, models.CONTENT_NORMAL, keyword}
+	values = append(values, filterValues...)
+	values = append(values, param.SinceUid, param.Bunch-param.NoticeCount)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,27 +127,33 @@ func (r *TsboardBoardRepository) FindPostsByTitleContent(param models.BoardListP
 	return r.MakeListItem(param.UserUid, rows)
 }
 
-// 게시글 작성자 혹은 분류명으로 검색해서 가져오기
+// 게시글 작성자 혹은 분류명으로 검색해서 가져오기 (분류는 하위 분류의 글들도 포함)
 func (r *TsboardBoardRepository) FindPostsByNameCategory(param models.BoardListParameter) ([]models.BoardListItem, error) {
 	option := param.Option.String()
 	arrow, order := param.Direction.Query()
-	table := models.TABLE_USER
+	var uids []uint
 	if param.Option == models.SEARCH_CATEGORY {
-		table = models.TABLE_BOARD_CAT
+		catUid := r.GetCategoryUidByName(param.BoardUid, param.Keyword)
+		if catUid < 1 {
+			return make([]models.BoardListItem, 0), nil
+		}
+		uids = utils.GetCategoryFamily(r.GetBoardCategories(param.BoardUid), catUid)
+	} else {
+		uids = []uint{r.GetUidByTable(models.TABLE_USER, param.Keyword)}
 	}
-	uid := r.GetUidByTable(table, param.Keyword)
-	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status = ? AND %s = ? AND uid %s ?
+	marks := strings.TrimSuffix(strings.Repeat("?,", len(uids)), ",")
+	filter, filterValues := makeFieldFilterQuery("uid", param.Filters)
+	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status = ? AND %s IN (%s) %s AND uid %s ?
 												ORDER BY uid %s LIMIT ?`,
-		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, option, arrow, order)
-
-	rows, err := r.db.Query(
-		query,
-		param.BoardUid,
-		models.CONTENT_NORMAL,
-		uid,
-		param.SinceUid,
-		param.Bunch-param.NoticeCount,
-	)
+		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, option, marks, filter, arrow, order)
+
+	values := []interface{}{param.BoardUid, models.CONTENT_NORMAL}
+	for _, uid := range uids {
+		values = append(values, uid)
+	}
+	values = append(values, filterValues...)
+	values = append(values, param.SinceUid, param.Bunch-param.NoticeCount)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,22 +165,19 @@ func (r *TsboardBoardRepository) FindPostsByNameCategory(param models.BoardListP
 func (r *TsboardBoardRepository) FindPostsByHashtag(param models.BoardListParameter) ([]models.BoardListItem, error) {
 	arrow, order := param.Direction.Query()
 	tagUidStr, tagCount := r.GetTagUids(param.Keyword)
+	filter, filterValues := makeFieldFilterQuery("p.uid", param.Filters)
 	query := fmt.Sprintf(`SELECT p.uid, p.user_uid, p.category_uid, p.title, p.content, 
 												p.submitted, p.modified, p.hit, p.status
 												FROM %s%s AS p JOIN %s%s AS ph ON p.uid = ph.post_uid
-												WHERE p.board_uid = ? AND p.status = ? AND p.uid %s ? AND ph.hashtag_uid IN (%s)
+												WHERE p.board_uid = ? AND p.status = ? AND p.uid %s ? AND ph.hashtag_uid IN (%s) %s
 												GROUP BY ph.post_uid HAVING (COUNT(ph.hashtag_uid) = ?)
 												ORDER BY p.uid %s LIMIT ?`,
-		configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_POST_HASHTAG, arrow, tagUidStr, order)
-
-	rows, err := r.db.Query(
-		query,
-		param.BoardUid,
-		models.CONTENT_NORMAL,
-		param.SinceUid,
-		tagCount,
-		param.Bunch-param.NoticeCount,
-	)
+		configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_POST_HASHTAG, arrow, tagUidStr, filter, order)
+
+	values := []interface{}{param.BoardUid, models.CONTENT_NORMAL, param.SinceUid}
+	values = append(values, filterValues...)
+	values = append(values, tagCount, param.Bunch-param.NoticeCount)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +205,65 @@ func (r *TsboardBoardRepository) GetBoardConfig(boardUid uint) models.BoardConfi
 	return config
 }
 
+// 게시판별 이미지 설명글 생성 옵션 가져오기 (없으면 빈 값)
+func (r *TsboardBoardRepository) GetDescribeOption(boardUid uint) models.ImageDescribeOption {
+	opt := models.ImageDescribeOption{}
+	query := fmt.Sprintf("SELECT model, prompt, language FROM %s%s WHERE board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_DESC)
+	r.db.QueryRow(query, boardUid).Scan(&opt.Model, &opt.Prompt, &opt.Language)
+	return opt
+}
+
+// 반복문에서 사용하는 사용자 정의 필드 값들 가져오기
+func (r *TsboardBoardRepository) GetFieldValuesForLoop(stmt *sql.Stmt, postUid uint) []models.BoardFieldValue {
+	rows, err := stmt.Query(postUid)
+	if err != nil {
+		return make([]models.BoardFieldValue, 0)
+	}
+	defer rows.Close()
+	return scanFieldValues(rows)
+}
+
+// 게시글에 입력된 사용자 정의 필드 값들 가져오기
+func (r *TsboardBoardRepository) GetFieldValues(postUid uint) []models.BoardFieldValue {
+	query := fmt.Sprintf(`SELECT pf.field_uid, bf.name, bf.type, pf.value FROM %s%s AS pf
+											 JOIN %s%s AS bf ON pf.field_uid = bf.uid
+											 WHERE pf.post_uid = ? ORDER BY pf.field_uid ASC`,
+		configs.Env.Prefix, models.TABLE_POST_FIELD, configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	rows, err := r.db.Query(query, postUid)
+	if err != nil {
+		return make([]models.BoardFieldValue, 0)
+	}
+	defer rows.Close()
+	return scanFieldValues(rows)
+}
+
+// 게시판별 변형 이미지 생성 옵션 가져오기 (따로 지정하지 않았다면 false 반환)
+func (r *TsboardBoardRepository) GetVariantOption(boardUid uint) (models.BoardVariantOption, bool) {
+	opt := models.BoardVariantOption{Widths: make([]uint, 0)}
+	var widths string
+	query := fmt.Sprintf("SELECT widths, avif FROM %s%s WHERE board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_VARIANT)
+	if err := r.db.QueryRow(query, boardUid).Scan(&widths, &opt.Avif); err != nil {
+		return opt, false
+	}
+	opt.Widths = utils.ParseVariantWidths(widths)
+	return opt, true
+}
+
+// 게시판별 반응 이모지 옵션 가져오기 (따로 지정하지 않았다면 false 반환)
+func (r *TsboardBoardRepository) GetReactionOption(boardUid uint) (models.BoardReactionOption, bool) {
+	opt := models.BoardReactionOption{Emojis: make([]string, 0)}
+	var emojis string
+	query := fmt.Sprintf("SELECT emojis, multiple FROM %s%s WHERE board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_REACTION)
+	if err := r.db.QueryRow(query, boardUid).Scan(&emojis, &opt.Multiple); err != nil {
+		return opt, false
+	}
+	opt.Emojis = utils.ParseReactionEmojis(emojis)
+	return opt, true
+}
+
 // 게시판 아이디로 게시판 고유 번호 가져오기
 func (r *TsboardBoardRepository) GetBoardUidById(id string) uint {
 	var uid uint
@@ -204,10 +273,11 @@ func (r *TsboardBoardRepository) GetBoardUidById(id string) uint {
 	return uid
 }
 
-// 지정된 게시판에서 사용중인 카테고리 목록들 반환
-func (r *TsboardBoardRepository) GetBoardCategories(boardUid uint) []models.Pair {
-	items := make([]models.Pair, 0)
-	query := fmt.Sprintf("SELECT uid, name FROM %s%s WHERE board_uid = ?", configs.Env.Prefix, models.TABLE_BOARD_CAT)
+// 지정된 게시판에서 사용중인 카테고리 목록들 반환 (정렬 순서대로)
+func (r *TsboardBoardRepository) GetBoardCategories(boardUid uint) []models.BoardCategory {
+	items := make([]models.BoardCategory, 0)
+	query := fmt.Sprintf(`SELECT uid, parent_uid, name, description, sort_order, level_write
+												FROM %s%s WHERE board_uid = ? ORDER BY sort_order ASC, uid ASC`, configs.Env.Prefix, models.TABLE_BOARD_CAT)
 
 	rows, err := r.db.Query(query, boardUid)
 	if err != nil {
@@ -216,8 +286,8 @@ func (r *TsboardBoardRepository) GetBoardCategories(boardUid uint) []models.Pair
 	defer rows.Close()
 
 	for rows.Next() {
-		item := models.Pair{}
-		err := rows.Scan(&item.Uid, &item.Name)
+		item := models.BoardCategory{}
+		err := rows.Scan(&item.Uid, &item.ParentUid, &item.Name, &item.Description, &item.SortOrder, &item.LevelWrite)
 		if err != nil {
 			return items
 		}
@@ -226,6 +296,26 @@ func (r *TsboardBoardRepository) GetBoardCategories(boardUid uint) []models.Pair
 	return items
 }
 
+// 게시판에 속한 카테고리 정보 가져오기 (다른 게시판의 카테고리면 빈 값 반환)
+func (r *TsboardBoardRepository) GetCategory(boardUid uint, categoryUid uint) models.BoardCategory {
+	cat := models.BoardCategory{}
+	query := fmt.Sprintf(`SELECT uid, parent_uid, name, description, sort_order, level_write
+												FROM %s%s WHERE uid = ? AND board_uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_BOARD_CAT)
+
+	r.db.QueryRow(query, categoryUid, boardUid).Scan(&cat.Uid, &cat.ParentUid, &cat.Name, &cat.Description, &cat.SortOrder, &cat.LevelWrite)
+	return cat
+}
+
+// 게시판에 속한 카테고리 이름으로 고유 번호 가져오기
+func (r *TsboardBoardRepository) GetCategoryUidByName(boardUid uint, name string) uint {
+	var uid uint
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE board_uid = ? AND name = ? ORDER BY uid ASC LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_CAT)
+
+	r.db.QueryRow(query, boardUid, name).Scan(&uid)
+	return uid
+}
+
 // 반복문에서 사용할 카테고리 이름 가져오기
 func (r *TsboardBoardRepository) GetCategoryByUidForLoop(stmt *sql.Stmt, categoryUid uint) models.Pair {
 	cat := models.Pair{}
@@ -328,11 +418,15 @@ func (r *TsboardBoardRepository) GetNoticePosts(boardUid uint, actionUserUid uin
 // 비밀글을 포함한 일반 게시글들 가져오기
 func (r *TsboardBoardRepository) GetNormalPosts(param models.BoardListParameter) ([]models.BoardListItem, error) {
 	arrow, order := param.Direction.Query()
-	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status IN (?, ?) AND uid %s ?
+	filter, filterValues := makeFieldFilterQuery("uid", param.Filters)
+	query := fmt.Sprintf(`SELECT %s FROM %s%s WHERE board_uid = ? AND status IN (?, ?) %s AND uid %s ?
 												ORDER BY uid %s LIMIT ?`,
-		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, arrow, order)
+		POST_COLUMNS, configs.Env.Prefix, models.TABLE_POST, filter, arrow, order)
 
-	rows, err := r.db.Query(query, param.BoardUid, models.CONTENT_NORMAL, models.CONTENT_SECRET, param.SinceUid, param.Bunch-param.NoticeCount)
+	values := []interface{}{param.BoardUid, models.CONTENT_NORMAL, models.CONTENT_SECRET}
+	values = append(values, filterValues...)
+	values = append(values, param.SinceUid, param.Bunch-param.NoticeCount)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -348,6 +442,28 @@ func (r *TsboardBoardRepository) GetMaxUid(table models.Table) uint {
 	return max
 }
 
+// 반복문에서 사용하는 이모지별 반응 수 가져오기
+func (r *TsboardBoardRepository) GetReactionsForLoop(stmt *sql.Stmt, targetUid uint, userUid uint) []models.ReactionCount {
+	rows, err := stmt.Query(userUid, targetUid)
+	if err != nil {
+		return make([]models.ReactionCount, 0)
+	}
+	defer rows.Close()
+	return scanReactions(rows)
+}
+
+// 게시글 혹은 댓글에 남겨진 이모지별 반응 수 가져오기 (table 은 게시글 혹은 댓글 반응 테이블)
+func (r *TsboardBoardRepository) GetReactions(table models.Table, targetUid uint, userUid uint) []models.ReactionCount {
+	query := fmt.Sprintf(`SELECT emoji, COUNT(*), SUM(user_uid = ?) FROM %s%s
+												WHERE target_uid = ? GROUP BY emoji ORDER BY MIN(uid) ASC`, configs.Env.Prefix, table)
+	rows, err := r.db.Query(query, userUid, targetUid)
+	if err != nil {
+		return make([]models.ReactionCount, 0)
+	}
+	defer rows.Close()
+	return scanReactions(rows)
+}
+
 // 스페이스로 구분된 태그 이름들을 가져와서 태그 고유번호 문자열로 변환
 func (r *TsboardBoardRepository) GetTagUids(keyword string) (string, int) {
 	tags := strings.Split(keyword, " ")
@@ -375,10 +491,10 @@ func (r *TsboardBoardRepository) GetTagUids(keyword string) (string, int) {
 // 게시판에 등록된 글 갯수 반환
 func (r *TsboardBoardRepository) GetTotalPostCount(boardUid uint) uint {
 	var count uint
-	query := fmt.Sprintf("SELECT COUNT(*) AS total FROM %s%s WHERE board_uid = ? AND status != ?",
+	query := fmt.Sprintf("SELECT COUNT(*) AS total FROM %s%s WHERE board_uid = ? AND status NOT IN (?, ?)",
 		configs.Env.Prefix, models.TABLE_POST)
 
-	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED).Scan(&count)
+	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED, models.CONTENT_PENDING).Scan(&count)
 	return count
 }
 
@@ -459,6 +575,27 @@ func (r *TsboardBoardRepository) MakeListItem(actionUserUid uint, rows *sql.Rows
 	}
 	defer stmtLiked.Close()
 
+	// 이모지별 반응 수 가져오는 쿼리문 준비
+	query = fmt.Sprintf(`SELECT emoji, COUNT(*), SUM(user_uid = ?) FROM %s%s
+											 WHERE target_uid = ? GROUP BY emoji ORDER BY MIN(uid) ASC`,
+		configs.Env.Prefix, models.TABLE_POST_REACTION)
+	stmtReactions, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmtReactions.Close()
+
+	// 사용자 정의 필드 값들 가져오는 쿼리문 준비
+	query = fmt.Sprintf(`SELECT pf.field_uid, bf.name, bf.type, pf.value FROM %s%s AS pf
+											 JOIN %s%s AS bf ON pf.field_uid = bf.uid
+											 WHERE pf.post_uid = ? ORDER BY pf.field_uid ASC`,
+		configs.Env.Prefix, models.TABLE_POST_FIELD, configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	stmtFields, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmtFields.Close()
+
 	// 게시글 작성자 정보 가져오는 쿼리문 준비
 	query = fmt.Sprintf("SELECT name, profile, signature FROM %s%s WHERE uid = ? LIMIT 1",
 		configs.Env.Prefix, models.TABLE_USER)
@@ -482,8 +619,54 @@ func (r *TsboardBoardRepository) MakeListItem(actionUserUid uint, rows *sql.Rows
 		item.Comment = r.GetCommentCountForLoop(stmtCommmentCount, item.Uid)
 		item.Like = r.GetLikedCountForLoop(stmtLikeCount, item.Uid)
 		item.Liked = r.CheckLikedPostForLoop(stmtLiked, item.Uid, actionUserUid)
+		item.Reactions = r.GetReactionsForLoop(stmtReactions, item.Uid, actionUserUid)
 		item.Writer = r.GetWriterInfoForLoop(stmtWriter, writerUid)
+		item.Fields = r.GetFieldValuesForLoop(stmtFields, item.Uid)
 		items = append(items, item)
 	}
 	return items, nil
 }
+
+// 사용자 정의 필드 값으로 게시글을 거르는 조건문과 값들 만들기 (문자, 링크는 부분 일치)
+func makeFieldFilterQuery(column string, filters []models.BoardFieldFilter) (string, []interface{}) {
+	conditions := make([]string, 0)
+	values := make([]interface{}, 0)
+	for _, filter := range filters {
+		compare, value := "= ?", filter.Value
+		if filter.Type == models.FIELD_TEXT || filter.Type == models.FIELD_URL {
+			compare, value = "LIKE ?", "%"+filter.Value+"%"
+		}
+		conditions = append(conditions, fmt.Sprintf("AND %s IN (SELECT post_uid FROM %s%s WHERE field_uid = ? AND value %s)",
+			column, configs.Env.Prefix, models.TABLE_POST_FIELD, compare))
+		values = append(values, filter.FieldUid, value)
+	}
+	return strings.Join(conditions, " "), values
+}
+
+// 사용자 정의 필드 값 쿼리 결과를 변환하기
+func scanFieldValues(rows *sql.Rows) []models.BoardFieldValue {
+	items := make([]models.BoardFieldValue, 0)
+	for rows.Next() {
+		item := models.BoardFieldValue{}
+		if err := rows.Scan(&item.FieldUid, &item.Name, &item.Type, &item.Value); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 이모지별 반응 수 쿼리 결과를 변환하기
+func scanReactions(rows *sql.Rows) []models.ReactionCount {
+	items := make([]models.ReactionCount, 0)
+	for rows.Next() {
+		item := models.ReactionCount{}
+		var reacted uint
+		if err := rows.Scan(&item.Emoji, &item.Count, &reacted); err != nil {
+			return items
+		}
+		item.Reacted = reacted > 0
+		items = append(items, item)
+	}
+	return items
+}
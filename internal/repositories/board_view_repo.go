@@ -3,6 +3,7 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sirini/goapi/internal/configs"
@@ -18,10 +19,12 @@ type BoardViewRepository interface {
 	GetBasicBoardConfig(boardUid uint) models.BoardBasicConfig
 	GetDownloadInfo(fileUid uint) models.BoardViewDownloadResult
 	GetExif(fileUid uint) models.BoardExif
+	GetImageVariants(fileUid uint) []models.BoardImageVariant
 	GetNeededLevelPoint(boardUid uint, action models.BoardAction) (int, int)
 	GetPrevPostUid(boardUid uint, postUid uint) uint
 	GetNextPostUid(boardUid uint, postUid uint) uint
 	GetPost(postUid uint, actionUserUid uint) (models.BoardListItem, error)
+	GetRelatedPosts(param models.BoardRelatedParameter) ([]models.BoardRelatedPost, error)
 	GetTags(postUid uint) []models.Pair
 	GetTagName(hashtagUid uint) string
 	GetThumbnailImage(fileUid uint) models.BoardThumbnail
@@ -35,11 +38,11 @@ type BoardViewRepository interface {
 	RemoveComments(postUid uint)
 	RemoveExif(fileUid uint)
 	RemoveImageDescription(fileUid uint)
+	RemoveImageVariants(fileUid uint) []string
 	RemovePost(postUid uint) error
 	RemovePostTags(postUid uint)
 	RemoveThumbnails(fileUid uint) []string
 	UpdateLikePost(param models.BoardViewLikeParameter)
-	UpdatePostHit(postUid uint)
 	UpdatePostBoardUid(targetBoardUid uint, postUid uint)
 }
 
@@ -142,6 +145,7 @@ func (r *TsboardBoardViewRepository) GetAttachedImages(postUid uint) ([]models.B
 				Small: thumb.Small,
 				Large: thumb.Large,
 			},
+			Variants:    r.GetImageVariants(fileUid),
 			Exif:        exif,
 			Description: desc,
 		}
@@ -171,10 +175,12 @@ func (r *TsboardBoardViewRepository) GetDownloadInfo(fileUid uint) models.BoardV
 // EXIF 정보 가져오기
 func (r *TsboardBoardViewRepository) GetExif(fileUid uint) models.BoardExif {
 	exif := models.BoardExif{}
-	query := fmt.Sprintf(`SELECT make, model, aperture, iso, focal_length, exposure, width, height, date 
+	query := fmt.Sprintf(`SELECT make, model, lens, aperture, iso, focal_length, exposure, width, height,
+												orientation, latitude, longitude, date 
 												FROM %s%s WHERE file_uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_EXIF)
 
-	r.db.QueryRow(query, fileUid).Scan(&exif.Make, &exif.Model, &exif.Aperture, &exif.ISO, &exif.FocalLength, &exif.Exposure, &exif.Width, &exif.Height, &exif.Date)
+	r.db.QueryRow(query, fileUid).Scan(&exif.Make, &exif.Model, &exif.Lens, &exif.Aperture, &exif.ISO, &exif.FocalLength,
+		&exif.Exposure, &exif.Width, &exif.Height, &exif.Orientation, &exif.Latitude, &exif.Longitude, &exif.Date)
 	return exif
 }
 
@@ -187,6 +193,28 @@ func (r *TsboardBoardViewRepository) GetImageDescription(fileUid uint) string {
 	return description
 }
 
+// 반응형 이미지용 변형 이미지들 가져오기 (작은 너비부터)
+func (r *TsboardBoardViewRepository) GetImageVariants(fileUid uint) []models.BoardImageVariant {
+	items := make([]models.BoardImageVariant, 0)
+	query := fmt.Sprintf(`SELECT path, width, height, format FROM %s%s WHERE file_uid = ? AND path != ''
+												ORDER BY width ASC, format ASC`,
+		configs.Env.Prefix, models.TABLE_FILE_VARIANT)
+	rows, err := r.db.Query(query, fileUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.BoardImageVariant{}
+		if err := rows.Scan(&item.Path, &item.Width, &item.Height, &item.Format); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 // Action에 필요한 포인트 양 확인하기
 func (r *TsboardBoardViewRepository) GetNeededLevelPoint(boardUid uint, action models.BoardAction) (int, int) {
 	var level, point int
@@ -201,20 +229,20 @@ func (r *TsboardBoardViewRepository) GetNeededLevelPoint(boardUid uint, action m
 // 현재 게시글의 이전 게시글 번호 가져오기
 func (r *TsboardBoardViewRepository) GetPrevPostUid(boardUid uint, postUid uint) uint {
 	var prevUid uint
-	query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE board_uid = ? AND status != ? AND uid < ? 
+	query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE board_uid = ? AND status NOT IN (?, ?) AND uid < ? 
 												ORDER BY uid DESC LIMIT 1`, configs.Env.Prefix, models.TABLE_POST)
 
-	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED, postUid).Scan(&prevUid)
+	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, postUid).Scan(&prevUid)
 	return prevUid
 }
 
 // 현재 게시글의 다음 게시글 번호 가져오기
 func (r *TsboardBoardViewRepository) GetNextPostUid(boardUid uint, postUid uint) uint {
 	var nextUid uint
-	query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE board_uid = ? AND status != ? AND uid > ?
+	query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE board_uid = ? AND status NOT IN (?, ?) AND uid > ?
 											 ORDER BY uid ASC LIMIT 1`, configs.Env.Prefix, models.TABLE_POST)
 
-	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED, postUid).Scan(&nextUid)
+	r.db.QueryRow(query, boardUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, postUid).Scan(&nextUid)
 	return nextUid
 }
 
@@ -233,12 +261,79 @@ func (r *TsboardBoardViewRepository) GetPost(postUid uint, actionUserUid uint) (
 	item.Writer = r.board.GetWriterInfo(writerUid)
 	item.Like = r.board.GetLikeCount(postUid)
 	item.Liked = r.board.CheckLikedPost(postUid, actionUserUid)
+	item.Reactions = r.board.GetReactions(models.TABLE_POST_REACTION, postUid, actionUserUid)
 	item.Category = r.board.GetCategoryByUid(item.Category.Uid)
 	item.Comment = r.board.GetCommentCount(postUid)
 	item.Cover = r.board.GetCoverImage(postUid)
+	item.Fields = r.board.GetFieldValues(postUid)
 	return item, nil
 }
 
+// 해시태그, 카테고리 (및 제목 유사도)가 겹치는 관련 글들을 점수가 높은 순서로 가져오기 (조건별 최근 후보들 안에서만 계산)
+func (r *TsboardBoardViewRepository) GetRelatedPosts(param models.BoardRelatedParameter) ([]models.BoardRelatedPost, error) {
+	scores := []string{fmt.Sprintf("IF(p.board_uid = ? AND p.category_uid = ?, %d, 0)", models.RELATED_CATEGORY_WEIGHT)}
+	candidates := []string{fmt.Sprintf("(SELECT uid FROM %s%s WHERE board_uid = ? AND category_uid = ? ORDER BY uid DESC LIMIT %d)",
+		configs.Env.Prefix, models.TABLE_POST, models.RELATED_CANDIDATE_LIMIT)}
+	scoreValues := []interface{}{param.BoardUid, param.CategoryUid}
+	candidateValues := []interface{}{param.BoardUid, param.CategoryUid}
+
+	if len(param.TagUids) > 0 {
+		marks := strings.TrimSuffix(strings.Repeat("?,", len(param.TagUids)), ",")
+		scores = append(scores, fmt.Sprintf("(SELECT COUNT(*) FROM %s%s WHERE post_uid = p.uid AND hashtag_uid IN (%s)) * %d",
+			configs.Env.Prefix, models.TABLE_POST_HASHTAG, marks, models.RELATED_TAG_WEIGHT))
+		candidates = append(candidates, fmt.Sprintf("(SELECT post_uid FROM %s%s WHERE hashtag_uid IN (%s) ORDER BY post_uid DESC LIMIT %d)",
+			configs.Env.Prefix, models.TABLE_POST_HASHTAG, marks, models.RELATED_CANDIDATE_LIMIT))
+		for _, tagUid := range param.TagUids {
+			scoreValues = append(scoreValues, tagUid)
+			candidateValues = append(candidateValues, tagUid)
+		}
+	}
+	if param.UseFulltext && len(param.Title) > 0 {
+		scores = append(scores, "MATCH(p.title) AGAINST(? IN NATURAL LANGUAGE MODE)")
+		candidates = append(candidates, fmt.Sprintf("(SELECT uid FROM %s%s WHERE MATCH(title) AGAINST(? IN NATURAL LANGUAGE MODE) LIMIT %d)",
+			configs.Env.Prefix, models.TABLE_POST, models.RELATED_CANDIDATE_LIMIT))
+		scoreValues = append(scoreValues, param.Title)
+		candidateValues = append(candidateValues, param.Title)
+	}
+
+	query := fmt.Sprintf(`SELECT p.uid, p.board_uid, p.title, p.submitted, p.hit, %s AS score
+												FROM (%s) AS c JOIN %s%s AS p ON c.uid = p.uid JOIN %s%s AS b ON p.board_uid = b.uid
+												WHERE p.uid != ? AND p.status IN (?, ?) AND b.level_view <= ?
+												ORDER BY score DESC, p.uid DESC LIMIT ?`,
+		strings.Join(scores, " + "), strings.Join(candidates, " UNION "),
+		configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_BOARD)
+	values := append(scoreValues, candidateValues...)
+	values = append(values, param.PostUid, models.CONTENT_NORMAL, models.CONTENT_NOTICE, param.UserLevel, param.Limit)
+
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.BoardRelatedPost, 0)
+	boardUids := make([]uint, 0)
+	for rows.Next() {
+		item := models.BoardRelatedPost{}
+		var boardUid uint
+		err = rows.Scan(&item.PostUid, &boardUid, &item.Title, &item.Submitted, &item.Hit, &item.Score)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		boardUids = append(boardUids, boardUid)
+	}
+	rows.Close()
+
+	for i := range items {
+		items[i].Board = r.GetBasicBoardConfig(boardUids[i])
+		items[i].Comment = r.board.GetCommentCount(items[i].PostUid)
+		items[i].Like = r.board.GetLikeCount(items[i].PostUid)
+		items[i].Cover = r.board.GetCoverImage(items[i].PostUid)
+	}
+	return items, nil
+}
+
 // 게시글에 등록된 해시태그들 가져오기
 func (r *TsboardBoardViewRepository) GetTags(postUid uint) []models.Pair {
 	items := make([]models.Pair, 0)
@@ -281,10 +376,10 @@ func (r *TsboardBoardViewRepository) GetThumbnailImage(fileUid uint) models.Boar
 // 게시글 작성자의 최근 댓글들 가져오기
 func (r *TsboardBoardViewRepository) GetWriterLatestComment(writerUid uint, limit uint) ([]models.BoardWriterLatestComment, error) {
 	query := fmt.Sprintf(`SELECT uid, board_uid, post_uid, content, submitted 
-												FROM %s%s WHERE user_uid = ? AND status != ? 
+												FROM %s%s WHERE user_uid = ? AND status NOT IN (?, ?) 
 												ORDER BY uid DESC LIMIT ?`, configs.Env.Prefix, models.TABLE_COMMENT)
 
-	rows, err := r.db.Query(query, writerUid, models.CONTENT_REMOVED, limit)
+	rows, err := r.db.Query(query, writerUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -308,10 +403,10 @@ func (r *TsboardBoardViewRepository) GetWriterLatestComment(writerUid uint, limi
 // 게시글 작성자의 최근 포스트들 가져오기
 func (r *TsboardBoardViewRepository) GetWriterLatestPost(writerUid uint, limit uint) ([]models.BoardWriterLatestPost, error) {
 	query := fmt.Sprintf(`SELECT uid, board_uid, title, submitted FROM %s%s 
-												WHERE user_uid = ? AND status != ? 
+												WHERE user_uid = ? AND status NOT IN (?, ?) 
 												ORDER BY uid DESC LIMIT ?`, configs.Env.Prefix, models.TABLE_POST)
 
-	rows, err := r.db.Query(query, writerUid, models.CONTENT_REMOVED, limit)
+	rows, err := r.db.Query(query, writerUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -389,6 +484,9 @@ func (r *TsboardBoardViewRepository) RemoveAttachedFile(fileUid uint, filePath s
 		thumbs := r.RemoveThumbnails(fileUid)
 		removes = append(removes, thumbs...)
 
+		variants := r.RemoveImageVariants(fileUid)
+		removes = append(removes, variants...)
+
 		r.RemoveImageDescription(fileUid)
 		r.RemoveExif(fileUid)
 	}
@@ -415,6 +513,17 @@ func (r *TsboardBoardViewRepository) RemoveImageDescription(fileUid uint) {
 	r.db.Exec(query, fileUid)
 }
 
+// 변형 이미지들 삭제하기
+func (r *TsboardBoardViewRepository) RemoveImageVariants(fileUid uint) []string {
+	removes := make([]string, 0)
+	for _, variant := range r.GetImageVariants(fileUid) {
+		removes = append(removes, variant.Path)
+	}
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE file_uid = ?", configs.Env.Prefix, models.TABLE_FILE_VARIANT)
+	r.db.Exec(query, fileUid)
+	return removes
+}
+
 // 게시글 삭제 상태로 변경하기
 func (r *TsboardBoardViewRepository) RemovePost(postUid uint) error {
 	query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
@@ -475,12 +584,6 @@ func (r *TsboardBoardViewRepository) UpdateLikePost(param models.BoardViewLikePa
 	r.db.Exec(query, param.Liked, time.Now().UnixMilli(), param.PostUid, param.UserUid)
 }
 
-// 조회수 업데이트 하기
-func (r *TsboardBoardViewRepository) UpdatePostHit(postUid uint) {
-	query := fmt.Sprintf("UPDATE %s%s SET hit = hit + 1 WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
-	r.db.Exec(query, postUid)
-}
-
 // 게시글의 소속 게시판 변경하기
 func (r *TsboardBoardViewRepository) UpdatePostBoardUid(targetBoardUid uint, postUid uint) {
 	query := fmt.Sprintf("UPDATE %s%s SET board_uid = ?, modified = ? WHERE uid = ? LIMIT 1",
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type BookmarkRepository interface {
+	GetBookmarkCount(param models.BookmarkListParameter) uint
+	GetBookmarkFolders(userUid uint) []models.BookmarkFolder
+	GetBookmarks(param models.BookmarkListParameter) []models.BookmarkRecord
+	InsertBookmark(param models.BookmarkParameter) error
+	IsBookmarked(postUid uint, userUid uint) bool
+	RemoveBookmark(postUid uint, userUid uint) error
+}
+
+type TsboardBookmarkRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardBookmarkRepository(db *sql.DB) *TsboardBookmarkRepository {
+	return &TsboardBookmarkRepository{db: db}
+}
+
+// 볼 수 있는 게시글에 대한 북마크 수 가져오기
+func (r *TsboardBookmarkRepository) GetBookmarkCount(param models.BookmarkListParameter) uint {
+	var count uint
+	query, values := r.makeVisibleQuery("COUNT(*)", param)
+	r.db.QueryRow(query, values...).Scan(&count)
+	return count
+}
+
+// 북마크 폴더 목록과 폴더별 북마크 수 가져오기 (이름 없는 폴더 포함)
+func (r *TsboardBookmarkRepository) GetBookmarkFolders(userUid uint) []models.BookmarkFolder {
+	folders := make([]models.BookmarkFolder, 0)
+	query := fmt.Sprintf("SELECT folder, COUNT(*) FROM %s%s WHERE user_uid = ? GROUP BY folder ORDER BY folder ASC",
+		configs.Env.Prefix, models.TABLE_BOOKMARK)
+	rows, err := r.db.Query(query, userUid)
+	if err != nil {
+		return folders
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		folder := models.BookmarkFolder{}
+		if err := rows.Scan(&folder.Name, &folder.Count); err != nil {
+			return folders
+		}
+		folders = append(folders, folder)
+	}
+	return folders
+}
+
+// 볼 수 있는 게시글에 대한 북마크들을 최근 순서로 가져오기
+func (r *TsboardBookmarkRepository) GetBookmarks(param models.BookmarkListParameter) []models.BookmarkRecord {
+	items := make([]models.BookmarkRecord, 0)
+	query, values := r.makeVisibleQuery("m.uid, p.board_uid, m.post_uid, m.folder, m.note, m.timestamp", param)
+	query += " ORDER BY m.uid DESC LIMIT ?, ?"
+	values = append(values, (param.Page-1)*param.Bunch, param.Bunch)
+
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.BookmarkRecord{}
+		if err := rows.Scan(&item.Uid, &item.BoardUid, &item.PostUid, &item.Folder, &item.Note, &item.Timestamp); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 북마크 추가하기 (이미 추가한 게시글이면 폴더와 메모만 변경)
+func (r *TsboardBookmarkRepository) InsertBookmark(param models.BookmarkParameter) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (user_uid, board_uid, post_uid, folder, note, timestamp) VALUES (?, ?, ?, ?, ?, ?)
+												ON DUPLICATE KEY UPDATE folder = VALUES(folder), note = VALUES(note)`,
+		configs.Env.Prefix, models.TABLE_BOOKMARK)
+	_, err := r.db.Exec(query, param.UserUid, param.BoardUid, param.PostUid, param.Folder, param.Note, time.Now().UnixMilli())
+	return err
+}
+
+// 게시글을 북마크에 추가했는지 확인하기
+func (r *TsboardBookmarkRepository) IsBookmarked(postUid uint, userUid uint) bool {
+	var uid uint
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE post_uid = ? AND user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOOKMARK)
+	r.db.QueryRow(query, postUid, userUid).Scan(&uid)
+	return uid > 0
+}
+
+// 북마크 삭제하기
+func (r *TsboardBookmarkRepository) RemoveBookmark(postUid uint, userUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE post_uid = ? AND user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOOKMARK)
+	result, err := r.db.Exec(query, postUid, userUid)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected < 1 {
+		return fmt.Errorf("unable to find the bookmark")
+	}
+	return nil
+}
+
+// 삭제되었거나 볼 수 없는 게시판의 글, 다른 사람의 예약글을 제외하는 북마크 쿼리문 만들기 (게시글이 이동했을 수 있으므로 현재 게시판 기준)
+func (r *TsboardBookmarkRepository) makeVisibleQuery(columns string, param models.BookmarkListParameter) (string, []interface{}) {
+	query := fmt.Sprintf(`SELECT %s FROM %s%s AS m
+												JOIN %s%s AS p ON m.post_uid = p.uid
+												JOIN %s%s AS b ON p.board_uid = b.uid
+												WHERE m.user_uid = ? AND p.status != ? AND (p.status != ? OR p.user_uid = ?) AND b.level_view <= ?`,
+		columns, configs.Env.Prefix, models.TABLE_BOOKMARK, configs.Env.Prefix, models.TABLE_POST,
+		configs.Env.Prefix, models.TABLE_BOARD)
+	values := []interface{}{param.UserUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, param.UserUid, param.UserLevel}
+	if len(param.Folder) > 0 {
+		query += " AND m.folder = ?"
+		values = append(values, param.Folder)
+	}
+	return query, values
+}
@@ -47,14 +47,14 @@ func (r *TsboardCommentRepository) FindPostUserUidByUid(commentUid uint) (uint,
 	return postUid, userUid
 }
 
-// 댓글들 가져오기
+// 댓글들 가져오기 (승인 대기중인 댓글은 작성자에게만 보여주기)
 func (r *TsboardCommentRepository) GetComments(param models.CommentListParameter) ([]models.CommentItem, error) {
 	arrow, _ := param.Direction.Query()
 	query := fmt.Sprintf(`SELECT uid, reply_uid, user_uid, content, submitted, modified, status 
-												FROM %s%s WHERE post_uid = ? AND status != ? AND uid %s ?
+												FROM %s%s WHERE post_uid = ? AND status != ? AND (status != ? OR user_uid = ?) AND uid %s ?
 												ORDER BY reply_uid ASC, uid ASC LIMIT ?`, configs.Env.Prefix, models.TABLE_COMMENT, arrow)
 
-	rows, err := r.db.Query(query, param.PostUid, models.CONTENT_REMOVED, param.SinceUid, param.Bunch)
+	rows, err := r.db.Query(query, param.PostUid, models.CONTENT_REMOVED, models.CONTENT_PENDING, param.UserUid, param.SinceUid, param.Bunch)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +87,16 @@ func (r *TsboardCommentRepository) GetComments(param models.CommentListParameter
 	}
 	defer stmtLiked.Close()
 
+	// 댓글에 남겨진 이모지별 반응 수 가져오는 쿼리문 준비
+	query = fmt.Sprintf(`SELECT emoji, COUNT(*), SUM(user_uid = ?) FROM %s%s
+											 WHERE target_uid = ? GROUP BY emoji ORDER BY MIN(uid) ASC`,
+		configs.Env.Prefix, models.TABLE_COMMENT_REACTION)
+	stmtReactions, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmtReactions.Close()
+
 	items := make([]models.CommentItem, 0)
 	for rows.Next() {
 		item := models.CommentItem{}
@@ -98,6 +108,7 @@ func (r *TsboardCommentRepository) GetComments(param models.CommentListParameter
 		item.Writer = r.board.GetWriterInfoForLoop(stmtWriter, item.Writer.UserUid)
 		item.Like = r.GetLikedCountForLoop(stmtLikedCount, item.Uid)
 		item.Liked = r.board.CheckLikedCommentForLoop(stmtLiked, item.Uid, param.UserUid)
+		item.Reactions = r.board.GetReactionsForLoop(stmtReactions, item.Uid, param.UserUid)
 		items = append(items, item)
 	}
 	return items, nil
@@ -183,6 +194,10 @@ func (r *TsboardCommentRepository) InsertComment(param models.CommentWriteParame
 												(reply_uid, board_uid, post_uid, user_uid, content, submitted, modified, status) 
 												VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_COMMENT)
 
+	status := models.CONTENT_NORMAL
+	if param.IsPending {
+		status = models.CONTENT_PENDING
+	}
 	result, err := r.db.Exec(
 		query,
 		0,
@@ -192,7 +207,7 @@ func (r *TsboardCommentRepository) InsertComment(param models.CommentWriteParame
 		param.Content,
 		time.Now().UnixMilli(),
 		0,
-		models.CONTENT_NORMAL,
+		status,
 	)
 	if err != nil {
 		return models.FAILED, err
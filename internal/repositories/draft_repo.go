@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type DraftRepository interface {
+	GetDraft(draftUid uint, userUid uint) (models.DraftItem, error)
+	GetDraftCount(boardUid uint, userUid uint) uint
+	GetDraftList(boardUid uint, userUid uint) []models.DraftListItem
+	GetDraftVersion(draftUid uint, userUid uint) (uint, uint64, bool)
+	GetStagedFiles(draftUid uint) []models.DraftFile
+	GetStagedImages(draftUid uint) []string
+	InsertDraft(param models.DraftSaveParameter) uint
+	InsertStagedFile(draftUid uint, userUid uint, name string, path string, size int64) uint
+	PublishStagedImages(draftUid uint)
+	RemoveDraft(draftUid uint)
+	RemoveStagedFile(draftUid uint, fileUid uint) string
+	RemoveStagedFiles(draftUid uint)
+	RemoveStagedImages(draftUid uint)
+	UpdateDraft(param models.DraftSaveParameter) bool
+}
+
+type TsboardDraftRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardDraftRepository(db *sql.DB) *TsboardDraftRepository {
+	return &TsboardDraftRepository{db: db}
+}
+
+// 내가 임시저장한 글 가져오기
+func (r *TsboardDraftRepository) GetDraft(draftUid uint, userUid uint) (models.DraftItem, error) {
+	item := models.DraftItem{}
+	var format models.ContentFormat
+	query := fmt.Sprintf(`SELECT uid, board_uid, category_uid, version, title, content, format, tags, created, updated
+												FROM %s%s WHERE uid = ? AND user_uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_DRAFT)
+
+	err := r.db.QueryRow(query, draftUid, userUid).Scan(&item.Uid, &item.BoardUid, &item.CategoryUid, &item.Version,
+		&item.Title, &item.Content, &format, &item.Tags, &item.Created, &item.Updated)
+	if err != nil {
+		return item, err
+	}
+	item.Format = format.String()
+	item.Files = r.GetStagedFiles(draftUid)
+	item.Images = r.GetStagedImages(draftUid)
+	return item, nil
+}
+
+// 게시판에 내가 임시저장한 글 개수 가져오기
+func (r *TsboardDraftRepository) GetDraftCount(boardUid uint, userUid uint) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE board_uid = ? AND user_uid = ?",
+		configs.Env.Prefix, models.TABLE_DRAFT)
+	r.db.QueryRow(query, boardUid, userUid).Scan(&count)
+	return count
+}
+
+// 게시판에 내가 임시저장한 글 목록 가져오기 (최근 저장한 순서)
+func (r *TsboardDraftRepository) GetDraftList(boardUid uint, userUid uint) []models.DraftListItem {
+	items := make([]models.DraftListItem, 0)
+	query := fmt.Sprintf(`SELECT uid, version, title, updated FROM %s%s
+												WHERE board_uid = ? AND user_uid = ? ORDER BY updated DESC`, configs.Env.Prefix, models.TABLE_DRAFT)
+	rows, err := r.db.Query(query, boardUid, userUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.DraftListItem{}
+		if err := rows.Scan(&item.Uid, &item.Version, &item.Title, &item.Updated); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 임시저장 글의 현재 버전과 마지막 저장 시각 가져오기 (내 글이 아니거나 없으면 false)
+func (r *TsboardDraftRepository) GetDraftVersion(draftUid uint, userUid uint) (uint, uint64, bool) {
+	var version uint
+	var updated uint64
+	query := fmt.Sprintf("SELECT version, updated FROM %s%s WHERE uid = ? AND user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_DRAFT)
+	if err := r.db.QueryRow(query, draftUid, userUid).Scan(&version, &updated); err != nil {
+		return 0, 0, false
+	}
+	return version, updated, true
+}
+
+// 임시저장 글에 미리 올려둔 첨부파일들 가져오기
+func (r *TsboardDraftRepository) GetStagedFiles(draftUid uint) []models.DraftFile {
+	files := make([]models.DraftFile, 0)
+	query := fmt.Sprintf("SELECT uid, name, size, path FROM %s%s WHERE draft_uid = ? ORDER BY uid ASC",
+		configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+	rows, err := r.db.Query(query, draftUid)
+	if err != nil {
+		return files
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		file := models.DraftFile{}
+		if err := rows.Scan(&file.Uid, &file.Name, &file.Size, &file.Path); err != nil {
+			return files
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// 임시저장 글을 쓰면서 삽입한 이미지 경로들 가져오기
+func (r *TsboardDraftRepository) GetStagedImages(draftUid uint) []string {
+	paths := make([]string, 0)
+	query := fmt.Sprintf("SELECT path FROM %s%s WHERE draft_uid = ? ORDER BY uid ASC",
+		configs.Env.Prefix, models.TABLE_IMAGE)
+	rows, err := r.db.Query(query, draftUid)
+	if err != nil {
+		return paths
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return paths
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// 새 임시저장 글 추가하기
+func (r *TsboardDraftRepository) InsertDraft(param models.DraftSaveParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(board_uid, user_uid, category_uid, title, content, format, tags, version, created, updated)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_DRAFT)
+	now := time.Now().UnixMilli()
+	result, err := r.db.Exec(query, param.BoardUid, param.UserUid, param.CategoryUid,
+		param.Title, param.Content, param.Format, param.Tags, 1, now, now)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 임시저장 글에 첨부파일 미리 올려두기
+func (r *TsboardDraftRepository) InsertStagedFile(draftUid uint, userUid uint, name string, path string, size int64) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s (draft_uid, user_uid, name, path, size, timestamp)
+												VALUES (?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+	result, err := r.db.Exec(query, draftUid, userUid, name, path, size, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 임시저장 글을 쓰면서 삽입한 이미지들을 일반 삽입 이미지로 전환하기 (게시글로 발행할 때)
+func (r *TsboardDraftRepository) PublishStagedImages(draftUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET draft_uid = 0 WHERE draft_uid = ?", configs.Env.Prefix, models.TABLE_IMAGE)
+	r.db.Exec(query, draftUid)
+}
+
+// 임시저장 글 삭제하기 (미리 올려둔 첨부파일, 삽입 이미지 레코드들도 함께 삭제)
+func (r *TsboardDraftRepository) RemoveDraft(draftUid uint) {
+	r.RemoveStagedFiles(draftUid)
+	r.RemoveStagedImages(draftUid)
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_DRAFT)
+	r.db.Exec(query, draftUid)
+}
+
+// 미리 올려둔 첨부파일 하나를 삭제하고 경로 반환
+func (r *TsboardDraftRepository) RemoveStagedFile(draftUid uint, fileUid uint) string {
+	var path string
+	query := fmt.Sprintf("SELECT path FROM %s%s WHERE uid = ? AND draft_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+	if err := r.db.QueryRow(query, fileUid, draftUid).Scan(&path); err != nil {
+		return ""
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+	r.db.Exec(query, fileUid)
+	return path
+}
+
+// 미리 올려둔 첨부파일 레코드들 삭제하기 (실제 파일은 호출하는 쪽에서 처리)
+func (r *TsboardDraftRepository) RemoveStagedFiles(draftUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE draft_uid = ?", configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+	r.db.Exec(query, draftUid)
+}
+
+// 임시저장 글을 쓰면서 삽입한 이미지 레코드들 삭제하기 (실제 파일은 호출하는 쪽에서 처리)
+func (r *TsboardDraftRepository) RemoveStagedImages(draftUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE draft_uid = ?", configs.Env.Prefix, models.TABLE_IMAGE)
+	r.db.Exec(query, draftUid)
+}
+
+// 마지막으로 불러온 버전이 그대로일 때만 임시저장 글 업데이트 (Force 면 버전 무시)
+func (r *TsboardDraftRepository) UpdateDraft(param models.DraftSaveParameter) bool {
+	versionQuery := "AND version = ?"
+	if param.Force {
+		versionQuery = "AND version > ?"
+		param.Version = 0
+	}
+	query := fmt.Sprintf(`UPDATE %s%s SET category_uid = ?, title = ?, content = ?, format = ?, tags = ?,
+												version = version + 1, updated = ? WHERE uid = ? AND user_uid = ? %s LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_DRAFT, versionQuery)
+
+	result, err := r.db.Exec(query, param.CategoryUid, param.Title, param.Content, param.Format, param.Tags,
+		time.Now().UnixMilli(), param.DraftUid, param.UserUid, param.Version)
+	if err != nil {
+		return false
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false
+	}
+	return affected > 0
+}
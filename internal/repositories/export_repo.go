@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ExportRepository interface {
+	FindExpiredExports(before uint64, bunch uint) []models.ExportItem
+	FindPostUids(boardUid uint, sinceUid uint, bunch uint) []uint
+	GetComments(postUid uint) []models.ExportComment
+	GetExport(exportUid uint) models.ExportItem
+	GetFiles(postUid uint) []models.ExportFile
+	GetPost(boardUid uint, postUid uint) (models.ExportPost, error)
+	InsertExport(param models.ExportInsertParameter) uint
+	UpdateExportDone(exportUid uint, path string, size uint)
+	UpdateExportExpired(exportUid uint)
+	UpdateExportFailed(exportUid uint, message string)
+}
+
+type TsboardExportRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardExportRepository(db *sql.DB) *TsboardExportRepository {
+	return &TsboardExportRepository{db: db}
+}
+
+// 보관 기간이 지난 내보내기 완료 항목들 가져오기
+func (r *TsboardExportRepository) FindExpiredExports(before uint64, bunch uint) []models.ExportItem {
+	items := make([]models.ExportItem, 0)
+	query := fmt.Sprintf("SELECT uid, path FROM %s%s WHERE status = ? AND completed < ? ORDER BY uid ASC LIMIT ?",
+		configs.Env.Prefix, models.TABLE_EXPORT)
+	rows, err := r.db.Query(query, models.EXPORT_DONE, before, bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ExportItem{}
+		if err := rows.Scan(&item.Uid, &item.Path); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 게시판에서 내보낼 게시글 번호들을 오래된 순서로 가져오기 (삭제된 글은 제외)
+func (r *TsboardExportRepository) FindPostUids(boardUid uint, sinceUid uint, bunch uint) []uint {
+	items := make([]uint, 0)
+	query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE board_uid = ? AND uid > ? AND status != ?
+												ORDER BY uid ASC LIMIT ?`, configs.Env.Prefix, models.TABLE_POST)
+	rows, err := r.db.Query(query, boardUid, sinceUid, models.CONTENT_REMOVED, bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid uint
+		if err := rows.Scan(&uid); err != nil {
+			return items
+		}
+		items = append(items, uid)
+	}
+	return items
+}
+
+// 게시글에 달린 댓글들을 작성 순서대로 가져오기 (삭제된 댓글은 제외)
+func (r *TsboardExportRepository) GetComments(postUid uint) []models.ExportComment {
+	items := make([]models.ExportComment, 0)
+	query := fmt.Sprintf(`SELECT c.uid, c.reply_uid, c.user_uid, IFNULL(u.name, ''), c.content, c.submitted, c.modified
+												FROM %s%s AS c LEFT JOIN %s%s AS u ON u.uid = c.user_uid
+												WHERE c.post_uid = ? AND c.status != ? ORDER BY c.uid ASC`,
+		configs.Env.Prefix, models.TABLE_COMMENT, configs.Env.Prefix, models.TABLE_USER)
+	rows, err := r.db.Query(query, postUid, models.CONTENT_REMOVED)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ExportComment{}
+		err := rows.Scan(&item.Uid, &item.ReplyUid, &item.WriterUid, &item.Writer, &item.Content, &item.Submitted, &item.Modified)
+		if err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 내보내기 요청 정보 가져오기
+func (r *TsboardExportRepository) GetExport(exportUid uint) models.ExportItem {
+	item := models.ExportItem{}
+	query := fmt.Sprintf(`SELECT uid, board_uid, post_uid, user_uid, format, status, path, size, message, created, completed
+												FROM %s%s WHERE uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_EXPORT)
+	r.db.QueryRow(query, exportUid).Scan(&item.Uid, &item.BoardUid, &item.PostUid, &item.UserUid, &item.Format,
+		&item.Status, &item.Path, &item.Size, &item.Message, &item.Created, &item.Completed)
+	return item
+}
+
+// 게시글에 첨부된 파일들 가져오기 (압축 파일 안의 경로는 서비스에서 지정)
+func (r *TsboardExportRepository) GetFiles(postUid uint) []models.ExportFile {
+	items := make([]models.ExportFile, 0)
+	query := fmt.Sprintf("SELECT uid, name, path FROM %s%s WHERE post_uid = ? ORDER BY uid ASC", configs.Env.Prefix, models.TABLE_FILE)
+	rows, err := r.db.Query(query, postUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ExportFile{}
+		if err := rows.Scan(&item.Uid, &item.Name, &item.Origin); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 내보낼 게시글 가져오기 (작성자 이름과 카테고리 이름 포함)
+func (r *TsboardExportRepository) GetPost(boardUid uint, postUid uint) (models.ExportPost, error) {
+	item := models.ExportPost{}
+	var format models.ContentFormat
+	var status models.Status
+	query := fmt.Sprintf(`SELECT p.uid, p.title, IFNULL(c.name, ''), p.user_uid, IFNULL(u.name, ''), p.format, IFNULL(p.content, ''),
+												IFNULL(p.source, ''), p.status, p.hit, p.submitted, p.modified FROM %s%s AS p
+												LEFT JOIN %s%s AS u ON u.uid = p.user_uid
+												LEFT JOIN %s%s AS c ON c.uid = p.category_uid
+												WHERE p.uid = ? AND p.board_uid = ? AND p.status != ? LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_USER, configs.Env.Prefix, models.TABLE_BOARD_CAT)
+	err := r.db.QueryRow(query, postUid, boardUid, models.CONTENT_REMOVED).Scan(&item.Uid, &item.Title, &item.Category,
+		&item.WriterUid, &item.Writer, &format, &item.Content, &item.Source, &status, &item.Hit, &item.Submitted, &item.Modified)
+	if err != nil {
+		return item, err
+	}
+	item.Format = format.String()
+	item.Notice = status == models.CONTENT_NOTICE
+	item.Secret = status == models.CONTENT_SECRET
+	return item, nil
+}
+
+// 내보내기 요청 추가하기
+func (r *TsboardExportRepository) InsertExport(param models.ExportInsertParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, post_uid, user_uid, format, status, created)
+												VALUES (?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_EXPORT)
+	result, err := r.db.Exec(query, param.BoardUid, param.PostUid, param.UserUid, param.Format, models.EXPORT_PENDING, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 내보내기 완료 처리하고 압축 파일 경로 저장하기
+func (r *TsboardExportRepository) UpdateExportDone(exportUid uint, path string, size uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, path = ?, size = ?, message = '', completed = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_EXPORT)
+	r.db.Exec(query, models.EXPORT_DONE, path, size, time.Now().UnixMilli(), exportUid)
+}
+
+// 보관 기간이 지나 압축 파일을 삭제한 내보내기 항목 표시하기
+func (r *TsboardExportRepository) UpdateExportExpired(exportUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, path = '' WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_EXPORT)
+	r.db.Exec(query, models.EXPORT_EXPIRED, exportUid)
+}
+
+// 내보내기 실패 처리하기
+func (r *TsboardExportRepository) UpdateExportFailed(exportUid uint, message string) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, message = ?, completed = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_EXPORT)
+	r.db.Exec(query, models.EXPORT_FAILED, message, time.Now().UnixMilli(), exportUid)
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type FieldRepository interface {
+	GetFieldCount(boardUid uint) uint
+	GetFields(boardUid uint) []models.BoardField
+	InsertField(boardUid uint, field models.BoardField) uint
+	RemoveField(boardUid uint, fieldUid uint) error
+	RemoveFieldValues(postUid uint)
+	UpdateField(boardUid uint, field models.BoardField) error
+	UpdateFieldValues(postUid uint, values []models.BoardFieldValue)
+}
+
+type TsboardFieldRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardFieldRepository(db *sql.DB) *TsboardFieldRepository {
+	return &TsboardFieldRepository{db: db}
+}
+
+// 게시판에 정의된 사용자 정의 필드 개수 가져오기
+func (r *TsboardFieldRepository) GetFieldCount(boardUid uint) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE board_uid = ?", configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	r.db.QueryRow(query, boardUid).Scan(&count)
+	return count
+}
+
+// 게시판에 정의된 사용자 정의 필드 목록 가져오기
+func (r *TsboardFieldRepository) GetFields(boardUid uint) []models.BoardField {
+	items := make([]models.BoardField, 0)
+	query := fmt.Sprintf("SELECT uid, name, type, required, options FROM %s%s WHERE board_uid = ? ORDER BY uid ASC",
+		configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	rows, err := r.db.Query(query, boardUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.BoardField{Options: make([]string, 0)}
+		var required uint8
+		var options string
+		if err := rows.Scan(&item.Uid, &item.Name, &item.Type, &required, &options); err != nil {
+			return items
+		}
+		item.Required = required > 0
+		if len(options) > 0 {
+			item.Options = strings.Split(options, ",")
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 게시판에 새 사용자 정의 필드 추가하기
+func (r *TsboardFieldRepository) InsertField(boardUid uint, field models.BoardField) uint {
+	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, name, type, required, options) VALUES (?, ?, ?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	result, err := r.db.Exec(query, boardUid, field.Name, field.Type, field.Required, strings.Join(field.Options, ","))
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 게시판의 사용자 정의 필드와 게시글들에 입력된 값들 삭제하기 (해당 게시판의 필드일 때만, 값들은 외래 키 때문에 먼저 삭제)
+func (r *TsboardFieldRepository) RemoveField(boardUid uint, fieldUid uint) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var uid uint
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE uid = ? AND board_uid = ? LIMIT 1 FOR UPDATE",
+		configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	if err := tx.QueryRow(query, fieldUid, boardUid).Scan(&uid); err != nil {
+		return fmt.Errorf("unable to find the field")
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE field_uid = ?", configs.Env.Prefix, models.TABLE_POST_FIELD)
+	if _, err := tx.Exec(query, uid); err != nil {
+		return err
+	}
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? AND board_uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	if _, err := tx.Exec(query, uid, boardUid); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 게시글에 입력된 사용자 정의 필드 값들 모두 삭제하기
+func (r *TsboardFieldRepository) RemoveFieldValues(postUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE post_uid = ?", configs.Env.Prefix, models.TABLE_POST_FIELD)
+	r.db.Exec(query, postUid)
+}
+
+// 게시판의 사용자 정의 필드 수정하기
+func (r *TsboardFieldRepository) UpdateField(boardUid uint, field models.BoardField) error {
+	query := fmt.Sprintf("UPDATE %s%s SET name = ?, type = ?, required = ?, options = ? WHERE uid = ? AND board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_FIELD)
+	_, err := r.db.Exec(query, field.Name, field.Type, field.Required, strings.Join(field.Options, ","), field.Uid, boardUid)
+	return err
+}
+
+// 게시글의 사용자 정의 필드 값들을 새로 저장하기 (빈 값은 저장하지 않음)
+func (r *TsboardFieldRepository) UpdateFieldValues(postUid uint, values []models.BoardFieldValue) {
+	r.RemoveFieldValues(postUid)
+
+	marks := make([]string, 0)
+	args := make([]interface{}, 0)
+	for _, value := range values {
+		if len(value.Value) < 1 {
+			continue
+		}
+		marks = append(marks, "(?, ?, ?)")
+		args = append(args, postUid, value.FieldUid, value.Value)
+	}
+	if len(marks) < 1 {
+		return
+	}
+	query := fmt.Sprintf("INSERT INTO %s%s (post_uid, field_uid, value) VALUES %s",
+		configs.Env.Prefix, models.TABLE_POST_FIELD, strings.Join(marks, ", "))
+	r.db.Exec(query, args...)
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type HashtagRepository interface {
+	BlockHashtag(hashtagUid uint, blocked bool) error
+	GetHashtag(hashtagUid uint) models.Hashtag
+	GetHashtagByName(name string) models.Hashtag
+	GetHashtagList(param models.HashtagListParameter) []models.Hashtag
+	GetTrendingTags(since int64, bunch uint) []models.HashtagCloudItem
+	MergeHashtag(fromUid uint, toUid uint) (uint, error)
+	RecountHashtags() uint
+	RemoveHashtag(hashtagUid uint) error
+	RemoveUnusedHashtags() uint
+	RenameHashtag(hashtagUid uint, name string) error
+}
+
+type TsboardHashtagRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardHashtagRepository(db *sql.DB) *TsboardHashtagRepository {
+	return &TsboardHashtagRepository{db: db}
+}
+
+// 해시태그 차단 여부 변경하기 (차단하면 게시글들과의 연결도 모두 끊음)
+func (r *TsboardHashtagRepository) BlockHashtag(hashtagUid uint, blocked bool) error {
+	if !blocked {
+		query := fmt.Sprintf("UPDATE %s%s SET blocked = 0 WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
+		_, err := r.db.Exec(query, hashtagUid)
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE hashtag_uid = ?", configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	if _, err := r.db.Exec(query, hashtagUid); err != nil {
+		return err
+	}
+	query = fmt.Sprintf("UPDATE %s%s SET blocked = 1, used = 0 WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
+	_, err := r.db.Exec(query, hashtagUid)
+	return err
+}
+
+// 해시태그 정보 가져오기
+func (r *TsboardHashtagRepository) GetHashtag(hashtagUid uint) models.Hashtag {
+	item := models.Hashtag{}
+	query := fmt.Sprintf("SELECT uid, name, used, blocked, timestamp FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_HASHTAG)
+	r.db.QueryRow(query, hashtagUid).Scan(&item.Uid, &item.Name, &item.Used, &item.Blocked, &item.Timestamp)
+	return item
+}
+
+// 해시태그 이름으로 정보 가져오기
+func (r *TsboardHashtagRepository) GetHashtagByName(name string) models.Hashtag {
+	item := models.Hashtag{}
+	query := fmt.Sprintf("SELECT uid, name, used, blocked, timestamp FROM %s%s WHERE name = ? ORDER BY uid ASC LIMIT 1",
+		configs.Env.Prefix, models.TABLE_HASHTAG)
+	r.db.QueryRow(query, name).Scan(&item.Uid, &item.Name, &item.Used, &item.Blocked, &item.Timestamp)
+	return item
+}
+
+// (관리화면) 해시태그 목록 가져오기
+func (r *TsboardHashtagRepository) GetHashtagList(param models.HashtagListParameter) []models.Hashtag {
+	items := make([]models.Hashtag, 0)
+	last := 1 + param.MaxUid - (param.Page-1)*param.Bunch
+	query := fmt.Sprintf(`SELECT uid, name, used, blocked, timestamp FROM %s%s
+												WHERE uid < ? ORDER BY uid DESC LIMIT ?`, configs.Env.Prefix, models.TABLE_HASHTAG)
+	rows, err := r.db.Query(query, last, param.Bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.Hashtag{}
+		if err := rows.Scan(&item.Uid, &item.Name, &item.Used, &item.Blocked, &item.Timestamp); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 지정된 시각 이후 공개된 게시글들에 많이 사용된 해시태그들 가져오기
+func (r *TsboardHashtagRepository) GetTrendingTags(since int64, bunch uint) []models.HashtagCloudItem {
+	items := make([]models.HashtagCloudItem, 0)
+	query := fmt.Sprintf(`SELECT h.uid, h.name, COUNT(*) AS total FROM %s%s AS ph
+												JOIN %s%s AS p ON p.uid = ph.post_uid
+												JOIN %s%s AS h ON h.uid = ph.hashtag_uid
+												WHERE p.status = ? AND p.submitted >= ? AND h.blocked = 0
+												GROUP BY h.uid, h.name ORDER BY total DESC, h.uid DESC LIMIT ?`,
+		configs.Env.Prefix, models.TABLE_POST_HASHTAG, configs.Env.Prefix, models.TABLE_POST, configs.Env.Prefix, models.TABLE_HASHTAG)
+	rows, err := r.db.Query(query, models.CONTENT_NORMAL, since, bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.HashtagCloudItem{}
+		if err := rows.Scan(&item.Uid, &item.Name, &item.Count); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 해시태그를 다른 해시태그로 합치고 옮겨진 게시글 연결 개수 반환하기 (하나의 트랜잭션으로 처리)
+func (r *TsboardHashtagRepository) MergeHashtag(fromUid uint, toUid uint) (uint, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`DELETE a FROM %s%s AS a JOIN %s%s AS b ON a.post_uid = b.post_uid
+												WHERE a.hashtag_uid = ? AND b.hashtag_uid = ?`,
+		configs.Env.Prefix, models.TABLE_POST_HASHTAG, configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	if _, err := tx.Exec(query, fromUid, toUid); err != nil {
+		return 0, err
+	}
+
+	query = fmt.Sprintf("UPDATE %s%s SET hashtag_uid = ? WHERE hashtag_uid = ?", configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	result, err := tx.Exec(query, toUid, fromUid)
+	if err != nil {
+		return 0, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
+	if _, err := tx.Exec(query, fromUid); err != nil {
+		return 0, err
+	}
+
+	query = fmt.Sprintf("UPDATE %s%s SET used = (SELECT COUNT(*) FROM %s%s WHERE hashtag_uid = ?) WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_HASHTAG, configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	if _, err := tx.Exec(query, toUid, toUid); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return uint(moved), nil
+}
+
+// 게시글 연결 정보를 기준으로 모든 해시태그의 사용 횟수 다시 계산하고 바뀐 개수 반환하기
+func (r *TsboardHashtagRepository) RecountHashtags() uint {
+	query := fmt.Sprintf(`UPDATE %s%s AS h SET h.used = (SELECT COUNT(*) FROM %s%s AS ph WHERE ph.hashtag_uid = h.uid)
+												WHERE h.blocked = 0`, configs.Env.Prefix, models.TABLE_HASHTAG, configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0
+	}
+	updated, _ := result.RowsAffected()
+	return uint(updated)
+}
+
+// 해시태그와 게시글 연결 정보 모두 삭제하기
+func (r *TsboardHashtagRepository) RemoveHashtag(hashtagUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE hashtag_uid = ?", configs.Env.Prefix, models.TABLE_POST_HASHTAG)
+	if _, err := r.db.Exec(query, hashtagUid); err != nil {
+		return err
+	}
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
+	_, err := r.db.Exec(query, hashtagUid)
+	return err
+}
+
+// 어떤 게시글에도 연결되지 않은 해시태그들 삭제하고 삭제한 개수 반환하기 (차단된 태그는 유지)
+func (r *TsboardHashtagRepository) RemoveUnusedHashtags() uint {
+	query := fmt.Sprintf(`DELETE FROM %s%s WHERE blocked = 0 AND NOT EXISTS
+												(SELECT 1 FROM %s%s AS ph WHERE ph.hashtag_uid = %s%s.uid)`,
+		configs.Env.Prefix, models.TABLE_HASHTAG, configs.Env.Prefix, models.TABLE_POST_HASHTAG, configs.Env.Prefix, models.TABLE_HASHTAG)
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0
+	}
+	removed, _ := result.RowsAffected()
+	return uint(removed)
+}
+
+// 해시태그 이름 변경하기
+func (r *TsboardHashtagRepository) RenameHashtag(hashtagUid uint, name string) error {
+	query := fmt.Sprintf("UPDATE %s%s SET name = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_HASHTAG)
+	_, err := r.db.Exec(query, name, hashtagUid)
+	return err
+}
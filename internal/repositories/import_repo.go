@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ImportRepository interface {
+	GetImportMap(format models.ImportFormat, kind models.ImportKind) map[string]uint
+	InsertBoard(groupUid uint, board models.ImportBoard) uint
+	InsertComment(param models.ImportCommentParameter) uint
+	InsertImportMap(format models.ImportFormat, kind models.ImportKind, sourceId string, targetUid uint, legacyPassword string)
+	InsertPost(param models.ImportPostParameter) uint
+	InsertUser(user models.ImportUser, name string) uint
+	IsPasswordResetRequired(userUid uint) bool
+}
+
+type TsboardImportRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardImportRepository(db *sql.DB) *TsboardImportRepository {
+	return &TsboardImportRepository{db: db}
+}
+
+// 이전에 옮겨온 원본 고유번호와 새 고유번호 대응표 가져오기
+func (r *TsboardImportRepository) GetImportMap(format models.ImportFormat, kind models.ImportKind) map[string]uint {
+	items := make(map[string]uint)
+	query := fmt.Sprintf("SELECT source_id, target_uid FROM %s%s WHERE format = ? AND kind = ?",
+		configs.Env.Prefix, models.TABLE_IMPORT_MAP)
+	rows, err := r.db.Query(query, format, kind)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sourceId string
+		var targetUid uint
+		if err := rows.Scan(&sourceId, &targetUid); err != nil {
+			return items
+		}
+		items[sourceId] = targetUid
+	}
+	return items
+}
+
+// 옮겨온 게시판 추가하기 (이름과 설명 외에는 새 게시판 기본값 사용)
+func (r *TsboardImportRepository) InsertBoard(groupUid uint, board models.ImportBoard) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(id, group_uid, admin_uid, type, name, info,
+													row_count, width, use_category, level_list, level_view, level_write,
+													level_comment, level_download, point_view, point_write, point_comment, point_download)
+													VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		configs.Env.Prefix, models.TABLE_BOARD)
+	result, err := r.db.Exec(
+		query,
+		board.Id,
+		groupUid,
+		models.CREATE_BOARD_ADMIN,
+		models.CREATE_BOARD_TYPE,
+		board.Name,
+		board.Info,
+		models.CREATE_BOARD_ROWS,
+		models.CREATE_BOARD_WIDTH,
+		models.CREATE_BOARD_USE_CAT,
+		models.CREATE_BOARD_LV_LIST,
+		models.CREATE_BOARD_LV_VIEW,
+		models.CREATE_BOARD_LV_WRITE,
+		models.CREATE_BOARD_LV_COMMENT,
+		models.CREATE_BOARD_LV_DOWNLOAD,
+		models.CREATE_BOARD_PT_VIEW,
+		models.CREATE_BOARD_PT_WRITE,
+		models.CREATE_BOARD_PT_COMMENT,
+		models.CREATE_BOARD_PT_DOWNLOAD,
+	)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 옮겨온 댓글 추가하기 (원래 작성 시각 유지)
+func (r *TsboardImportRepository) InsertComment(param models.ImportCommentParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(reply_uid, board_uid, post_uid, user_uid, content, submitted, modified, status)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_COMMENT)
+	result, err := r.db.Exec(query, 0, param.BoardUid, param.PostUid, param.UserUid, param.Content,
+		param.Submitted, param.Modified, models.CONTENT_NORMAL)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 원본 고유번호와 새 고유번호 대응 관계 기록하기
+func (r *TsboardImportRepository) InsertImportMap(format models.ImportFormat, kind models.ImportKind, sourceId string, targetUid uint, legacyPassword string) {
+	query := fmt.Sprintf(`INSERT INTO %s%s (format, kind, source_id, target_uid, legacy_password, timestamp)
+												VALUES (?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_IMPORT_MAP)
+	r.db.Exec(query, format, kind, sourceId, targetUid, legacyPassword, time.Now().UnixMilli())
+}
+
+// 옮겨온 게시글 추가하기 (원래 작성 시각과 조회수 유지)
+func (r *TsboardImportRepository) InsertPost(param models.ImportPostParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(board_uid, user_uid, category_uid, title, content, format, source, submitted, modified, hit, status,
+												publish_at, expire_at, reserved_status, expire_hide, created)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_POST)
+	result, err := r.db.Exec(query, param.BoardUid, param.UserUid, param.CategoryUid, param.Title, param.Content,
+		models.FORMAT_HTML, "", param.Submitted, param.Modified, param.Hit, param.Status, 0, 0, param.Status, 0, param.Submitted)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 옮겨온 회원 추가하기 (비밀번호는 비워두어 재설정하기 전까지 로그인 불가)
+func (r *TsboardImportRepository) InsertUser(user models.ImportUser, name string) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+											(id, name, password, profile, level, point, signature, signup, signin, blocked)
+											VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_USER)
+	result, err := r.db.Exec(query, user.Email, name, "", "", user.Level, user.Point, user.Signature, user.Signup, 0, 0)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 다른 게시판 엔진에서 옮겨온 뒤 아직 비밀번호를 재설정하지 않은 회원인지 확인
+func (r *TsboardImportRepository) IsPasswordResetRequired(userUid uint) bool {
+	var uid uint
+	query := fmt.Sprintf(`SELECT u.uid FROM %s%s AS u JOIN %s%s AS m ON m.target_uid = u.uid AND m.kind = ?
+												WHERE u.uid = ? AND u.password = '' LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_USER, configs.Env.Prefix, models.TABLE_IMPORT_MAP)
+	r.db.QueryRow(query, models.IMPORT_USER, userUid).Scan(&uid)
+	return uid > 0
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type JobRepository interface {
+	ClaimJob(jobUid uint) bool
+	FindPendingJobs(limit uint) []models.JobItem
+	GetJobsByPost(postUid uint) []models.JobItem
+	GetJobList(param models.JobListParameter) []models.JobItem
+	InsertJob(param models.JobInsertParameter) uint
+	ResetRunningJobs()
+	UpdateJobDone(jobUid uint)
+	UpdateJobFailed(jobUid uint, attempts uint, message string)
+	UpdateJobPending(jobUid uint) error
+	UpdateJobRetry(jobUid uint, attempts uint, message string, runAfter int64)
+}
+
+type TsboardJobRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardJobRepository(db *sql.DB) *TsboardJobRepository {
+	return &TsboardJobRepository{db: db}
+}
+
+// 공통으로 가져오는 작업 컬럼들
+const JOB_COLUMNS = "uid, type, status, board_uid, post_uid, file_uid, path, attempts, message, created, updated"
+
+// 대기 중인 작업을 실행 중으로 바꾸고, 다른 워커가 먼저 가져갔다면 false 반환
+func (r *TsboardJobRepository) ClaimJob(jobUid uint) bool {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, updated = ? WHERE uid = ? AND status = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_JOB)
+	result, err := r.db.Exec(query, models.JOB_RUNNING, time.Now().UnixMilli(), jobUid, models.JOB_PENDING)
+	if err != nil {
+		return false
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false
+	}
+	return affected > 0
+}
+
+// 실행 가능한 대기 작업들 가져오기
+func (r *TsboardJobRepository) FindPendingJobs(limit uint) []models.JobItem {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE status = ? AND run_after <= ? ORDER BY uid ASC LIMIT ?",
+		JOB_COLUMNS, configs.Env.Prefix, models.TABLE_JOB)
+	rows, err := r.db.Query(query, models.JOB_PENDING, time.Now().UnixMilli(), limit)
+	if err != nil {
+		return make([]models.JobItem, 0)
+	}
+	defer rows.Close()
+	return r.makeJobItems(rows)
+}
+
+// 게시글에 연결된 작업들 가져오기
+func (r *TsboardJobRepository) GetJobsByPost(postUid uint) []models.JobItem {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE post_uid = ? ORDER BY uid ASC",
+		JOB_COLUMNS, configs.Env.Prefix, models.TABLE_JOB)
+	rows, err := r.db.Query(query, postUid)
+	if err != nil {
+		return make([]models.JobItem, 0)
+	}
+	defer rows.Close()
+	return r.makeJobItems(rows)
+}
+
+// 작업 목록 가져오기 (관리화면용)
+func (r *TsboardJobRepository) GetJobList(param models.JobListParameter) []models.JobItem {
+	last := 1 + param.MaxUid - (param.Page-1)*param.Bunch
+	whereQuery := ""
+	if !param.AnyState {
+		whereQuery = fmt.Sprintf("AND status = %d", param.Status)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE uid < ? %s ORDER BY uid DESC LIMIT ?",
+		JOB_COLUMNS, configs.Env.Prefix, models.TABLE_JOB, whereQuery)
+	rows, err := r.db.Query(query, last, param.Bunch)
+	if err != nil {
+		return make([]models.JobItem, 0)
+	}
+	defer rows.Close()
+	return r.makeJobItems(rows)
+}
+
+// 새 작업 추가하기
+func (r *TsboardJobRepository) InsertJob(param models.JobInsertParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(type, status, board_uid, post_uid, file_uid, path, attempts, message, run_after, created, updated)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_JOB)
+	now := time.Now().UnixMilli()
+	result, err := r.db.Exec(query, param.Type, models.JOB_PENDING, param.BoardUid, param.PostUid,
+		param.FileUid, param.Path, 0, "", now, now, now)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 쿼리 결과를 작업 항목들로 변환하기
+func (r *TsboardJobRepository) makeJobItems(rows *sql.Rows) []models.JobItem {
+	items := make([]models.JobItem, 0)
+	for rows.Next() {
+		item := models.JobItem{}
+		err := rows.Scan(&item.Uid, &item.Type, &item.Status, &item.BoardUid, &item.PostUid,
+			&item.FileUid, &item.Path, &item.Attempts, &item.Message, &item.Created, &item.Updated)
+		if err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 서버 재시작 등으로 중단된 작업들을 다시 대기 상태로 되돌리기
+func (r *TsboardJobRepository) ResetRunningJobs() {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE status = ?", configs.Env.Prefix, models.TABLE_JOB)
+	r.db.Exec(query, models.JOB_PENDING, models.JOB_RUNNING)
+}
+
+// 작업 완료 처리하기
+func (r *TsboardJobRepository) UpdateJobDone(jobUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, message = ?, updated = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_JOB)
+	r.db.Exec(query, models.JOB_DONE, "", time.Now().UnixMilli(), jobUid)
+}
+
+// 재시도 횟수를 모두 소진한 작업을 실패 처리하기
+func (r *TsboardJobRepository) UpdateJobFailed(jobUid uint, attempts uint, message string) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ?, attempts = ?, message = ?, updated = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_JOB)
+	r.db.Exec(query, models.JOB_FAILED, attempts, utils.CutString(message, 300), time.Now().UnixMilli(), jobUid)
+}
+
+// 실패한 작업을 처음부터 다시 대기 상태로 되돌리기
+func (r *TsboardJobRepository) UpdateJobPending(jobUid uint) error {
+	query := fmt.Sprintf(`UPDATE %s%s SET status = ?, attempts = ?, message = ?, run_after = ?, updated = ?
+												WHERE uid = ? AND status = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_JOB)
+	now := time.Now().UnixMilli()
+	_, err := r.db.Exec(query, models.JOB_PENDING, 0, "", now, now, jobUid, models.JOB_FAILED)
+	return err
+}
+
+// 실패한 작업을 지정된 시각 이후에 재시도하도록 업데이트
+func (r *TsboardJobRepository) UpdateJobRetry(jobUid uint, attempts uint, message string, runAfter int64) {
+	query := fmt.Sprintf(`UPDATE %s%s SET status = ?, attempts = ?, message = ?, run_after = ?, updated = ?
+												WHERE uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_JOB)
+	r.db.Exec(query, models.JOB_PENDING, attempts, utils.CutString(message, 300), runAfter, time.Now().UnixMilli(), jobUid)
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type MentionRepository interface {
+	FindUserUidsByNames(names []string) []uint
+	GetMentionedUids(postUid uint, commentUid uint) []uint
+	GetSuggestionNames(input string, actionUserUid uint, bunch uint) []models.UserBasicInfo
+	InsertMention(param models.MentionParameter, targetUserUid uint)
+	RemoveMention(postUid uint, commentUid uint, targetUserUid uint)
+}
+
+type TsboardMentionRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardMentionRepository(db *sql.DB) *TsboardMentionRepository {
+	return &TsboardMentionRepository{db: db}
+}
+
+// 이름들에 해당하는 (차단되지 않은) 회원 번호들 가져오기
+func (r *TsboardMentionRepository) FindUserUidsByNames(names []string) []uint {
+	uids := make([]uint, 0)
+	if len(names) < 1 {
+		return uids
+	}
+	marks := strings.TrimSuffix(strings.Repeat("?,", len(names)), ",")
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE name IN (%s) AND blocked = 0",
+		configs.Env.Prefix, models.TABLE_USER, marks)
+
+	values := make([]interface{}, 0)
+	for _, name := range names {
+		values = append(values, name)
+	}
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return uids
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid uint
+		if err := rows.Scan(&uid); err != nil {
+			return uids
+		}
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+// 게시글 본문 혹은 댓글에서 이미 언급한 회원 번호들 가져오기
+func (r *TsboardMentionRepository) GetMentionedUids(postUid uint, commentUid uint) []uint {
+	uids := make([]uint, 0)
+	query := fmt.Sprintf("SELECT to_uid FROM %s%s WHERE post_uid = ? AND comment_uid = ?",
+		configs.Env.Prefix, models.TABLE_MENTION)
+	rows, err := r.db.Query(query, postUid, commentUid)
+	if err != nil {
+		return uids
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid uint
+		if err := rows.Scan(&uid); err != nil {
+			return uids
+		}
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+// 언급할 회원 이름 추천하기 (나를 차단한 회원은 제외)
+func (r *TsboardMentionRepository) GetSuggestionNames(input string, actionUserUid uint, bunch uint) []models.UserBasicInfo {
+	items := make([]models.UserBasicInfo, 0)
+	query := fmt.Sprintf(`SELECT uid, name, profile FROM %s%s WHERE name LIKE ? AND blocked = 0
+												AND uid NOT IN (SELECT user_uid FROM %s%s WHERE black_uid = ?)
+												ORDER BY signin DESC LIMIT ?`,
+		configs.Env.Prefix, models.TABLE_USER, configs.Env.Prefix, models.TABLE_USER_BLOCK)
+
+	rows, err := r.db.Query(query, utils.EscapeLike(input)+"%", actionUserUid, bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.UserBasicInfo{}
+		if err := rows.Scan(&item.UserUid, &item.Name, &item.Profile); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 언급 기록 추가하기
+func (r *TsboardMentionRepository) InsertMention(param models.MentionParameter, targetUserUid uint) {
+	query := fmt.Sprintf(`INSERT IGNORE INTO %s%s (board_uid, post_uid, comment_uid, from_uid, to_uid, timestamp)
+												VALUES (?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_MENTION)
+	r.db.Exec(query, param.BoardUid, param.PostUid, param.CommentUid, param.UserUid, targetUserUid, time.Now().UnixMilli())
+}
+
+// 수정하면서 빠진 언급 기록 삭제하기
+func (r *TsboardMentionRepository) RemoveMention(postUid uint, commentUid uint, targetUserUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE post_uid = ? AND comment_uid = ? AND to_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_MENTION)
+	r.db.Exec(query, postUid, commentUid, targetUserUid)
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ModerationRepository interface {
+	GetModerationList(param models.ModerationListParameter) []models.ModerationRecord
+	GetModerationOption(boardUid uint) models.BoardModerationOption
+	GetModerationRecord(moderationUid uint) (models.ModerationRecord, error)
+	GetPublishStatus(postUid uint) models.Status
+	GetPublishedCount(boardUid uint, userUid uint) uint
+	GetSummary(record models.ModerationRecord) (string, string)
+	HoldComment(boardUid uint, postUid uint, commentUid uint, userUid uint)
+	InsertModeration(boardUid uint, postUid uint, commentUid uint, userUid uint)
+	IsWaiting(postUid uint, commentUid uint) bool
+	PublishComment(commentUid uint)
+	PublishPost(postUid uint)
+	UpdateDecision(moderationUid uint, decision models.ModerationDecision, reason string, moderatorUid uint)
+	UpdateModerationOption(boardUid uint, opt models.BoardModerationOption) error
+}
+
+type TsboardModerationRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardModerationRepository(db *sql.DB) *TsboardModerationRepository {
+	return &TsboardModerationRepository{db: db}
+}
+
+const MODERATION_COLUMNS = "uid, board_uid, post_uid, comment_uid, user_uid, decision, reason, moderator_uid, submitted, decided"
+
+// 승인 대기중인 항목들 가져오기
+func (r *TsboardModerationRepository) GetModerationList(param models.ModerationListParameter) []models.ModerationRecord {
+	items := make([]models.ModerationRecord, 0)
+	last := 1 + param.MaxUid - (param.Page-1)*param.Bunch
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE decision = ? AND uid < ? ORDER BY uid DESC LIMIT ?",
+		MODERATION_COLUMNS, configs.Env.Prefix, models.TABLE_MODERATION)
+	rows, err := r.db.Query(query, models.MODERATION_WAITING, last, param.Bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ModerationRecord{}
+		err := rows.Scan(&item.Uid, &item.BoardUid, &item.PostUid, &item.CommentUid, &item.UserUid,
+			&item.Decision, &item.Reason, &item.ModeratorUid, &item.Submitted, &item.Decided)
+		if err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 게시판별 승인 대기 옵션 가져오기 (따로 지정하지 않았다면 사용 안함)
+func (r *TsboardModerationRepository) GetModerationOption(boardUid uint) models.BoardModerationOption {
+	opt := models.BoardModerationOption{Mode: models.MODERATION_OFF}
+	query := fmt.Sprintf("SELECT mode, threshold FROM %s%s WHERE board_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_BOARD_MODERATION)
+	r.db.QueryRow(query, boardUid).Scan(&opt.Mode, &opt.Threshold)
+	return opt
+}
+
+// 승인 대기 레코드 가져오기
+func (r *TsboardModerationRepository) GetModerationRecord(moderationUid uint) (models.ModerationRecord, error) {
+	item := models.ModerationRecord{}
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE uid = ? LIMIT 1",
+		MODERATION_COLUMNS, configs.Env.Prefix, models.TABLE_MODERATION)
+	err := r.db.QueryRow(query, moderationUid).Scan(&item.Uid, &item.BoardUid, &item.PostUid, &item.CommentUid, &item.UserUid,
+		&item.Decision, &item.Reason, &item.ModeratorUid, &item.Submitted, &item.Decided)
+	return item, err
+}
+
+// 승인 대기중인 게시글이 공개될 때의 상태 가져오기 (발행 예약 시각이 남았거나 게시 종료된 글이면 대기 상태)
+func (r *TsboardModerationRepository) GetPublishStatus(postUid uint) models.Status {
+	status := models.CONTENT_PENDING
+	query := fmt.Sprintf("SELECT IF(publish_at > ? OR expired = 1, ?, reserved_status) FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.QueryRow(query, time.Now().UnixMilli(), models.CONTENT_PENDING, postUid).Scan(&status)
+	return status
+}
+
+// 게시판에 공개된 회원의 게시글, 댓글 개수 가져오기
+func (r *TsboardModerationRepository) GetPublishedCount(boardUid uint, userUid uint) uint {
+	var posts, comments uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE board_uid = ? AND user_uid = ? AND status IN (?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.QueryRow(query, boardUid, userUid, models.CONTENT_NORMAL, models.CONTENT_NOTICE, models.CONTENT_SECRET).Scan(&posts)
+
+	query = fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE board_uid = ? AND user_uid = ? AND status = ?",
+		configs.Env.Prefix, models.TABLE_COMMENT)
+	r.db.QueryRow(query, boardUid, userUid, models.CONTENT_NORMAL).Scan(&comments)
+	return posts + comments
+}
+
+// 승인 대기 항목의 제목(게시글)과 내용 가져오기
+func (r *TsboardModerationRepository) GetSummary(record models.ModerationRecord) (string, string) {
+	var title, content string
+	if record.CommentUid > 0 {
+		query := fmt.Sprintf("SELECT content FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+		r.db.QueryRow(query, record.CommentUid).Scan(&content)
+		return title, content
+	}
+	query := fmt.Sprintf("SELECT title, content FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
+	r.db.QueryRow(query, record.PostUid).Scan(&title, &content)
+	return title, content
+}
+
+// 댓글을 승인 대기 상태로 바꾸고 대기열에 추가하기
+func (r *TsboardModerationRepository) HoldComment(boardUid uint, postUid uint, commentUid uint, userUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+	r.db.Exec(query, models.CONTENT_PENDING, commentUid)
+	r.InsertModeration(boardUid, postUid, commentUid, userUid)
+}
+
+// 승인 대기 상태로 저장된 게시글 혹은 댓글을 대기열에 추가하기 (게시글은 commentUid 가 0, 이미 검토했던 항목이면 다시 대기 상태로)
+func (r *TsboardModerationRepository) InsertModeration(boardUid uint, postUid uint, commentUid uint, userUid uint) {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, post_uid, comment_uid, user_uid, decision, reason, moderator_uid, submitted, decided)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+												ON DUPLICATE KEY UPDATE decision = VALUES(decision), reason = '', moderator_uid = 0,
+												submitted = VALUES(submitted), decided = 0`, configs.Env.Prefix, models.TABLE_MODERATION)
+	r.db.Exec(query, boardUid, postUid, commentUid, userUid, models.MODERATION_WAITING, "", 0, time.Now().UnixMilli(), 0)
+}
+
+// 승인 대기중인 게시글 혹은 댓글인지 확인하기 (게시글은 commentUid 가 0)
+func (r *TsboardModerationRepository) IsWaiting(postUid uint, commentUid uint) bool {
+	var uid uint
+	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE post_uid = ? AND comment_uid = ? AND decision = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_MODERATION)
+	r.db.QueryRow(query, postUid, commentUid, models.MODERATION_WAITING).Scan(&uid)
+	return uid > 0
+}
+
+// 승인 대기중인 댓글 공개하기
+func (r *TsboardModerationRepository) PublishComment(commentUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? AND status = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_COMMENT)
+	r.db.Exec(query, models.CONTENT_NORMAL, commentUid, models.CONTENT_PENDING)
+}
+
+// 승인 대기중인 게시글을 원래 상태로 공개하기 (발행 예약 시각이 남았거나 게시 종료된 글이면 숨김 유지)
+func (r *TsboardModerationRepository) PublishPost(postUid uint) {
+	query := fmt.Sprintf(`UPDATE %s%s SET status = reserved_status
+												WHERE uid = ? AND status = ? AND expired = 0 AND (publish_at = 0 OR publish_at <= ?) LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_POST)
+	r.db.Exec(query, postUid, models.CONTENT_PENDING, time.Now().UnixMilli())
+}
+
+// 승인 대기 항목의 검토 결과 저장하기
+func (r *TsboardModerationRepository) UpdateDecision(moderationUid uint, decision models.ModerationDecision, reason string, moderatorUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET decision = ?, reason = ?, moderator_uid = ?, decided = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_MODERATION)
+	r.db.Exec(query, decision, reason, moderatorUid, time.Now().UnixMilli(), moderationUid)
+}
+
+// 게시판별 승인 대기 옵션 저장하기
+func (r *TsboardModerationRepository) UpdateModerationOption(boardUid uint, opt models.BoardModerationOption) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, mode, threshold) VALUES (?, ?, ?)
+												ON DUPLICATE KEY UPDATE mode = VALUES(mode), threshold = VALUES(threshold)`,
+		configs.Env.Prefix, models.TABLE_BOARD_MODERATION)
+	_, err := r.db.Exec(query, boardUid, opt.Mode, opt.Threshold)
+	return err
+}
@@ -12,6 +12,7 @@ import (
 type NotiRepository interface {
 	FindBoardIdTypeByUid(boardUid uint) (string, models.Board)
 	FindBoardUidByPostUid(postUid uint) uint
+	FindModerationReason(postUid uint, commentUid uint) string
 	FindNotificationByUserUid(userUid uint, limit uint) ([]models.NotificationItem, error)
 	FindUserNameProfileByUid(userUid uint) (string, string)
 	InsertNotification(param models.InsertNotificationParameter)
@@ -46,9 +47,18 @@ func (r *TsboardNotiRepository) FindBoardUidByPostUid(postUid uint) uint {
 	return boardUid
 }
 
+// 반려된 게시글 혹은 댓글의 반려 사유 가져오기
+func (r *TsboardNotiRepository) FindModerationReason(postUid uint, commentUid uint) string {
+	var reason string
+	query := fmt.Sprintf("SELECT reason FROM %s%s WHERE post_uid = ? AND comment_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_MODERATION)
+	r.db.QueryRow(query, postUid, commentUid).Scan(&reason)
+	return reason
+}
+
 // 나에게 온 알림들 가져오기
 func (r *TsboardNotiRepository) FindNotificationByUserUid(userUid uint, limit uint) ([]models.NotificationItem, error) {
-	query := fmt.Sprintf(`SELECT uid, from_uid, type, post_uid, checked, timestamp 
+	query := fmt.Sprintf(`SELECT uid, from_uid, type, post_uid, comment_uid, checked, timestamp 
 												FROM %s%s WHERE to_uid = ? ORDER BY uid DESC LIMIT ?`,
 		configs.Env.Prefix, models.TABLE_NOTI)
 
@@ -61,12 +71,16 @@ func (r *TsboardNotiRepository) FindNotificationByUserUid(userUid uint, limit ui
 	items := make([]models.NotificationItem, 0)
 	for rows.Next() {
 		item := models.NotificationItem{}
+		var commentUid uint
 		var checked uint8
-		err = rows.Scan(&item.Uid, &item.FromUser.UserUid, &item.Type, &item.PostUid, &checked, &item.Timestamp)
+		err = rows.Scan(&item.Uid, &item.FromUser.UserUid, &item.Type, &item.PostUid, &commentUid, &checked, &item.Timestamp)
 		if err != nil {
 			return nil, err
 		}
 		item.Checked = checked > 0
+		if item.Type == models.NOTI_MODERATION_REJECTED {
+			item.Reason = r.FindModerationReason(item.PostUid, commentUid)
+		}
 
 		boardUid := r.FindBoardUidByPostUid(item.PostUid)
 		if boardUid > 0 {
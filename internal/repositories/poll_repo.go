@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type PollRepository interface {
+	FindClosedPolls(now int64) []models.PollClosedItem
+	GetPoll(postUid uint) (models.PollResult, error)
+	GetPollOptions(pollUid uint, withVoters bool) []models.PollOption
+	GetVotedOptions(pollUid uint, userUid uint) []uint
+	GetVoterCount(pollUid uint) uint
+	InsertPoll(boardUid uint, postUid uint, userUid uint, param models.PollWriteParameter) uint
+	InsertVote(pollUid uint, userUid uint, optionUids []uint) error
+	UpdateNotified(pollUid uint)
+}
+
+type TsboardPollRepository struct {
+	db    *sql.DB
+	board BoardRepository
+}
+
+// sql.DB, board 포인터 주입받기
+func NewTsboardPollRepository(db *sql.DB, board BoardRepository) *TsboardPollRepository {
+	return &TsboardPollRepository{db: db, board: board}
+}
+
+// 마감 시각이 지났지만 아직 작성자에게 알리지 않은 투표들 가져오기
+func (r *TsboardPollRepository) FindClosedPolls(now int64) []models.PollClosedItem {
+	items := make([]models.PollClosedItem, 0)
+	query := fmt.Sprintf("SELECT uid, post_uid, user_uid FROM %s%s WHERE notified = 0 AND close_at > 0 AND close_at <= ?",
+		configs.Env.Prefix, models.TABLE_POLL)
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.PollClosedItem{}
+		if err := rows.Scan(&item.Uid, &item.PostUid, &item.UserUid); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 게시글에 등록된 투표 정보 가져오기
+func (r *TsboardPollRepository) GetPoll(postUid uint) (models.PollResult, error) {
+	poll := models.PollResult{}
+	query := fmt.Sprintf(`SELECT uid, board_uid, post_uid, user_uid, question, multiple, anonymous, open_at, close_at
+												FROM %s%s WHERE post_uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_POLL)
+	err := r.db.QueryRow(query, postUid).Scan(&poll.Uid, &poll.BoardUid, &poll.PostUid, &poll.UserUid, &poll.Question,
+		&poll.Multiple, &poll.Anonymous, &poll.OpenAt, &poll.CloseAt)
+	return poll, err
+}
+
+// 투표 선택지들과 득표수 가져오기 (withVoters 가 참이면 투표자 목록도 포함)
+func (r *TsboardPollRepository) GetPollOptions(pollUid uint, withVoters bool) []models.PollOption {
+	options := make([]models.PollOption, 0)
+	query := fmt.Sprintf(`SELECT o.uid, o.content, COUNT(v.uid) FROM %s%s AS o
+												LEFT JOIN %s%s AS v ON o.uid = v.option_uid
+												WHERE o.poll_uid = ? GROUP BY o.uid ORDER BY o.uid ASC`,
+		configs.Env.Prefix, models.TABLE_POLL_OPTION, configs.Env.Prefix, models.TABLE_POLL_VOTE)
+	rows, err := r.db.Query(query, pollUid)
+	if err != nil {
+		return options
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		option := models.PollOption{Voters: make([]models.UserBasicInfo, 0)}
+		if err := rows.Scan(&option.Uid, &option.Content, &option.Count); err != nil {
+			return options
+		}
+		options = append(options, option)
+	}
+
+	if withVoters {
+		for i := range options {
+			options[i].Voters = r.getOptionVoters(options[i].Uid)
+		}
+	}
+	return options
+}
+
+// 내가 투표한 선택지 번호들 가져오기
+func (r *TsboardPollRepository) GetVotedOptions(pollUid uint, userUid uint) []uint {
+	uids := make([]uint, 0)
+	query := fmt.Sprintf("SELECT option_uid FROM %s%s WHERE poll_uid = ? AND user_uid = ?",
+		configs.Env.Prefix, models.TABLE_POLL_VOTE)
+	rows, err := r.db.Query(query, pollUid, userUid)
+	if err != nil {
+		return uids
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid uint
+		if err := rows.Scan(&uid); err != nil {
+			return uids
+		}
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+// 투표에 참여한 회원 수 가져오기
+func (r *TsboardPollRepository) GetVoterCount(pollUid uint) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE poll_uid = ?", configs.Env.Prefix, models.TABLE_POLL_VOTER)
+	r.db.QueryRow(query, pollUid).Scan(&count)
+	return count
+}
+
+// 게시글에 새 투표와 선택지들 추가하기
+func (r *TsboardPollRepository) InsertPoll(boardUid uint, postUid uint, userUid uint, param models.PollWriteParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(board_uid, post_uid, user_uid, question, multiple, anonymous, open_at, close_at, notified, created)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_POLL)
+	result, err := r.db.Exec(query, boardUid, postUid, userUid, param.Question, param.Multiple, param.Anonymous,
+		param.OpenAt, param.CloseAt, 0, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s%s (poll_uid, content) VALUES ", configs.Env.Prefix, models.TABLE_POLL_OPTION)
+	values := make([]interface{}, 0)
+	for _, option := range param.Options {
+		query += "(?, ?),"
+		values = append(values, insertId, option)
+	}
+	query = query[:len(query)-1]
+	r.db.Exec(query, values...)
+	return uint(insertId)
+}
+
+// 투표하기 (투표자 테이블의 고유 키로 회원당 한 번만 참여하도록 보장, 투표자와 선택 내역은 함께 저장)
+func (r *TsboardPollRepository) InsertVote(pollUid uint, userUid uint, optionUids []uint) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("INSERT INTO %s%s (poll_uid, user_uid, timestamp) VALUES (?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_POLL_VOTER)
+	if _, err := tx.Exec(query, pollUid, userUid, time.Now().UnixMilli()); err != nil {
+		return fmt.Errorf("you have already voted")
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s%s (poll_uid, option_uid, user_uid) VALUES ", configs.Env.Prefix, models.TABLE_POLL_VOTE)
+	values := make([]interface{}, 0)
+	for _, optionUid := range optionUids {
+		query += "(?, ?, ?),"
+		values = append(values, pollUid, optionUid, userUid)
+	}
+	query = query[:len(query)-1]
+	if _, err := tx.Exec(query, values...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 투표 마감 알림을 보냈다고 표시하기
+func (r *TsboardPollRepository) UpdateNotified(pollUid uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET notified = 1 WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POLL)
+	r.db.Exec(query, pollUid)
+}
+
+// 선택지에 투표한 회원들 가져오기
+func (r *TsboardPollRepository) getOptionVoters(optionUid uint) []models.UserBasicInfo {
+	voters := make([]models.UserBasicInfo, 0)
+	query := fmt.Sprintf("SELECT user_uid FROM %s%s WHERE option_uid = ? ORDER BY uid ASC",
+		configs.Env.Prefix, models.TABLE_POLL_VOTE)
+	rows, err := r.db.Query(query, optionUid)
+	if err != nil {
+		return voters
+	}
+	defer rows.Close()
+
+	userUids := make([]uint, 0)
+	for rows.Next() {
+		var userUid uint
+		if err := rows.Scan(&userUid); err != nil {
+			break
+		}
+		userUids = append(userUids, userUid)
+	}
+	rows.Close()
+
+	for _, userUid := range userUids {
+		voters = append(voters, r.board.GetWriterInfo(userUid).UserBasicInfo)
+	}
+	return voters
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ReactionRepository interface {
+	FindTarget(table models.Table, targetUid uint) (uint, uint, models.Status)
+	GetReactionUserCount(param models.ReactionUserParameter) uint
+	GetReactionUsers(param models.ReactionUserParameter) []models.ReactionUserItem
+	GetUserReactions(table models.Table, targetUid uint, userUid uint) []string
+	InsertReaction(param models.ReactionParameter) error
+	RemoveReaction(param models.ReactionParameter)
+	RemoveUserReactions(table models.Table, targetUid uint, userUid uint)
+}
+
+type TsboardReactionRepository struct {
+	db    *sql.DB
+	board BoardRepository
+}
+
+// sql.DB, board 포인터 주입받기
+func NewTsboardReactionRepository(db *sql.DB, board BoardRepository) *TsboardReactionRepository {
+	return &TsboardReactionRepository{db: db, board: board}
+}
+
+// 반응 대상 게시글 혹은 댓글의 게시판 번호, 게시글 번호, 상태 가져오기
+func (r *TsboardReactionRepository) FindTarget(table models.Table, targetUid uint) (uint, uint, models.Status) {
+	var boardUid, postUid uint
+	var status int8
+	query := fmt.Sprintf("SELECT board_uid, uid, status FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	if table == models.TABLE_COMMENT_REACTION {
+		query = fmt.Sprintf("SELECT board_uid, post_uid, status FROM %s%s WHERE uid = ? LIMIT 1",
+			configs.Env.Prefix, models.TABLE_COMMENT)
+	}
+	r.db.QueryRow(query, targetUid).Scan(&boardUid, &postUid, &status)
+	return boardUid, postUid, models.Status(status)
+}
+
+// 반응을 남긴 회원 수 가져오기 (이모지를 지정하지 않으면 전체)
+func (r *TsboardReactionRepository) GetReactionUserCount(param models.ReactionUserParameter) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE target_uid = ?", configs.Env.Prefix, param.Table)
+	values := []interface{}{param.TargetUid}
+	if len(param.Emoji) > 0 {
+		query += " AND emoji = ?"
+		values = append(values, param.Emoji)
+	}
+	r.db.QueryRow(query, values...).Scan(&count)
+	return count
+}
+
+// 반응을 남긴 회원 목록 가져오기 (이모지를 지정하지 않으면 전체, 최근 순서)
+func (r *TsboardReactionRepository) GetReactionUsers(param models.ReactionUserParameter) []models.ReactionUserItem {
+	items := make([]models.ReactionUserItem, 0)
+	query := fmt.Sprintf("SELECT uid, user_uid, emoji, timestamp FROM %s%s WHERE target_uid = ?",
+		configs.Env.Prefix, param.Table)
+	values := []interface{}{param.TargetUid}
+	if len(param.Emoji) > 0 {
+		query += " AND emoji = ?"
+		values = append(values, param.Emoji)
+	}
+	query += " ORDER BY uid DESC LIMIT ?, ?"
+	values = append(values, (param.Page-1)*param.Bunch, param.Bunch)
+
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ReactionUserItem{}
+		if err := rows.Scan(&item.Uid, &item.User.UserUid, &item.Emoji, &item.Timestamp); err != nil {
+			break
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+
+	for i := range items {
+		items[i].User = r.board.GetWriterInfo(items[i].User.UserUid).UserBasicInfo
+	}
+	return items
+}
+
+// 회원이 게시글 혹은 댓글에 남긴 이모지들 가져오기
+func (r *TsboardReactionRepository) GetUserReactions(table models.Table, targetUid uint, userUid uint) []string {
+	emojis := make([]string, 0)
+	query := fmt.Sprintf("SELECT emoji FROM %s%s WHERE target_uid = ? AND user_uid = ?", configs.Env.Prefix, table)
+	rows, err := r.db.Query(query, targetUid, userUid)
+	if err != nil {
+		return emojis
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var emoji string
+		if err := rows.Scan(&emoji); err != nil {
+			return emojis
+		}
+		emojis = append(emojis, emoji)
+	}
+	return emojis
+}
+
+// 게시글 혹은 댓글에 반응 남기기 (같은 이모지는 고유 키로 한 번만 저장)
+func (r *TsboardReactionRepository) InsertReaction(param models.ReactionParameter) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, target_uid, user_uid, emoji, timestamp)
+												VALUES (?, ?, ?, ?, ?)`, configs.Env.Prefix, param.Table)
+	_, err := r.db.Exec(query, param.BoardUid, param.TargetUid, param.UserUid, param.Emoji, time.Now().UnixMilli())
+	return err
+}
+
+// 게시글 혹은 댓글에 남긴 반응 취소하기
+func (r *TsboardReactionRepository) RemoveReaction(param models.ReactionParameter) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE target_uid = ? AND user_uid = ? AND emoji = ? LIMIT 1",
+		configs.Env.Prefix, param.Table)
+	r.db.Exec(query, param.TargetUid, param.UserUid, param.Emoji)
+}
+
+// 회원이 게시글 혹은 댓글에 남긴 반응들 모두 취소하기
+func (r *TsboardReactionRepository) RemoveUserReactions(table models.Table, targetUid uint, userUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE target_uid = ? AND user_uid = ?", configs.Env.Prefix, table)
+	r.db.Exec(query, targetUid, userUid)
+}
@@ -4,35 +4,69 @@ import "database/sql"
 
 // 모든 리포지토리들을 관리
 type Repository struct {
-	Admin     AdminRepository
-	Auth      AuthRepository
-	Board     BoardRepository
-	BoardEdit BoardEditRepository
-	BoardView BoardViewRepository
-	Chat      ChatRepository
-	Comment   CommentRepository
-	Home      HomeRepository
-	Noti      NotiRepository
-	Sync      SyncRepository
-	Trade     TradeRepository
-	User      UserRepository
+	Admin      AdminRepository
+	Auth       AuthRepository
+	Board      BoardRepository
+	BoardEdit  BoardEditRepository
+	BoardView  BoardViewRepository
+	Bookmark   BookmarkRepository
+	Chat       ChatRepository
+	Comment    CommentRepository
+	Draft      DraftRepository
+	Export     ExportRepository
+	Field      FieldRepository
+	Hashtag    HashtagRepository
+	Home       HomeRepository
+	Import     ImportRepository
+	Job        JobRepository
+	Mention    MentionRepository
+	Moderation ModerationRepository
+	Noti       NotiRepository
+	Poll       PollRepository
+	Reaction   ReactionRepository
+	Revision   RevisionRepository
+	Series     SeriesRepository
+	Spam       SpamRepository
+	Sync       SyncRepository
+	Trade      TradeRepository
+	Trash      TrashRepository
+	Unfurl     UnfurlRepository
+	User       UserRepository
+	View       ViewRepository
 }
 
 // 모든 리포지토리를 생성
 func NewRepository(db *sql.DB) *Repository {
 	board := NewTsboardBoardRepository(db)
 	return &Repository{
-		Admin:     NewTsboardAdminRepository(db),
-		Auth:      NewTsboardAuthRepository(db),
-		Board:     board,
-		BoardEdit: NewTsboardBoardEditRepository(db, board),
-		BoardView: NewTsboardBoardViewRepository(db, board),
-		Chat:      NewTsboardChatRepository(db),
-		Comment:   NewTsboardCommentRepository(db, board),
-		Home:      NewTsboardHomeRepository(db, board),
-		Noti:      NewTsboardNotiRepository(db),
-		Sync:      NewTsboardSyncRepository(db),
-		Trade:     NewTsboardTradeRepository(db),
-		User:      NewTsboardUserRepository(db),
+		Admin:      NewTsboardAdminRepository(db),
+		Auth:       NewTsboardAuthRepository(db),
+		Board:      board,
+		BoardEdit:  NewTsboardBoardEditRepository(db, board),
+		BoardView:  NewTsboardBoardViewRepository(db, board),
+		Bookmark:   NewTsboardBookmarkRepository(db),
+		Chat:       NewTsboardChatRepository(db),
+		Comment:    NewTsboardCommentRepository(db, board),
+		Draft:      NewTsboardDraftRepository(db),
+		Export:     NewTsboardExportRepository(db),
+		Field:      NewTsboardFieldRepository(db),
+		Hashtag:    NewTsboardHashtagRepository(db),
+		Home:       NewTsboardHomeRepository(db, board),
+		Import:     NewTsboardImportRepository(db),
+		Job:        NewTsboardJobRepository(db),
+		Mention:    NewTsboardMentionRepository(db),
+		Moderation: NewTsboardModerationRepository(db),
+		Noti:       NewTsboardNotiRepository(db),
+		Poll:       NewTsboardPollRepository(db, board),
+		Reaction:   NewTsboardReactionRepository(db, board),
+		Revision:   NewTsboardRevisionRepository(db, board),
+		Series:     NewTsboardSeriesRepository(db, board),
+		Spam:       NewTsboardSpamRepository(db),
+		Sync:       NewTsboardSyncRepository(db),
+		Trade:      NewTsboardTradeRepository(db),
+		Trash:      NewTsboardTrashRepository(db),
+		Unfurl:     NewTsboardUnfurlRepository(db),
+		User:       NewTsboardUserRepository(db),
+		View:       NewTsboardViewRepository(db),
 	}
 }
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type RevisionRepository interface {
+	GetRevision(postUid uint, revisionUid uint) (models.PostRevision, error)
+	GetRevisionCount(postUid uint) uint
+	GetRevisionList(postUid uint) []models.RevisionListItem
+	InsertRevision(param models.RevisionInsertParameter) uint
+	RemoveRevisions(postUid uint)
+}
+
+type TsboardRevisionRepository struct {
+	db    *sql.DB
+	board BoardRepository
+}
+
+// sql.DB, board 포인터 주입받기
+func NewTsboardRevisionRepository(db *sql.DB, board BoardRepository) *TsboardRevisionRepository {
+	return &TsboardRevisionRepository{db: db, board: board}
+}
+
+// 게시글의 특정 수정 이력 가져오기
+func (r *TsboardRevisionRepository) GetRevision(postUid uint, revisionUid uint) (models.PostRevision, error) {
+	item := models.PostRevision{}
+	var editorUid uint
+	var format models.ContentFormat
+	query := fmt.Sprintf(`SELECT uid, post_uid, editor_uid, title, content, format, tags, created, restored_from
+												FROM %s%s WHERE uid = ? AND post_uid = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_POST_REVISION)
+
+	err := r.db.QueryRow(query, revisionUid, postUid).Scan(&item.Uid, &item.PostUid, &editorUid, &item.Title,
+		&item.Content, &format, &item.Tags, &item.Created, &item.RestoredFrom)
+	if err != nil {
+		return item, err
+	}
+	item.Editor = r.board.GetWriterInfo(editorUid)
+	item.Format = format.String()
+	return item, nil
+}
+
+// 게시글에 쌓인 수정 이력 개수 가져오기
+func (r *TsboardRevisionRepository) GetRevisionCount(postUid uint) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE post_uid = ?", configs.Env.Prefix, models.TABLE_POST_REVISION)
+	r.db.QueryRow(query, postUid).Scan(&count)
+	return count
+}
+
+// 게시글의 수정 이력 목록 가져오기 (최신 이력부터)
+func (r *TsboardRevisionRepository) GetRevisionList(postUid uint) []models.RevisionListItem {
+	items := make([]models.RevisionListItem, 0)
+	query := fmt.Sprintf(`SELECT uid, editor_uid, title, created, restored_from FROM %s%s
+												WHERE post_uid = ? ORDER BY uid DESC`, configs.Env.Prefix, models.TABLE_POST_REVISION)
+	rows, err := r.db.Query(query, postUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.RevisionListItem{}
+		var editorUid uint
+		if err := rows.Scan(&item.Uid, &editorUid, &item.Title, &item.Created, &item.RestoredFrom); err != nil {
+			return items
+		}
+		item.Editor = r.board.GetWriterInfo(editorUid)
+		items = append(items, item)
+	}
+	return items
+}
+
+// 새 수정 이력 추가하기
+func (r *TsboardRevisionRepository) InsertRevision(param models.RevisionInsertParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(post_uid, board_uid, editor_uid, title, content, format, tags, created, restored_from)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_POST_REVISION)
+	result, err := r.db.Exec(query, param.PostUid, param.BoardUid, param.EditorUid, param.Title,
+		param.Content, param.Format, param.Tags, param.Created, param.RestoredFrom)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 게시글의 수정 이력들 모두 삭제하기
+func (r *TsboardRevisionRepository) RemoveRevisions(postUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE post_uid = ?", configs.Env.Prefix, models.TABLE_POST_REVISION)
+	r.db.Exec(query, postUid)
+}
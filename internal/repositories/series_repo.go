@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type SeriesRepository interface {
+	GetSeries(seriesUid uint) (models.SeriesItem, error)
+	GetSeriesList(boardUid uint) []models.SeriesItem
+	GetSeriesName(postUid uint) string
+	GetSeriesNavigation(postUid uint) models.SeriesNavigation
+	GetSeriesPosts(seriesUid uint) []models.SeriesPostItem
+	InsertSeries(param models.SeriesParameter) uint
+	RemoveSeries(seriesUid uint)
+	RemoveSeriesPost(postUid uint)
+	UpdateSeries(param models.SeriesParameter)
+	UpdateSeriesPosts(seriesUid uint, postUids []uint) error
+}
+
+type TsboardSeriesRepository struct {
+	db    *sql.DB
+	board BoardRepository
+}
+
+// sql.DB, board 포인터 주입받기
+func NewTsboardSeriesRepository(db *sql.DB, board BoardRepository) *TsboardSeriesRepository {
+	return &TsboardSeriesRepository{db: db, board: board}
+}
+
+// 시리즈 정보 가져오기
+func (r *TsboardSeriesRepository) GetSeries(seriesUid uint) (models.SeriesItem, error) {
+	item := models.SeriesItem{}
+	query := fmt.Sprintf("SELECT uid, board_uid, user_uid, name, info, created, modified FROM %s%s WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_SERIES)
+	err := r.db.QueryRow(query, seriesUid).Scan(&item.Uid, &item.BoardUid, &item.Writer.UserUid, &item.Name,
+		&item.Info, &item.Created, &item.Modified)
+	if err != nil {
+		return item, err
+	}
+	item.Writer = r.board.GetWriterInfo(item.Writer.UserUid).UserBasicInfo
+	item.Count = r.getVisiblePostCount(item.Uid)
+	return item, nil
+}
+
+// 게시판에 등록된 시리즈 목록 가져오기 (최근에 수정된 순서)
+func (r *TsboardSeriesRepository) GetSeriesList(boardUid uint) []models.SeriesItem {
+	items := make([]models.SeriesItem, 0)
+	query := fmt.Sprintf(`SELECT uid, board_uid, user_uid, name, info, created, modified
+												FROM %s%s WHERE board_uid = ? ORDER BY modified DESC, uid DESC`,
+		configs.Env.Prefix, models.TABLE_SERIES)
+	rows, err := r.db.Query(query, boardUid)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.SeriesItem{}
+		if err := rows.Scan(&item.Uid, &item.BoardUid, &item.Writer.UserUid, &item.Name,
+			&item.Info, &item.Created, &item.Modified); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+
+	for i := range items {
+		items[i].Writer = r.board.GetWriterInfo(items[i].Writer.UserUid).UserBasicInfo
+		items[i].Count = r.getVisiblePostCount(items[i].Uid)
+	}
+	return items
+}
+
+// 게시글이 속한 시리즈 이름 가져오기
+func (r *TsboardSeriesRepository) GetSeriesName(postUid uint) string {
+	var name string
+	query := fmt.Sprintf(`SELECT s.name FROM %s%s AS sp JOIN %s%s AS s ON sp.series_uid = s.uid
+												WHERE sp.post_uid = ? LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_SERIES_POST, configs.Env.Prefix, models.TABLE_SERIES)
+	r.db.QueryRow(query, postUid).Scan(&name)
+	return name
+}
+
+// 게시글이 속한 시리즈와 그 안에서의 위치, 이전/다음 글 번호 가져오기
+func (r *TsboardSeriesRepository) GetSeriesNavigation(postUid uint) models.SeriesNavigation {
+	nav := models.SeriesNavigation{}
+	var position uint
+	query := fmt.Sprintf(`SELECT s.uid, s.name, sp.position FROM %s%s AS sp JOIN %s%s AS s ON sp.series_uid = s.uid
+												WHERE sp.post_uid = ? LIMIT 1`,
+		configs.Env.Prefix, models.TABLE_SERIES_POST, configs.Env.Prefix, models.TABLE_SERIES)
+	if err := r.db.QueryRow(query, postUid).Scan(&nav.Uid, &nav.Name, &position); err != nil {
+		return nav
+	}
+
+	from := r.makeVisibleQuery()
+	query = fmt.Sprintf("SELECT COUNT(*), IFNULL(SUM(sp.position <= ?), 0) %s", from)
+	r.db.QueryRow(query, position, nav.Uid, models.CONTENT_REMOVED, models.CONTENT_PENDING).Scan(&nav.Total, &nav.Position)
+
+	query = fmt.Sprintf("SELECT sp.post_uid %s AND sp.position < ? ORDER BY sp.position DESC LIMIT 1", from)
+	r.db.QueryRow(query, nav.Uid, models.CONTENT_REMOVED, models.CONTENT_PENDING, position).Scan(&nav.PrevPostUid)
+
+	query = fmt.Sprintf("SELECT sp.post_uid %s AND sp.position > ? ORDER BY sp.position ASC LIMIT 1", from)
+	r.db.QueryRow(query, nav.Uid, models.CONTENT_REMOVED, models.CONTENT_PENDING, position).Scan(&nav.NextPostUid)
+	return nav
+}
+
+// 시리즈에 속한 게시글들 순서대로 가져오기
+func (r *TsboardSeriesRepository) GetSeriesPosts(seriesUid uint) []models.SeriesPostItem {
+	items := make([]models.SeriesPostItem, 0)
+	query := fmt.Sprintf("SELECT sp.post_uid, sp.position, p.title, p.submitted %s ORDER BY sp.position ASC",
+		r.makeVisibleQuery())
+	rows, err := r.db.Query(query, seriesUid, models.CONTENT_REMOVED, models.CONTENT_PENDING)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.SeriesPostItem{}
+		if err := rows.Scan(&item.PostUid, &item.Position, &item.Title, &item.Submitted); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 새 시리즈 추가하기
+func (r *TsboardSeriesRepository) InsertSeries(param models.SeriesParameter) uint {
+	now := time.Now().UnixMilli()
+	query := fmt.Sprintf("INSERT INTO %s%s (board_uid, user_uid, name, info, created, modified) VALUES (?, ?, ?, ?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_SERIES)
+	result, err := r.db.Exec(query, param.BoardUid, param.UserUid, param.Name, param.Info, now, now)
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 시리즈 삭제하기 (게시글들은 그대로 두고 연결만 해제)
+func (r *TsboardSeriesRepository) RemoveSeries(seriesUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE series_uid = ?", configs.Env.Prefix, models.TABLE_SERIES_POST)
+	r.db.Exec(query, seriesUid)
+
+	query = fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_SERIES)
+	r.db.Exec(query, seriesUid)
+}
+
+// 게시글을 시리즈에서 빼기
+func (r *TsboardSeriesRepository) RemoveSeriesPost(postUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE post_uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_SERIES_POST)
+	r.db.Exec(query, postUid)
+}
+
+// 시리즈 이름, 설명 수정하기
+func (r *TsboardSeriesRepository) UpdateSeries(param models.SeriesParameter) {
+	query := fmt.Sprintf("UPDATE %s%s SET name = ?, info = ?, modified = ? WHERE uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_SERIES)
+	r.db.Exec(query, param.Name, param.Info, time.Now().UnixMilli(), param.SeriesUid)
+}
+
+// 시리즈에 속한 게시글들을 주어진 순서대로 다시 지정하기 (다른 시리즈에 있던 글은 옮겨옴)
+func (r *TsboardSeriesRepository) UpdateSeriesPosts(seriesUid uint, postUids []uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE series_uid = ?", configs.Env.Prefix, models.TABLE_SERIES_POST)
+	if _, err := r.db.Exec(query, seriesUid); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("UPDATE %s%s SET modified = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_SERIES)
+	r.db.Exec(query, time.Now().UnixMilli(), seriesUid)
+	if len(postUids) < 1 {
+		return nil
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s%s (series_uid, post_uid, position) VALUES ", configs.Env.Prefix, models.TABLE_SERIES_POST)
+	values := make([]interface{}, 0)
+	for index, postUid := range postUids {
+		query += "(?, ?, ?),"
+		values = append(values, seriesUid, postUid, index+1)
+	}
+	query = query[:len(query)-1] + " ON DUPLICATE KEY UPDATE series_uid = VALUES(series_uid), position = VALUES(position)"
+	_, err := r.db.Exec(query, values...)
+	return err
+}
+
+// 시리즈에 속한 게시글 중 공개된 글 개수 가져오기
+func (r *TsboardSeriesRepository) getVisiblePostCount(seriesUid uint) uint {
+	var count uint
+	query := fmt.Sprintf("SELECT COUNT(*) %s", r.makeVisibleQuery())
+	r.db.QueryRow(query, seriesUid, models.CONTENT_REMOVED, models.CONTENT_PENDING).Scan(&count)
+	return count
+}
+
+// 시리즈 내 삭제되거나 발행 전인 글을 제외하는 공통 쿼리 (series_uid, 제외할 상태 2개 순서로 바인딩)
+func (r *TsboardSeriesRepository) makeVisibleQuery() string {
+	return fmt.Sprintf(`FROM %s%s AS sp JOIN %s%s AS p ON sp.post_uid = p.uid
+											WHERE sp.series_uid = ? AND p.status NOT IN (?, ?)`,
+		configs.Env.Prefix, models.TABLE_SERIES_POST, configs.Env.Prefix, models.TABLE_POST)
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type SpamRepository interface {
+	GetRecentWriteCount(target models.SpamTarget, userUid uint, since int64) uint
+	GetSpamLogList(param models.SpamLogListParameter) []models.SpamLogRecord
+	GetSpamRules() []models.SpamRule
+	HasDuplicate(param models.SpamCheckParameter, since int64) bool
+	InsertSpamLogs(param models.SpamCheckParameter, hits []models.SpamHit, targetUid uint)
+	InsertSpamRule(rule models.SpamRule) uint
+	RemoveSpamRule(ruleUid uint) error
+}
+
+type TsboardSpamRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardSpamRepository(db *sql.DB) *TsboardSpamRepository {
+	return &TsboardSpamRepository{db: db}
+}
+
+// 회원이 지정된 시각 이후에 작성한 게시글, 댓글, 쪽지 중 하나의 개수 가져오기 (게시글은 발행 예약 시각이 아닌 실제 작성 시각 기준)
+func (r *TsboardSpamRepository) GetRecentWriteCount(target models.SpamTarget, userUid uint, since int64) uint {
+	var count uint
+	var query string
+	switch target {
+	case models.SPAM_TARGET_CHAT:
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE from_uid = ? AND timestamp >= ?", configs.Env.Prefix, models.TABLE_CHAT)
+	case models.SPAM_TARGET_COMMENT:
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE user_uid = ? AND submitted >= ?", configs.Env.Prefix, models.TABLE_COMMENT)
+	default:
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s%s WHERE user_uid = ? AND created >= ?", configs.Env.Prefix, models.TABLE_POST)
+	}
+	r.db.QueryRow(query, userUid, since).Scan(&count)
+	return count
+}
+
+// 스팸 검사 기록 가져오기
+func (r *TsboardSpamRepository) GetSpamLogList(param models.SpamLogListParameter) []models.SpamLogRecord {
+	items := make([]models.SpamLogRecord, 0)
+	last := 1 + param.MaxUid - (param.Page-1)*param.Bunch
+	query := fmt.Sprintf(`SELECT uid, target, target_uid, board_uid, user_uid, check_type, rule_uid, action, detail, excerpt, timestamp
+												FROM %s%s WHERE uid < ? ORDER BY uid DESC LIMIT ?`, configs.Env.Prefix, models.TABLE_SPAM_LOG)
+	rows, err := r.db.Query(query, last, param.Bunch)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.SpamLogRecord{}
+		err := rows.Scan(&item.Uid, &item.Target, &item.TargetUid, &item.BoardUid, &item.UserUid,
+			&item.Check, &item.RuleUid, &item.Action, &item.Detail, &item.Excerpt, &item.Timestamp)
+		if err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 등록된 금지어 규칙들 가져오기
+func (r *TsboardSpamRepository) GetSpamRules() []models.SpamRule {
+	items := make([]models.SpamRule, 0)
+	query := fmt.Sprintf("SELECT uid, type, pattern, action, timestamp FROM %s%s ORDER BY uid ASC",
+		configs.Env.Prefix, models.TABLE_SPAM_RULE)
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.SpamRule{}
+		if err := rows.Scan(&item.Uid, &item.Type, &item.Pattern, &item.Action, &item.Timestamp); err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// 회원이 지정된 시각 이후에 같은 내용을 올린 적이 있는지 확인 (수정 중인 글은 제외, 댓글은 같은 게시글 안에서만 확인)
+func (r *TsboardSpamRepository) HasDuplicate(param models.SpamCheckParameter, since int64) bool {
+	var uid uint
+	switch param.Target {
+	case models.SPAM_TARGET_COMMENT:
+		query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE post_uid = ? AND user_uid = ? AND content = ? AND submitted >= ?
+													AND uid != ? AND status != ? LIMIT 1`, configs.Env.Prefix, models.TABLE_COMMENT)
+		r.db.QueryRow(query, param.PostUid, param.UserUid, param.Content, since, param.TargetUid, models.CONTENT_REMOVED).Scan(&uid)
+	default:
+		query := fmt.Sprintf(`SELECT uid FROM %s%s WHERE user_uid = ? AND content = ? AND created >= ?
+													AND uid != ? AND status != ? LIMIT 1`, configs.Env.Prefix, models.TABLE_POST)
+		r.db.QueryRow(query, param.UserUid, param.Content, since, param.TargetUid, models.CONTENT_REMOVED).Scan(&uid)
+	}
+	return uid > 0
+}
+
+// 스팸 검사에 걸린 항목들 기록하기
+func (r *TsboardSpamRepository) InsertSpamLogs(param models.SpamCheckParameter, hits []models.SpamHit, targetUid uint) {
+	if len(hits) < 1 {
+		return
+	}
+	marks := make([]string, 0)
+	args := make([]interface{}, 0)
+	excerpt := utils.MakeSpamExcerpt(param.Title, param.Content)
+	now := time.Now().UnixMilli()
+	for _, hit := range hits {
+		marks = append(marks, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, param.Target, targetUid, param.BoardUid, param.UserUid, hit.Check, hit.RuleUid, hit.Action,
+			utils.CutString(hit.Detail, models.SPAM_MAX_DETAIL), excerpt, now)
+	}
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(target, target_uid, board_uid, user_uid, check_type, rule_uid, action, detail, excerpt, timestamp)
+												VALUES %s`, configs.Env.Prefix, models.TABLE_SPAM_LOG, strings.Join(marks, ", "))
+	r.db.Exec(query, args...)
+}
+
+// 새 금지어 규칙 추가하기
+func (r *TsboardSpamRepository) InsertSpamRule(rule models.SpamRule) uint {
+	query := fmt.Sprintf("INSERT INTO %s%s (type, pattern, action, timestamp) VALUES (?, ?, ?, ?)",
+		configs.Env.Prefix, models.TABLE_SPAM_RULE)
+	result, err := r.db.Exec(query, rule.Type, rule.Pattern, rule.Action, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 금지어 규칙 삭제하기
+func (r *TsboardSpamRepository) RemoveSpamRule(ruleUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_SPAM_RULE)
+	_, err := r.db.Exec(query, ruleUid)
+	return err
+}
@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type TrashRepository interface {
+	FindExpiredTrash(removedBefore int64, limit uint) []models.TrashRecord
+	GetTrash(trashUid uint) (models.TrashRecord, error)
+	GetTrashByTarget(trashType models.TrashType, targetUid uint) (models.TrashRecord, error)
+	GetTrashChildren(parentUid uint) []models.TrashRecord
+	GetTrashList(param models.TrashListParameter) []models.TrashRecord
+	GetTrashSummary(record models.TrashRecord) string
+	InsertTrash(param models.TrashInsertParameter) uint
+	MoveCommentToTrash(commentUid uint, removedBy uint, keepForReplies bool) uint
+	MovePostToTrash(postUid uint, removedBy uint) uint
+	PurgeTarget(record models.TrashRecord)
+	RemoveTrash(trashUid uint)
+	RestoreTarget(record models.TrashRecord)
+	UpdatePrevStatus(trashUid uint, status models.Status)
+}
+
+type TsboardTrashRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardTrashRepository(db *sql.DB) *TsboardTrashRepository {
+	return &TsboardTrashRepository{db: db}
+}
+
+const TRASH_COLUMNS = "uid, parent_uid, type, target_uid, board_uid, post_uid, user_uid, removed_by, prev_status, backup, removed"
+
+// 보관 기간이 지난 휴지통 항목들 가져오기 (게시글과 함께 삭제된 댓글들은 제외)
+func (r *TsboardTrashRepository) FindExpiredTrash(removedBefore int64, limit uint) []models.TrashRecord {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE parent_uid = 0 AND removed < ? ORDER BY uid ASC LIMIT ?",
+		TRASH_COLUMNS, configs.Env.Prefix, models.TABLE_TRASH)
+	rows, err := r.db.Query(query, removedBefore, limit)
+	if err != nil {
+		return make([]models.TrashRecord, 0)
+	}
+	defer rows.Close()
+	return r.makeTrashRecords(rows)
+}
+
+// 휴지통 항목 가져오기
+func (r *TsboardTrashRepository) GetTrash(trashUid uint) (models.TrashRecord, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE uid = ? LIMIT 1", TRASH_COLUMNS, configs.Env.Prefix, models.TABLE_TRASH)
+	return r.scanTrashRecord(r.db.QueryRow(query, trashUid))
+}
+
+// 삭제된 게시글 혹은 댓글 번호로 휴지통 항목 가져오기
+func (r *TsboardTrashRepository) GetTrashByTarget(trashType models.TrashType, targetUid uint) (models.TrashRecord, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE type = ? AND target_uid = ? ORDER BY uid DESC LIMIT 1",
+		TRASH_COLUMNS, configs.Env.Prefix, models.TABLE_TRASH)
+	return r.scanTrashRecord(r.db.QueryRow(query, trashType, targetUid))
+}
+
+// 게시글과 함께 휴지통으로 옮겨진 댓글 항목들 가져오기
+func (r *TsboardTrashRepository) GetTrashChildren(parentUid uint) []models.TrashRecord {
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE parent_uid = ?", TRASH_COLUMNS, configs.Env.Prefix, models.TABLE_TRASH)
+	rows, err := r.db.Query(query, parentUid)
+	if err != nil {
+		return make([]models.TrashRecord, 0)
+	}
+	defer rows.Close()
+	return r.makeTrashRecords(rows)
+}
+
+// (관리화면) 휴지통 목록 가져오기
+func (r *TsboardTrashRepository) GetTrashList(param models.TrashListParameter) []models.TrashRecord {
+	last := 1 + param.MaxUid - (param.Page-1)*param.Bunch
+	query := fmt.Sprintf("SELECT %s FROM %s%s WHERE parent_uid = 0 AND uid < ? ORDER BY uid DESC LIMIT ?",
+		TRASH_COLUMNS, configs.Env.Prefix, models.TABLE_TRASH)
+	rows, err := r.db.Query(query, last, param.Bunch)
+	if err != nil {
+		return make([]models.TrashRecord, 0)
+	}
+	defer rows.Close()
+	return r.makeTrashRecords(rows)
+}
+
+// 휴지통 항목의 제목(게시글) 혹은 내용(댓글) 가져오기
+func (r *TsboardTrashRepository) GetTrashSummary(record models.TrashRecord) string {
+	if len(record.Backup) > 0 {
+		return record.Backup
+	}
+	var summary string
+	query := fmt.Sprintf("SELECT content FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+	if record.Type == models.TRASH_POST {
+		query = fmt.Sprintf("SELECT title FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
+	}
+	r.db.QueryRow(query, record.TargetUid).Scan(&summary)
+	return summary
+}
+
+// 휴지통 항목 추가하기
+func (r *TsboardTrashRepository) InsertTrash(param models.TrashInsertParameter) uint {
+	query := fmt.Sprintf(`INSERT INTO %s%s
+												(parent_uid, type, target_uid, board_uid, post_uid, user_uid, removed_by, prev_status, backup, removed)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_TRASH)
+	result, err := r.db.Exec(query, param.ParentUid, param.Type, param.TargetUid, param.BoardUid, param.PostUid,
+		param.UserUid, param.RemovedBy, param.PrevStatus, param.Backup, time.Now().UnixMilli())
+	if err != nil {
+		return models.FAILED
+	}
+	insertId, err := result.LastInsertId()
+	if err != nil {
+		return models.FAILED
+	}
+	return uint(insertId)
+}
+
+// 댓글을 휴지통으로 옮기기 (답글이 달린 댓글은 내용만 비우고 원래 내용은 휴지통에 보관)
+func (r *TsboardTrashRepository) MoveCommentToTrash(commentUid uint, removedBy uint, keepForReplies bool) uint {
+	param := models.TrashInsertParameter{
+		Type:      models.TRASH_COMMENT,
+		TargetUid: commentUid,
+		RemovedBy: removedBy,
+	}
+	var content string
+	query := fmt.Sprintf("SELECT board_uid, post_uid, user_uid, content, status FROM %s%s WHERE uid = ? AND status != ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_COMMENT)
+	err := r.db.QueryRow(query, commentUid, models.CONTENT_REMOVED).Scan(
+		&param.BoardUid, &param.PostUid, &param.UserUid, &content, &param.PrevStatus)
+	if err != nil || (keepForReplies && len(content) < 1) {
+		return models.FAILED
+	}
+
+	if keepForReplies {
+		param.Backup = content
+		query = fmt.Sprintf("UPDATE %s%s SET content = '' WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+		r.db.Exec(query, commentUid)
+	} else {
+		query = fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+		r.db.Exec(query, models.CONTENT_REMOVED, commentUid)
+	}
+	return r.InsertTrash(param)
+}
+
+// 게시글과 달린 댓글들을 휴지통으로 옮기기 (첨부파일, 태그 등은 완전히 삭제될 때까지 유지)
+func (r *TsboardTrashRepository) MovePostToTrash(postUid uint, removedBy uint) uint {
+	param := models.TrashInsertParameter{
+		Type:      models.TRASH_POST,
+		TargetUid: postUid,
+		PostUid:   postUid,
+		RemovedBy: removedBy,
+	}
+	query := fmt.Sprintf("SELECT board_uid, user_uid, status FROM %s%s WHERE uid = ? AND status != ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_POST)
+	err := r.db.QueryRow(query, postUid, models.CONTENT_REMOVED).Scan(&param.BoardUid, &param.UserUid, &param.PrevStatus)
+	if err != nil {
+		return models.FAILED
+	}
+	trashUid := r.InsertTrash(param)
+	if trashUid == models.FAILED {
+		return models.FAILED
+	}
+
+	query = fmt.Sprintf("SELECT uid, user_uid, status FROM %s%s WHERE post_uid = ? AND status != ?",
+		configs.Env.Prefix, models.TABLE_COMMENT)
+	rows, err := r.db.Query(query, postUid, models.CONTENT_REMOVED)
+	if err == nil {
+		comments := make([]models.TrashInsertParameter, 0)
+		for rows.Next() {
+			comment := models.TrashInsertParameter{
+				ParentUid: trashUid,
+				Type:      models.TRASH_COMMENT,
+				BoardUid:  param.BoardUid,
+				PostUid:   postUid,
+				RemovedBy: removedBy,
+			}
+			if err := rows.Scan(&comment.TargetUid, &comment.UserUid, &comment.PrevStatus); err != nil {
+				break
+			}
+			comments = append(comments, comment)
+		}
+		rows.Close()
+
+		for _, comment := range comments {
+			r.InsertTrash(comment)
+		}
+	}
+
+	query = fmt.Sprintf("UPDATE %s%s SET status = ? WHERE post_uid = ? AND status != ?", configs.Env.Prefix, models.TABLE_COMMENT)
+	r.db.Exec(query, models.CONTENT_REMOVED, postUid, models.CONTENT_REMOVED)
+	query = fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
+	r.db.Exec(query, models.CONTENT_REMOVED, postUid)
+	return trashUid
+}
+
+// 휴지통 항목의 게시글 혹은 댓글 내용을 완전히 지우기 (다른 테이블들이 참조하므로 레코드는 삭제 상태로 남겨둠)
+func (r *TsboardTrashRepository) PurgeTarget(record models.TrashRecord) {
+	query := fmt.Sprintf("UPDATE %s%s SET content = '' WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+	if record.Type == models.TRASH_POST {
+		query = fmt.Sprintf("UPDATE %s%s SET title = '', content = '' WHERE uid = ? LIMIT 1",
+			configs.Env.Prefix, models.TABLE_POST)
+	}
+	r.db.Exec(query, record.TargetUid)
+}
+
+// 휴지통 항목과 딸린 항목들 삭제하기
+func (r *TsboardTrashRepository) RemoveTrash(trashUid uint) {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE uid = ? OR parent_uid = ?", configs.Env.Prefix, models.TABLE_TRASH)
+	r.db.Exec(query, trashUid, trashUid)
+}
+
+// 휴지통 항목의 게시글 혹은 댓글을 삭제 전 상태로 되돌리기
+func (r *TsboardTrashRepository) RestoreTarget(record models.TrashRecord) {
+	if record.Type == models.TRASH_POST {
+		query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
+		r.db.Exec(query, record.PrevStatus, record.TargetUid)
+		return
+	}
+
+	if len(record.Backup) > 0 {
+		query := fmt.Sprintf("UPDATE %s%s SET content = ?, status = ? WHERE uid = ? LIMIT 1",
+			configs.Env.Prefix, models.TABLE_COMMENT)
+		r.db.Exec(query, record.Backup, record.PrevStatus, record.TargetUid)
+		return
+	}
+	query := fmt.Sprintf("UPDATE %s%s SET status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_COMMENT)
+	r.db.Exec(query, record.PrevStatus, record.TargetUid)
+}
+
+// 휴지통 항목을 되돌릴 때의 상태 바꾸기
+func (r *TsboardTrashRepository) UpdatePrevStatus(trashUid uint, status models.Status) {
+	query := fmt.Sprintf("UPDATE %s%s SET prev_status = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_TRASH)
+	r.db.Exec(query, status, trashUid)
+}
+
+// 쿼리 결과를 휴지통 레코드로 변환하기
+func (r *TsboardTrashRepository) scanTrashRecord(row *sql.Row) (models.TrashRecord, error) {
+	record := models.TrashRecord{}
+	err := row.Scan(&record.Uid, &record.ParentUid, &record.Type, &record.TargetUid, &record.BoardUid, &record.PostUid,
+		&record.UserUid, &record.RemovedBy, &record.PrevStatus, &record.Backup, &record.Removed)
+	return record, err
+}
+
+// 쿼리 결과들을 휴지통 레코드 목록으로 변환하기
+func (r *TsboardTrashRepository) makeTrashRecords(rows *sql.Rows) []models.TrashRecord {
+	records := make([]models.TrashRecord, 0)
+	for rows.Next() {
+		record := models.TrashRecord{}
+		err := rows.Scan(&record.Uid, &record.ParentUid, &record.Type, &record.TargetUid, &record.BoardUid, &record.PostUid,
+			&record.UserUid, &record.RemovedBy, &record.PrevStatus, &record.Backup, &record.Removed)
+		if err != nil {
+			return records
+		}
+		records = append(records, record)
+	}
+	return records
+}
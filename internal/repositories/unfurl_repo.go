@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type UnfurlRepository interface {
+	FindPreview(url string) (models.LinkPreviewRecord, error)
+	FindPreviews(urls []string) []models.LinkPreview
+	UpsertPreview(record models.LinkPreviewRecord) error
+}
+
+type TsboardUnfurlRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardUnfurlRepository(db *sql.DB) *TsboardUnfurlRepository {
+	return &TsboardUnfurlRepository{db: db}
+}
+
+// 캐시에 저장된 링크 미리보기 가져오기 (실패 기록 포함)
+func (r *TsboardUnfurlRepository) FindPreview(url string) (models.LinkPreviewRecord, error) {
+	record := models.LinkPreviewRecord{}
+	query := fmt.Sprintf(`SELECT url, title, description, site_name, image, type, failed, fetched
+												FROM %s%s WHERE url_hash = ? LIMIT 1`, configs.Env.Prefix, models.TABLE_LINK_PREVIEW)
+	var failed uint8
+	err := r.db.QueryRow(query, utils.GetHashedString(url)).Scan(&record.Url, &record.Title, &record.Description,
+		&record.SiteName, &record.Image, &record.Type, &failed, &record.Fetched)
+	record.Failed = failed > 0
+	return record, err
+}
+
+// 주어진 링크들 중 미리보기를 가져오는데 성공한 것들만 링크 순서대로 가져오기
+func (r *TsboardUnfurlRepository) FindPreviews(urls []string) []models.LinkPreview {
+	items := make([]models.LinkPreview, 0)
+	if len(urls) < 1 {
+		return items
+	}
+	marks := strings.TrimSuffix(strings.Repeat("?,", len(urls)), ",")
+	query := fmt.Sprintf(`SELECT url, title, description, site_name, image, type, fetched
+												FROM %s%s WHERE url_hash IN (%s) AND failed = 0`,
+		configs.Env.Prefix, models.TABLE_LINK_PREVIEW, marks)
+
+	values := make([]interface{}, 0)
+	for _, url := range urls {
+		values = append(values, utils.GetHashedString(url))
+	}
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return items
+	}
+	defer rows.Close()
+
+	found := make(map[string]models.LinkPreview)
+	for rows.Next() {
+		item := models.LinkPreview{}
+		if err := rows.Scan(&item.Url, &item.Title, &item.Description, &item.SiteName, &item.Image, &item.Type, &item.Fetched); err != nil {
+			return items
+		}
+		found[item.Url] = item
+	}
+	for _, url := range urls {
+		if item, exists := found[url]; exists {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// 링크 미리보기를 캐시에 저장하기 (이미 있으면 갱신)
+func (r *TsboardUnfurlRepository) UpsertPreview(record models.LinkPreviewRecord) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (url_hash, url, title, description, site_name, image, type, failed, fetched)
+												VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+												ON DUPLICATE KEY UPDATE title = VALUES(title), description = VALUES(description),
+												site_name = VALUES(site_name), image = VALUES(image), type = VALUES(type),
+												failed = VALUES(failed), fetched = VALUES(fetched)`,
+		configs.Env.Prefix, models.TABLE_LINK_PREVIEW)
+
+	failed := 0
+	if record.Failed {
+		failed = 1
+	}
+	_, err := r.db.Exec(query, utils.GetHashedString(record.Url), record.Url, record.Title, record.Description,
+		record.SiteName, record.Image, record.Type, failed, record.Fetched)
+	return err
+}
@@ -10,7 +10,13 @@ import (
 )
 
 type UserRepository interface {
+	AddStorageUsage(userUid uint, usage models.StorageUsage, size int64)
+	GetAttachedPaths(userUid uint) []string
+	GetInsertedImagePaths(userUid uint) []string
+	GetProfilePath(userUid uint) string
 	GetReportResponse(userUid uint) string
+	GetStorageQuota(userUid uint) (uint64, bool)
+	GetStorageUsage(userUid uint) (models.UserStorageResult, bool)
 	GetUserBlackList(userUid uint) []uint
 	GetUserLevelPoint(userUid uint) (int, int)
 	InsertBlackList(actionUserUid uint, targetUserUid uint) error
@@ -18,6 +24,7 @@ type UserRepository interface {
 	InsertNewUser(id string, pw string, name string) uint
 	InsertUserPermission(userUid uint, perm models.UserPermissionResult) error
 	InsertReportResponse(actionUserUid uint, targetUserUid uint, response string) error
+	InsertStorageUsage(userUid uint, usage models.UserStorageResult)
 	IsEmailDuplicated(id string) bool
 	IsNameDuplicated(name string, userUid uint) bool
 	IsBlocked(userUid uint) bool
@@ -25,8 +32,11 @@ type UserRepository interface {
 	IsPermissionAdded(userUid uint) bool
 	IsUserReported(userUid uint) bool
 	LoadUserPermission(userUid uint) models.UserPermissionResult
+	RemoveStorageQuota(userUid uint) error
+	RemoveStorageUsages()
 	UpdatePassword(userUid uint, password string) error
 	UpdatePointHistory(param models.UpdatePointParameter) error
+	UpdateStorageQuota(userUid uint, quota uint64) error
 	UpdateUserInfoString(userUid uint, name string, signature string) error
 	UpdateUserProfile(userUid uint, imagePath string) error
 	UpdateUserPermission(userUid uint, perm models.UserPermissionResult) error
@@ -44,6 +54,74 @@ func NewTsboardUserRepository(db *sql.DB) *TsboardUserRepository {
 	return &TsboardUserRepository{db: db}
 }
 
+// 저장해 둔 사용자의 저장 공간 사용량 늘리거나 줄이기 (아직 집계 전이면 무시)
+func (r *TsboardUserRepository) AddStorageUsage(userUid uint, usage models.StorageUsage, size int64) {
+	if size == 0 {
+		return
+	}
+	query := fmt.Sprintf("UPDATE %s%s SET %s = GREATEST(%s + ?, 0), timestamp = ? WHERE user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_USER_USAGE, usage.String(), usage.String())
+	r.db.Exec(query, size, time.Now().UnixMilli(), userUid)
+}
+
+// 사용자가 작성한 게시글들에 첨부된 파일(및 썸네일) 경로들 가져오기
+func (r *TsboardUserRepository) GetAttachedPaths(userUid uint) []string {
+	paths := make([]string, 0)
+	query := fmt.Sprintf(`SELECT f.path FROM %s%s AS f JOIN %s%s AS p ON f.post_uid = p.uid WHERE p.user_uid = ?
+												UNION ALL SELECT t.path FROM %s%s AS t JOIN %s%s AS p ON t.post_uid = p.uid WHERE p.user_uid = ?
+												UNION ALL SELECT t.full_path FROM %s%s AS t JOIN %s%s AS p ON t.post_uid = p.uid WHERE p.user_uid = ?
+												UNION ALL SELECT v.path FROM %s%s AS v JOIN %s%s AS p ON v.post_uid = p.uid WHERE p.user_uid = ? AND v.path != ''
+												UNION ALL SELECT d.path FROM %s%s AS d WHERE d.user_uid = ?`,
+		configs.Env.Prefix, models.TABLE_FILE, configs.Env.Prefix, models.TABLE_POST,
+		configs.Env.Prefix, models.TABLE_FILE_THUMB, configs.Env.Prefix, models.TABLE_POST,
+		configs.Env.Prefix, models.TABLE_FILE_THUMB, configs.Env.Prefix, models.TABLE_POST,
+		configs.Env.Prefix, models.TABLE_FILE_VARIANT, configs.Env.Prefix, models.TABLE_POST,
+		configs.Env.Prefix, models.TABLE_DRAFT_FILE)
+
+	rows, err := r.db.Query(query, userUid, userUid, userUid, userUid, userUid)
+	if err != nil {
+		return paths
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return paths
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// 사용자가 게시글 본문에 삽입한 이미지 경로들 가져오기
+func (r *TsboardUserRepository) GetInsertedImagePaths(userUid uint) []string {
+	paths := make([]string, 0)
+	query := fmt.Sprintf("SELECT path FROM %s%s WHERE user_uid = ?", configs.Env.Prefix, models.TABLE_IMAGE)
+	rows, err := r.db.Query(query, userUid)
+	if err != nil {
+		return paths
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return paths
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// 사용자의 프로필 이미지 경로 가져오기
+func (r *TsboardUserRepository) GetProfilePath(userUid uint) string {
+	var profile string
+	query := fmt.Sprintf("SELECT profile FROM %s%s WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_USER)
+	r.db.QueryRow(query, userUid).Scan(&profile)
+	return profile
+}
+
 // 사용자 신고 내용에 대한 응답 가져오기
 func (r *TsboardUserRepository) GetReportResponse(userUid uint) string {
 	var response string
@@ -53,6 +131,30 @@ func (r *TsboardUserRepository) GetReportResponse(userUid uint) string {
 	return response
 }
 
+// 관리자가 개별 지정한 사용자의 저장 공간 한도 가져오기
+func (r *TsboardUserRepository) GetStorageQuota(userUid uint) (uint64, bool) {
+	var quota uint64
+	query := fmt.Sprintf("SELECT quota FROM %s%s WHERE user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_USER_STORAGE)
+	err := r.db.QueryRow(query, userUid).Scan(&quota)
+	if err != nil {
+		return 0, false
+	}
+	return quota, true
+}
+
+// 저장해 둔 사용자의 저장 공간 사용량 가져오기
+func (r *TsboardUserRepository) GetStorageUsage(userUid uint) (models.UserStorageResult, bool) {
+	result := models.UserStorageResult{}
+	query := fmt.Sprintf("SELECT attachment, image, profile FROM %s%s WHERE user_uid = ? LIMIT 1",
+		configs.Env.Prefix, models.TABLE_USER_USAGE)
+	err := r.db.QueryRow(query, userUid).Scan(&result.Attachment, &result.Image, &result.Profile)
+	if err != nil {
+		return result, false
+	}
+	return result, true
+}
+
 // 사용자가 지정한 블랙 리스트 목록 가져오기
 func (r *TsboardUserRepository) GetUserBlackList(userUid uint) []uint {
 	blocks := make([]uint, 0)
@@ -153,6 +255,13 @@ func (r *TsboardUserRepository) InsertReportResponse(actionUserUid uint, targetU
 	return err
 }
 
+// 새로 집계한 사용자의 저장 공간 사용량 저장하기
+func (r *TsboardUserRepository) InsertStorageUsage(userUid uint, usage models.UserStorageResult) {
+	query := fmt.Sprintf(`INSERT IGNORE INTO %s%s (user_uid, attachment, image, profile, timestamp)
+												VALUES (?, ?, ?, ?, ?)`, configs.Env.Prefix, models.TABLE_USER_USAGE)
+	r.db.Exec(query, userUid, usage.Attachment, usage.Image, usage.Profile, time.Now().UnixMilli())
+}
+
 // (회원가입 시) 이메일 주소가 중복되는지 확인
 func (r *TsboardUserRepository) IsEmailDuplicated(id string) bool {
 	query := fmt.Sprintf("SELECT uid FROM %s%s WHERE id = ? LIMIT 1",
@@ -231,6 +340,19 @@ func (r *TsboardUserRepository) LoadUserPermission(userUid uint) models.UserPerm
 	return result
 }
 
+// 저장해 둔 사용량들을 모두 지워서 다음 조회 때 다시 집계하도록 하기
+func (r *TsboardUserRepository) RemoveStorageUsages() {
+	query := fmt.Sprintf("DELETE FROM %s%s", configs.Env.Prefix, models.TABLE_USER_USAGE)
+	r.db.Exec(query)
+}
+
+// 관리자가 개별 지정한 저장 공간 한도 삭제하기
+func (r *TsboardUserRepository) RemoveStorageQuota(userUid uint) error {
+	query := fmt.Sprintf("DELETE FROM %s%s WHERE user_uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_USER_STORAGE)
+	_, err := r.db.Exec(query, userUid)
+	return err
+}
+
 // 사용자 비밀번호 변경하기
 func (r *TsboardUserRepository) UpdatePassword(userUid uint, pw string) error {
 	query := fmt.Sprintf("UPDATE %s%s SET password = ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_USER)
@@ -246,6 +368,15 @@ func (r *TsboardUserRepository) UpdatePointHistory(param models.UpdatePointParam
 	return err
 }
 
+// 사용자의 저장 공간 한도 개별 지정하기
+func (r *TsboardUserRepository) UpdateStorageQuota(userUid uint, quota uint64) error {
+	query := fmt.Sprintf(`INSERT INTO %s%s (user_uid, quota, timestamp) VALUES (?, ?, ?)
+												ON DUPLICATE KEY UPDATE quota = VALUES(quota), timestamp = VALUES(timestamp)`,
+		configs.Env.Prefix, models.TABLE_USER_STORAGE)
+	_, err := r.db.Exec(query, userUid, quota, time.Now().UnixMilli())
+	return err
+}
+
 // 사용자 이름, 서명 변경하기
 func (r *TsboardUserRepository) UpdateUserInfoString(userUid uint, name string, signature string) error {
 	query := fmt.Sprintf("UPDATE %s%s SET name = ?, signature = ? WHERE uid = ? LIMIT 1",
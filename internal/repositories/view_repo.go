@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ViewRepository interface {
+	GetViewStatistic(postUid uint, days int) models.AdminDashboardStatistic
+	InsertDailyView(boardUid uint, postUid uint, count uint)
+	UpdatePostHit(postUid uint, count uint)
+}
+
+type TsboardViewRepository struct {
+	db *sql.DB
+}
+
+// sql.DB 포인터 주입받기
+func NewTsboardViewRepository(db *sql.DB) *TsboardViewRepository {
+	return &TsboardViewRepository{db: db}
+}
+
+// 최근 일자별 조회수와 전체 조회수 가져오기 (postUid 가 0이면 모든 게시글)
+func (r *TsboardViewRepository) GetViewStatistic(postUid uint, days int) models.AdminDashboardStatistic {
+	result := models.AdminDashboardStatistic{History: make([]models.AdminDashboardStatus, 0)}
+	query := fmt.Sprintf("SELECT IFNULL(SUM(hit), 0) FROM %s%s", configs.Env.Prefix, models.TABLE_POST)
+	values := make([]interface{}, 0)
+	if postUid > 0 {
+		query += " WHERE uid = ?"
+		values = append(values, postUid)
+	}
+	if err := r.db.QueryRow(query, values...).Scan(&result.Total); err != nil {
+		return result
+	}
+
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	query = fmt.Sprintf("SELECT IFNULL(SUM(views), 0) FROM %s%s WHERE date = ?", configs.Env.Prefix, models.TABLE_POST_VIEW)
+	if postUid > 0 {
+		query += " AND post_uid = ?"
+	}
+
+	for d := 0; d < days; d++ {
+		history := models.AdminDashboardStatus{}
+		history.Date = uint64(day.AddDate(0, 0, d*-1).UnixMilli())
+		values = []interface{}{history.Date}
+		if postUid > 0 {
+			values = append(values, postUid)
+		}
+		if err := r.db.QueryRow(query, values...).Scan(&history.Visit); err != nil {
+			return result
+		}
+		result.History = append(result.History, history)
+	}
+	return result
+}
+
+// 오늘 날짜의 게시글 조회수 통계에 더하기
+func (r *TsboardViewRepository) InsertDailyView(boardUid uint, postUid uint, count uint) {
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	query := fmt.Sprintf(`INSERT INTO %s%s (board_uid, post_uid, date, views) VALUES (?, ?, ?, ?)
+												ON DUPLICATE KEY UPDATE views = views + VALUES(views)`, configs.Env.Prefix, models.TABLE_POST_VIEW)
+	r.db.Exec(query, boardUid, postUid, day.UnixMilli(), count)
+}
+
+// 모아둔 조회수를 게시글에 더하기
+func (r *TsboardViewRepository) UpdatePostHit(postUid uint, count uint) {
+	query := fmt.Sprintf("UPDATE %s%s SET hit = hit + ? WHERE uid = ? LIMIT 1", configs.Env.Prefix, models.TABLE_POST)
+	r.db.Exec(query, count, postUid)
+}
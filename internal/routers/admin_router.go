@@ -12,8 +12,13 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	board := admin.Group("/board")
 	dashboard := admin.Group("/dashboard")
 	group := admin.Group("/group")
+	hashtag := admin.Group("/hashtag")
+	job := admin.Group("/job")
 	latest := admin.Group("/latest")
+	moderation := admin.Group("/moderation")
 	report := admin.Group("/report")
+	spam := admin.Group("/spam")
+	trash := admin.Group("/trash")
 	user := admin.Group("/user")
 
 	bGeneral := board.Group("/general")
@@ -25,6 +30,9 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	bGeneral.Patch("/change/type", h.Admin.ChangeBoardTypeHandler, middlewares.AdminMiddleware())
 	bGeneral.Patch("/change/rows", h.Admin.ChangeBoardRowHandler, middlewares.AdminMiddleware())
 	bGeneral.Patch("/change/width", h.Admin.ChangeBoardWidthHandler, middlewares.AdminMiddleware())
+	bGeneral.Patch("/modify/category", h.Admin.ModifyBoardCategoryHandler, middlewares.AdminMiddleware())
+	bGeneral.Patch("/move/category", h.Admin.MoveBoardCategoryHandler, middlewares.AdminMiddleware())
+	bGeneral.Patch("/reorder/category", h.Admin.ReorderBoardCategoryHandler, middlewares.AdminMiddleware())
 	bGeneral.Delete("/remove/category", h.Admin.RemoveBoardCategoryHandler, middlewares.AdminMiddleware())
 	bGeneral.Patch("/use/category", h.Admin.UseBoardCategoryHandler, middlewares.AdminMiddleware())
 
@@ -34,6 +42,29 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	bPermission.Patch("/update/levels", h.Admin.ChangeBoardLevelHandler, middlewares.AdminMiddleware())
 	bPermission.Get("/candidates", h.Admin.GetAdminCandidatesHandler, middlewares.AdminMiddleware())
 
+	bDescriber := board.Group("/describer")
+	bDescriber.Get("/load", h.Admin.BoardDescriberLoadHandler, middlewares.AdminMiddleware())
+	bDescriber.Patch("/update", h.Admin.ChangeBoardDescriberHandler, middlewares.AdminMiddleware())
+	bDescriber.Post("/regenerate", h.Admin.RegenerateDescriptionHandler, middlewares.AdminMiddleware())
+
+	bField := board.Group("/field")
+	bField.Post("/add", h.Admin.AddBoardFieldHandler, middlewares.AdminMiddleware())
+	bField.Get("/load", h.Admin.BoardFieldLoadHandler, middlewares.AdminMiddleware())
+	bField.Patch("/modify", h.Admin.ModifyBoardFieldHandler, middlewares.AdminMiddleware())
+	bField.Delete("/remove", h.Admin.RemoveBoardFieldHandler, middlewares.AdminMiddleware())
+
+	bModeration := board.Group("/moderation")
+	bModeration.Get("/load", h.Admin.BoardModerationLoadHandler, middlewares.AdminMiddleware())
+	bModeration.Patch("/update", h.Admin.ChangeBoardModerationHandler, middlewares.AdminMiddleware())
+
+	bReaction := board.Group("/reaction")
+	bReaction.Get("/load", h.Admin.BoardReactionLoadHandler, middlewares.AdminMiddleware())
+	bReaction.Patch("/update", h.Admin.ChangeBoardReactionHandler, middlewares.AdminMiddleware())
+
+	bVariant := board.Group("/variant")
+	bVariant.Get("/load", h.Admin.BoardVariantLoadHandler, middlewares.AdminMiddleware())
+	bVariant.Patch("/update", h.Admin.ChangeBoardVariantHandler, middlewares.AdminMiddleware())
+
 	bPoint := board.Group("/point")
 	bPoint.Get("/load", h.Admin.BoardPointLoadHandler, middlewares.AdminMiddleware())
 	bPoint.Patch("/update/points", h.Admin.ChangeBoardPointHandler, middlewares.AdminMiddleware())
@@ -43,6 +74,7 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	dLoad.Get("/item", h.Admin.DashboardItemLoadHandler, middlewares.AdminMiddleware())
 	dLoad.Get("/latest", h.Admin.DashboardLatestLoadHandler, middlewares.AdminMiddleware())
 	dLoad.Get("/statistic", h.Admin.DashboardStatisticLoadHandler, middlewares.AdminMiddleware())
+	dLoad.Get("/views", h.Admin.DashboardViewLoadHandler, middlewares.AdminMiddleware())
 
 	gGeneral := group.Group("/general")
 	gGeneral.Get("/load", h.Admin.GroupGeneralLoadHandler, middlewares.AdminMiddleware())
@@ -59,6 +91,29 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	gList.Delete("/remove/group", h.Admin.RemoveGroupHandler, middlewares.AdminMiddleware())
 	gList.Put("/update/group", h.Admin.ChangeGroupIdHandler, middlewares.AdminMiddleware())
 
+	job.Get("/list", h.Admin.JobListLoadHandler, middlewares.AdminMiddleware())
+	job.Patch("/retry", h.Admin.RetryJobHandler, middlewares.AdminMiddleware())
+
+	hashtag.Get("/list", h.Admin.HashtagListLoadHandler, middlewares.AdminMiddleware())
+	hashtag.Patch("/rename", h.Admin.RenameHashtagHandler, middlewares.AdminMiddleware())
+	hashtag.Patch("/merge", h.Admin.MergeHashtagHandler, middlewares.AdminMiddleware())
+	hashtag.Patch("/block", h.Admin.BlockHashtagHandler, middlewares.AdminMiddleware())
+	hashtag.Patch("/recount", h.Admin.RecountHashtagHandler, middlewares.AdminMiddleware())
+	hashtag.Delete("/remove", h.Admin.RemoveHashtagHandler, middlewares.AdminMiddleware())
+
+	moderation.Get("/list", h.Admin.ModerationListLoadHandler, middlewares.AdminMiddleware())
+	moderation.Patch("/approve", h.Admin.ApproveModerationHandler, middlewares.AdminMiddleware())
+	moderation.Patch("/reject", h.Admin.RejectModerationHandler, middlewares.AdminMiddleware())
+
+	spam.Get("/rule/load", h.Admin.SpamRuleLoadHandler, middlewares.AdminMiddleware())
+	spam.Post("/rule/add", h.Admin.AddSpamRuleHandler, middlewares.AdminMiddleware())
+	spam.Delete("/rule/remove", h.Admin.RemoveSpamRuleHandler, middlewares.AdminMiddleware())
+	spam.Get("/log/list", h.Admin.SpamLogListLoadHandler, middlewares.AdminMiddleware())
+
+	trash.Get("/list", h.Admin.TrashListLoadHandler, middlewares.AdminMiddleware())
+	trash.Patch("/restore", h.Admin.RestoreTrashHandler, middlewares.AdminMiddleware())
+	trash.Delete("/purge", h.Admin.PurgeTrashHandler, middlewares.AdminMiddleware())
+
 	latest.Get("/comment", h.Admin.LatestCommentLoadHandler, middlewares.AdminMiddleware())
 	latest.Get("/search/comment", h.Admin.LatestCommentSearchHandler, middlewares.AdminMiddleware())
 	latest.Delete("/remove/comment", h.Admin.RemoveCommentHandler, middlewares.AdminMiddleware())
@@ -72,4 +127,6 @@ func RegisterAdminRouters(api fiber.Router, h *handlers.Handler) {
 	user.Get("/list", h.Admin.UserListLoadHandler, middlewares.AdminMiddleware())
 	user.Get("/load", h.Admin.UserInfoLoadHandler, middlewares.AdminMiddleware())
 	user.Patch("/modify", h.Admin.UserInfoModifyHandler, middlewares.AdminMiddleware())
+	user.Get("/storage/load", h.Admin.UserStorageLoadHandler, middlewares.AdminMiddleware())
+	user.Patch("/storage/modify", h.Admin.UserStorageModifyHandler, middlewares.AdminMiddleware())
 }
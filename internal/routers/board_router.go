@@ -13,10 +13,28 @@ func RegisterBoardRouters(api fiber.Router, h *handlers.Handler) {
 	board.Get("/view", h.Board.BoardViewHandler)
 	board.Get("/photo/list", h.Board.GalleryListHandler)
 	board.Get("/photo/view", h.Board.GalleryLoadPhotoHandler)
+	board.Get("/poll/result", h.Board.PollResultHandler)
+	board.Get("/reaction/users", h.Board.PostReactionUsersHandler)
+	board.Get("/related", h.Board.RelatedPostListHandler)
+	board.Get("/series/list", h.Board.SeriesListHandler)
+	board.Get("/series/view", h.Board.SeriesViewHandler)
 
 	board.Get("/download", h.Board.DownloadHandler, middlewares.JWTMiddleware())
+	board.Post("/export/request", h.Board.ExportHandler, middlewares.JWTMiddleware())
+	board.Get("/export/status", h.Board.ExportStatusHandler, middlewares.JWTMiddleware())
+	board.Get("/export/download", h.Board.ExportDownloadHandler, middlewares.JWTMiddleware())
 	board.Get("/move/list", h.Board.ListForMoveHandler, middlewares.JWTMiddleware())
 	board.Patch("/like", h.Board.LikePostHandler, middlewares.JWTMiddleware())
+	board.Post("/poll/vote", h.Board.VotePollHandler, middlewares.JWTMiddleware())
+	board.Patch("/reaction", h.Board.PostReactionHandler, middlewares.JWTMiddleware())
 	board.Put("/move/apply", h.Board.MovePostHandler, middlewares.JWTMiddleware())
 	board.Delete("/remove/post", h.Board.RemovePostHandler, middlewares.JWTMiddleware())
+	board.Patch("/restore/post", h.Board.RestorePostHandler, middlewares.JWTMiddleware())
+	board.Post("/series/create", h.Board.CreateSeriesHandler, middlewares.JWTMiddleware())
+	board.Patch("/series/modify", h.Board.ModifySeriesHandler, middlewares.JWTMiddleware())
+	board.Put("/series/posts", h.Board.UpdateSeriesPostsHandler, middlewares.JWTMiddleware())
+	board.Delete("/series/remove", h.Board.RemoveSeriesHandler, middlewares.JWTMiddleware())
+	board.Get("/revision/diff", h.Board.RevisionDiffHandler, middlewares.JWTMiddleware())
+	board.Get("/revision/list", h.Board.RevisionListHandler, middlewares.JWTMiddleware())
+	board.Patch("/revision/restore", h.Board.RestoreRevisionHandler, middlewares.JWTMiddleware())
 }
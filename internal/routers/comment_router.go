@@ -10,10 +10,13 @@ import (
 func RegisterCommentRouters(api fiber.Router, h *handlers.Handler) {
 	comment := api.Group("/comment")
 	comment.Get("/list", h.Comment.CommentListHandler)
+	comment.Get("/reaction/users", h.Comment.CommentReactionUsersHandler)
 
 	comment.Patch("/like", h.Comment.LikeCommentHandler, middlewares.JWTMiddleware())
 	comment.Patch("/modify", h.Comment.ModifyCommentHandler, middlewares.JWTMiddleware())
+	comment.Patch("/reaction", h.Comment.CommentReactionHandler, middlewares.JWTMiddleware())
 	comment.Delete("/remove", h.Comment.RemoveCommentHandler, middlewares.JWTMiddleware())
 	comment.Post("/reply", h.Comment.ReplyCommentHandler, middlewares.JWTMiddleware())
+	comment.Patch("/restore", h.Comment.RestoreCommentHandler, middlewares.JWTMiddleware())
 	comment.Post("/write", h.Comment.WriteCommentHandler, middlewares.JWTMiddleware())
 }
@@ -11,12 +11,20 @@ func RegisterEditorRouters(api fiber.Router, h *handlers.Handler) {
 	editor := api.Group("/editor")
 	editor.Get("/config", h.Editor.GetEditorConfigHandler)
 
+	editor.Get("/draft/list", h.Editor.LoadDraftListHandler, middlewares.JWTMiddleware())
+	editor.Get("/draft/load", h.Editor.LoadDraftHandler, middlewares.JWTMiddleware())
+	editor.Delete("/draft/remove", h.Editor.RemoveDraftHandler, middlewares.JWTMiddleware())
+	editor.Delete("/draft/remove/attached", h.Editor.RemoveDraftFileHandler, middlewares.JWTMiddleware())
+	editor.Post("/draft/save", h.Editor.SaveDraftHandler, middlewares.JWTMiddleware())
 	editor.Get("/load/images", h.Editor.LoadInsertImageHandler, middlewares.JWTMiddleware())
+	editor.Get("/load/jobs", h.Editor.LoadJobStatusHandler, middlewares.JWTMiddleware())
 	editor.Get("/load/post", h.Editor.LoadPostHandler, middlewares.JWTMiddleware())
+	editor.Get("/mention/suggestion", h.Editor.SuggestionMentionHandler, middlewares.JWTMiddleware())
 	editor.Patch("/modify", h.Editor.ModifyPostHandler, middlewares.JWTMiddleware())
 	editor.Delete("/remove/attached", h.Editor.RemoveAttachedFileHandler, middlewares.JWTMiddleware())
 	editor.Delete("/remove/image", h.Editor.RemoveInsertImageHandler, middlewares.JWTMiddleware())
 	editor.Get("/tag/suggestion", h.Editor.SuggestionHashtagHandler, middlewares.JWTMiddleware())
+	editor.Get("/unfurl", h.Editor.UnfurlLinkHandler, middlewares.JWTMiddleware())
 	editor.Post("/upload/images", h.Editor.UploadInsertImageHandler, middlewares.JWTMiddleware())
 	editor.Post("/write", h.Editor.WritePostHandler, middlewares.JWTMiddleware())
 }
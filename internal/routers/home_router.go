@@ -13,6 +13,8 @@ func RegisterHomeRouters(api fiber.Router, h *handlers.Handler) {
 	home.Get("/latest", h.Home.LoadAllPostsHandler)
 	home.Get("/latest/post", h.Home.LoadPostsByIdHandler)
 	home.Get("/sidebar/links", h.Home.LoadSidebarLinkHandler)
+	home.Get("/tag/cloud", h.Home.LoadTagCloudHandler)
+	home.Get("/tag/posts", h.Home.LoadTagPostsHandler)
 
 	seo := api.Group("/seo")
 	seo.Get("/main.html", h.Home.LoadMainPageHandler)
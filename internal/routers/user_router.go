@@ -13,6 +13,11 @@ func RegisterUserRouters(api fiber.Router, h *handlers.Handler) {
 	user.Post("/change/password", h.User.ChangePasswordHandler)
 
 	user.Post("/report", h.User.ReportUserHandler, middlewares.JWTMiddleware())
+	user.Post("/bookmark/add", h.User.AddBookmarkHandler, middlewares.JWTMiddleware())
+	user.Get("/bookmark/folders", h.User.BookmarkFolderListHandler, middlewares.JWTMiddleware())
+	user.Get("/bookmark/list", h.User.BookmarkListHandler, middlewares.JWTMiddleware())
+	user.Delete("/bookmark/remove", h.User.RemoveBookmarkHandler, middlewares.JWTMiddleware())
 	user.Get("/load/permission", h.User.LoadUserPermissionHandler, middlewares.JWTMiddleware())
+	user.Get("/storage", h.User.LoadUserStorageHandler, middlewares.JWTMiddleware())
 	user.Post("/manage/user", h.User.ManageUserPermissionHandler, middlewares.JWTMiddleware())
 }
@@ -3,13 +3,16 @@ package services
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/sirini/goapi/internal/repositories"
 	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
 )
 
 type AdminService interface {
-	AddBoardCategory(boardUid uint, name string) uint
+	AddBoardCategory(boardUid uint, parentUid uint, name string) uint
+	AddBoardField(boardUid uint, field models.BoardField) (uint, error)
 	ChangeBoardAdmin(boardUid uint, newAdminUid uint) error
 	ChangeBoardLevelPolicy(boardUid uint, level models.BoardActionLevel) error
 	ChangeBoardPointPolicy(boardUid uint, point models.BoardActionPoint) error
@@ -17,10 +20,17 @@ type AdminService interface {
 	ChangeGroupId(groupUid uint, newGroupId string) error
 	CreateNewBoard(groupUid uint, newBoardId string) models.AdminCreateBoardResult
 	CreateNewGroup(newGroupId string) models.AdminGroupConfig
+	ChangeBoardDescribeOption(boardUid uint, opt models.ImageDescribeOption) error
+	ChangeBoardReactionOption(boardUid uint, opt models.BoardReactionOption, useDefault bool) error
+	ChangeBoardVariantOption(boardUid uint, opt models.BoardVariantOption, useDefault bool) error
 	GetBoardAdminCandidates(name string, bunch uint) ([]models.BoardWriter, error)
+	GetBoardDescribeOption(boardUid uint) models.ImageDescribeOption
+	GetBoardFields(boardUid uint) models.AdminBoardFieldResult
 	GetBoardLevelPolicy(boardUid uint) (models.AdminBoardLevelPolicy, error)
 	GetBoardList(groupUid uint) []models.AdminGroupBoardItem
 	GetBoardPointPolicy(boardUid uint) (models.AdminBoardPointPolicy, error)
+	GetBoardReactionOption(boardUid uint) models.AdminBoardReactionResult
+	GetBoardVariantOption(boardUid uint) models.AdminBoardVariantResult
 	GetCommentList(param models.AdminLatestParameter) models.AdminLatestCommentResult
 	GetDashboardItems(bunch uint) models.AdminDashboardItem
 	GetDashboardLatests(bunch uint) models.AdminDashboardLatest
@@ -37,13 +47,20 @@ type AdminService interface {
 	GetSearchedReports(param models.AdminReportParameter) models.AdminReportResult
 	GetUserList(param models.AdminUserParameter) models.AdminUserItemResult
 	GetUserInfo(userUid uint) models.AdminUserInfo
+	ModifyBoardCategory(param models.CategoryModifyParameter) error
+	ModifyBoardField(boardUid uint, field models.BoardField) error
+	MoveBoardCategory(boardUid uint, categoryUid uint, parentUid uint) error
+	RegenerateImageDescriptions(boardUid uint, postUid uint) (uint, error)
 	RemoveBoardCategory(boardUid uint, catUid uint) error
+	ReorderBoardCategory(param models.CategoryReorderParameter) error
+	RemoveBoardField(boardUid uint, fieldUid uint) error
 	RemoveBoard(boardUid uint) error
-	RemoveComment(commentUid uint) error
+	RemoveComment(commentUid uint, actionUserUid uint) error
 	RemoveGroup(groupUid uint) error
-	RemovePost(postUid uint) error
+	RemovePost(postUid uint, actionUserUid uint) error
 	UpdateBoardSetting(boardUid uint, column string, value string) error
 	UpdateUserLevelPoint(userUid uint, level uint, point uint) error
+	UpdateUserStorageQuota(userUid uint, quota uint64, useDefault bool) error
 }
 
 type TsboardAdminService struct {
@@ -56,16 +73,34 @@ func NewTsboardAdminService(repos *repositories.Repository) *TsboardAdminService
 }
 
 // 카테고리 추가하기 (추가하면 카테고리를 사용하는 것으로 업데이트)
-func (s *TsboardAdminService) AddBoardCategory(boardUid uint, name string) uint {
+func (s *TsboardAdminService) AddBoardCategory(boardUid uint, parentUid uint, name string) uint {
 	if isDup := s.repos.Admin.IsAddedCategory(boardUid, name); isDup {
 		return models.FAILED
 	}
+	if parentUid > 0 {
+		cats := s.repos.Board.GetBoardCategories(boardUid)
+		if depth := utils.GetCategoryDepth(cats, parentUid); depth < 1 || depth >= models.CATEGORY_MAX_DEPTH {
+			return models.FAILED
+		}
+	}
 
-	insertId := s.repos.Admin.InsertCategory(boardUid, name)
+	insertId := s.repos.Admin.InsertCategory(boardUid, parentUid, name)
 	s.repos.Admin.UpdateBoardSetting(boardUid, "use_category", "1")
 	return insertId
 }
 
+// 게시판에 사용자 정의 필드 추가하기
+func (s *TsboardAdminService) AddBoardField(boardUid uint, field models.BoardField) (uint, error) {
+	if count := s.repos.Field.GetFieldCount(boardUid); count >= models.FIELD_MAX_COUNT {
+		return models.FAILED, fmt.Errorf("too many fields, up to %d fields are allowed", models.FIELD_MAX_COUNT)
+	}
+	insertId := s.repos.Field.InsertField(boardUid, field)
+	if insertId == models.FAILED {
+		return models.FAILED, fmt.Errorf("failed to add a new field")
+	}
+	return insertId, nil
+}
+
 // 게시판 관리자 변경하기
 func (s *TsboardAdminService) ChangeBoardAdmin(boardUid uint, newAdminUid uint) error {
 	if isBlocked := s.repos.User.IsBlocked(newAdminUid); isBlocked {
@@ -74,6 +109,30 @@ func (s *TsboardAdminService) ChangeBoardAdmin(boardUid uint, newAdminUid uint)
 	return s.repos.Admin.UpdateGroupBoardAdmin(models.TABLE_BOARD, boardUid, newAdminUid)
 }
 
+// 게시판별 이미지 설명글 생성 옵션 변경하기
+func (s *TsboardAdminService) ChangeBoardDescribeOption(boardUid uint, opt models.ImageDescribeOption) error {
+	return s.repos.Admin.UpdateDescribeOption(boardUid, opt)
+}
+
+// 게시판별 반응 이모지 옵션 변경하기 (useDefault 가 true면 기본 이모지 목록 사용)
+func (s *TsboardAdminService) ChangeBoardReactionOption(boardUid uint, opt models.BoardReactionOption, useDefault bool) error {
+	if useDefault {
+		return s.repos.Admin.RemoveReactionOption(boardUid)
+	}
+	if len(opt.Emojis) < 1 {
+		return fmt.Errorf("at least one emoji is required")
+	}
+	return s.repos.Admin.UpdateReactionOption(boardUid, opt)
+}
+
+// 게시판별 변형 이미지 생성 옵션 변경하기 (useDefault 가 true면 사이트 기본값 사용)
+func (s *TsboardAdminService) ChangeBoardVariantOption(boardUid uint, opt models.BoardVariantOption, useDefault bool) error {
+	if useDefault {
+		return s.repos.Admin.RemoveVariantOption(boardUid)
+	}
+	return s.repos.Admin.UpdateVariantOption(boardUid, opt)
+}
+
 // 게시판 레벨 제한값 변경하기
 func (s *TsboardAdminService) ChangeBoardLevelPolicy(boardUid uint, level models.BoardActionLevel) error {
 	return s.repos.Admin.UpdateLevelPolicy(boardUid, level)
@@ -148,6 +207,36 @@ func (s *TsboardAdminService) GetBoardAdminCandidates(name string, bunch uint) (
 	return s.repos.Admin.GetAdminCandidates(name, bunch)
 }
 
+// 게시판별 이미지 설명글 생성 옵션 가져오기
+func (s *TsboardAdminService) GetBoardDescribeOption(boardUid uint) models.ImageDescribeOption {
+	return s.repos.Board.GetDescribeOption(boardUid)
+}
+
+// 게시판에 정의된 사용자 정의 필드 목록 가져오기
+func (s *TsboardAdminService) GetBoardFields(boardUid uint) models.AdminBoardFieldResult {
+	return models.AdminBoardFieldResult{
+		Fields: s.repos.Field.GetFields(boardUid),
+	}
+}
+
+// 게시판별 반응 이모지 옵션 가져오기 (지정하지 않았다면 기본 이모지 목록)
+func (s *TsboardAdminService) GetBoardReactionOption(boardUid uint) models.AdminBoardReactionResult {
+	opt, isCustom := s.repos.Board.GetReactionOption(boardUid)
+	return models.AdminBoardReactionResult{
+		BoardReactionOption: utils.MergeReactionOption(opt, isCustom),
+		IsCustom:            isCustom,
+	}
+}
+
+// 게시판별 변형 이미지 생성 옵션 가져오기 (지정하지 않았다면 사이트 기본값)
+func (s *TsboardAdminService) GetBoardVariantOption(boardUid uint) models.AdminBoardVariantResult {
+	opt, isCustom := s.repos.Board.GetVariantOption(boardUid)
+	return models.AdminBoardVariantResult{
+		BoardVariantOption: utils.MergeVariantOption(opt, isCustom),
+		IsCustom:           isCustom,
+	}
+}
+
 // 게시판의 레벨 제한값 가져오기
 func (s *TsboardAdminService) GetBoardLevelPolicy(boardUid uint) (models.AdminBoardLevelPolicy, error) {
 	perm, err := s.repos.Admin.GetLevelPolicy(boardUid)
@@ -228,6 +317,7 @@ func (s *TsboardAdminService) GetDashboardStatistics(bunch uint) models.AdminDas
 	reply := s.repos.Admin.GetStatistic(models.TABLE_COMMENT, models.COLUMN_SUBMITTED, days)
 	file := s.repos.Admin.GetStatistic(models.TABLE_FILE, models.COLUMN_TIMESTAMP, days)
 	image := s.repos.Admin.GetStatistic(models.TABLE_IMAGE, models.COLUMN_TIMESTAMP, days)
+	view := s.repos.View.GetViewStatistic(0, days)
 	result := models.AdminDashboardStatisticResult{
 		Visit:  visit,
 		Member: member,
@@ -235,6 +325,7 @@ func (s *TsboardAdminService) GetDashboardStatistics(bunch uint) models.AdminDas
 		Reply:  reply,
 		File:   file,
 		Image:  image,
+		View:   view,
 	}
 	return result
 }
@@ -365,9 +456,83 @@ func (s *TsboardAdminService) GetUserInfo(userUid uint) models.AdminUserInfo {
 	return s.repos.Admin.GetUserInfo(userUid)
 }
 
-// 카테고리 삭제하기
+// 카테고리 이름, 설명, 글쓰기 레벨 수정하기
+func (s *TsboardAdminService) ModifyBoardCategory(param models.CategoryModifyParameter) error {
+	if cat := s.repos.Board.GetCategory(param.BoardUid, param.CategoryUid); cat.Uid < 1 {
+		return fmt.Errorf("category is not belong to this board")
+	}
+	if exist := s.repos.Board.GetCategoryUidByName(param.BoardUid, param.Name); exist > 0 && exist != param.CategoryUid {
+		return fmt.Errorf("category name already exists")
+	}
+	return s.repos.Admin.UpdateCategory(param)
+}
+
+// 게시판의 사용자 정의 필드 수정하기 (이미 입력된 값들이 있으므로 필드 타입은 변경 불가)
+func (s *TsboardAdminService) ModifyBoardField(boardUid uint, field models.BoardField) error {
+	for _, current := range s.repos.Field.GetFields(boardUid) {
+		if current.Uid != field.Uid {
+			continue
+		}
+		if current.Type != field.Type {
+			return fmt.Errorf("unable to change the type of field, remove it and add a new one instead")
+		}
+		return s.repos.Field.UpdateField(boardUid, field)
+	}
+	return fmt.Errorf("field is not belong to this board")
+}
+
+// 카테고리를 다른 상위 카테고리 아래로 옮기기 (parentUid 가 0 이면 최상위로)
+func (s *TsboardAdminService) MoveBoardCategory(boardUid uint, categoryUid uint, parentUid uint) error {
+	cats := s.repos.Board.GetBoardCategories(boardUid)
+	if depth := utils.GetCategoryDepth(cats, categoryUid); depth < 1 {
+		return fmt.Errorf("category is not belong to this board")
+	}
+
+	parentDepth := 0
+	if parentUid > 0 {
+		parentDepth = utils.GetCategoryDepth(cats, parentUid)
+		if parentDepth < 1 {
+			return fmt.Errorf("parent category is not belong to this board")
+		}
+		if slices.Contains(utils.GetCategoryFamily(cats, categoryUid), parentUid) {
+			return fmt.Errorf("unable to move a category under itself or its children")
+		}
+	}
+	if parentDepth+utils.GetCategoryHeight(cats, categoryUid) > models.CATEGORY_MAX_DEPTH {
+		return fmt.Errorf("categories can be nested up to %d levels", models.CATEGORY_MAX_DEPTH)
+	}
+
+	sortOrder := s.repos.Admin.GetNextCategoryOrder(boardUid, parentUid)
+	return s.repos.Admin.UpdateCategoryParent(categoryUid, parentUid, sortOrder)
+}
+
+// 기존 이미지들의 설명글을 다시 생성하도록 작업 추가하기 (postUid 가 0이면 게시판 전체)
+func (s *TsboardAdminService) RegenerateImageDescriptions(boardUid uint, postUid uint) (uint, error) {
+	if describer := utils.NewImageDescriber(); describer == nil {
+		return 0, fmt.Errorf("image describer is not configured")
+	}
+
+	var count uint
+	targets := s.repos.Admin.FindDescribeTargets(boardUid, postUid)
+	for _, target := range targets {
+		jobUid := s.repos.Job.InsertJob(models.JobInsertParameter{
+			Type:     models.JOB_IMAGE_DESCRIPTION,
+			BoardUid: boardUid,
+			PostUid:  target.PostUid,
+			FileUid:  target.FileUid,
+			Path:     target.Path,
+		})
+		if jobUid > 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// 카테고리 삭제하기 (하위 카테고리와 게시글들은 상위 카테고리로, 최상위였다면 첫번째 카테고리로 옮김)
 func (s *TsboardAdminService) RemoveBoardCategory(boardUid uint, catUid uint) error {
-	if isValid := s.repos.Admin.CheckCategoryInBoard(boardUid, catUid); !isValid {
+	cat := s.repos.Board.GetCategory(boardUid, catUid)
+	if cat.Uid < 1 {
 		return fmt.Errorf("category is not belong to this board")
 	}
 
@@ -375,16 +540,54 @@ func (s *TsboardAdminService) RemoveBoardCategory(boardUid uint, catUid uint) er
 	if err != nil {
 		return err
 	}
-	defCatUid := s.repos.Admin.GetLowestCategoryUid(boardUid)
+	if err := s.repos.Admin.UpdateChildCategories(boardUid, catUid, cat.ParentUid); err != nil {
+		return err
+	}
+	defCatUid := cat.ParentUid
+	if defCatUid < 1 {
+		defCatUid = s.repos.Admin.GetLowestCategoryUid(boardUid)
+	}
 	return s.repos.Admin.UpdatePostCategory(boardUid, catUid, defCatUid)
 }
 
+// 같은 상위 카테고리 아래의 카테고리 순서 바꾸기
+func (s *TsboardAdminService) ReorderBoardCategory(param models.CategoryReorderParameter) error {
+	siblings := make(map[uint]bool)
+	for _, cat := range s.repos.Board.GetBoardCategories(param.BoardUid) {
+		if cat.ParentUid == param.ParentUid {
+			siblings[cat.Uid] = true
+		}
+	}
+	if len(param.Order) != len(siblings) {
+		return fmt.Errorf("all categories under the same parent should be given")
+	}
+	for _, uid := range param.Order {
+		if !siblings[uid] {
+			return fmt.Errorf("category %d is not under the given parent", uid)
+		}
+		delete(siblings, uid)
+	}
+
+	for order, uid := range param.Order {
+		if err := s.repos.Admin.UpdateCategoryOrder(uid, uint(order)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 게시판의 사용자 정의 필드 삭제하기 (게시글들에 입력된 값들도 함께 삭제)
+func (s *TsboardAdminService) RemoveBoardField(boardUid uint, fieldUid uint) error {
+	return s.repos.Field.RemoveField(boardUid, fieldUid)
+}
+
 // 게시판 삭제하기
 func (s *TsboardAdminService) RemoveBoard(boardUid uint) error {
 	paths := s.repos.Admin.GetRemoveFilePaths(boardUid)
 	for _, path := range paths {
 		os.Remove("." + path)
 	}
+	s.repos.User.RemoveStorageUsages()
 
 	err := s.repos.Admin.RemoveBoardCategories(boardUid)
 	if err != nil {
@@ -406,8 +609,11 @@ func (s *TsboardAdminService) RemoveBoard(boardUid uint) error {
 }
 
 // 댓글 삭제하기
-func (s *TsboardAdminService) RemoveComment(commentUid uint) error {
-	return s.repos.Comment.RemoveComment(commentUid)
+func (s *TsboardAdminService) RemoveComment(commentUid uint, actionUserUid uint) error {
+	if trashUid := s.repos.Trash.MoveCommentToTrash(commentUid, actionUserUid, false); trashUid == models.FAILED {
+		return fmt.Errorf("unable to remove the comment")
+	}
+	return nil
 }
 
 // 그룹 삭제하기
@@ -425,8 +631,11 @@ func (s *TsboardAdminService) RemoveGroup(groupUid uint) error {
 }
 
 // 게시글 삭제하기
-func (s *TsboardAdminService) RemovePost(postUid uint) error {
-	return s.repos.BoardView.RemovePost(postUid)
+func (s *TsboardAdminService) RemovePost(postUid uint, actionUserUid uint) error {
+	if trashUid := s.repos.Trash.MovePostToTrash(postUid, actionUserUid); trashUid == models.FAILED {
+		return fmt.Errorf("unable to remove the post")
+	}
+	return nil
 }
 
 // 게시판 설정 변경하기
@@ -438,3 +647,11 @@ func (s *TsboardAdminService) UpdateBoardSetting(boardUid uint, column string, v
 func (s *TsboardAdminService) UpdateUserLevelPoint(userUid uint, level uint, point uint) error {
 	return s.repos.Admin.UpdateUserLevelPoint(userUid, level, point)
 }
+
+// 사용자의 저장 공간 한도 개별 지정 (혹은 기본값으로 되돌리기)
+func (s *TsboardAdminService) UpdateUserStorageQuota(userUid uint, quota uint64, useDefault bool) error {
+	if useDefault {
+		return s.repos.User.RemoveStorageQuota(userUid)
+	}
+	return s.repos.User.UpdateStorageQuota(userUid, quota)
+}
@@ -19,6 +19,7 @@ type AuthService interface {
 	CheckUserPermission(userUid uint, action models.UserAction) bool
 	GetMyInfo(userUid uint) models.MyInfoResult
 	GetUpdatedAccessToken(userUid uint, refreshToken string) (string, bool)
+	IsPasswordResetRequired(id string) bool
 	Logout(userUid uint)
 	ResetPassword(id string, hostname string) bool
 	Signin(id string, pw string) models.MyInfoResult
@@ -69,6 +70,15 @@ func (s *TsboardAuthService) GetUpdatedAccessToken(userUid uint, refreshToken st
 	return newAccessToken, true
 }
 
+// 다른 게시판에서 옮겨와 비밀번호를 다시 설정해야 하는 회원인지 확인하기
+func (s *TsboardAuthService) IsPasswordResetRequired(id string) bool {
+	userUid := s.repos.Auth.FindUserUidById(id)
+	if userUid < 1 {
+		return false
+	}
+	return s.repos.Import.IsPasswordResetRequired(userUid)
+}
+
 // 로그아웃하기
 func (s *TsboardAuthService) Logout(userUid uint) {
 	s.repos.Auth.ClearRefreshToken(userUid)
@@ -7,6 +7,7 @@ import (
 
 type BlogService interface {
 	GetLatestPosts(boardUid uint, bunch uint) ([]models.HomePostItem, error)
+	GetSeriesName(postUid uint) string
 }
 
 type TsboardBlogService struct {
@@ -29,4 +30,9 @@ func (s *TsboardBlogService) GetLatestPosts(boardUid uint, bunch uint) ([]models
 		UserUid: 0,
 		BoardUid: boardUid,
 	})
-}
\ No newline at end of file
+}
+
+// 게시글이 속한 시리즈 이름 반환하기
+func (s *TsboardBlogService) GetSeriesName(postUid uint) string {
+	return s.repos.Series.GetSeriesName(postUid)
+}
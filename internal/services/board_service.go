@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"mime/multipart"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/sirini/goapi/internal/configs"
 	"github.com/sirini/goapi/internal/repositories"
 	"github.com/sirini/goapi/pkg/models"
 	"github.com/sirini/goapi/pkg/utils"
@@ -16,6 +19,7 @@ type BoardService interface {
 	GetBoardUid(id string) uint
 	GetMaxUid() uint
 	GetBoardConfig(boardUid uint) models.BoardConfig
+	GetBoardFields(boardUid uint) []models.BoardField
 	GetBoardList(boardUid uint, userUid uint) ([]models.BoardItem, error)
 	GetEditorConfig(boardUid uint, userUid uint) models.EditorConfigResult
 	GetGalleryGridItem(param models.BoardListParameter) ([]models.GalleryGridItem, error)
@@ -23,20 +27,24 @@ type BoardService interface {
 	GetGalleryPhotos(boardUid uint, postUid uint, userUid uint) (models.GalleryPhotoResult, error)
 	GetInsertedImages(param models.EditorInsertImageParameter) (models.EditorInsertImageResult, error)
 	GetListItem(param models.BoardListParameter) (models.BoardListResult, error)
+	GetRelatedPosts(param models.BoardViewParameter) ([]models.BoardRelatedPost, error)
+	GetRevisionDiff(boardUid uint, postUid uint, fromUid uint, toUid uint, userUid uint) (models.RevisionDiffResult, error)
+	GetRevisions(boardUid uint, postUid uint, userUid uint) ([]models.RevisionListItem, error)
 	GetSuggestionTags(input string, bunch uint) []models.EditorTagItem
 	GetViewItem(param models.BoardViewParameter) (models.BoardViewResult, error)
 	LikeThisPost(param models.BoardViewLikeParameter)
 	LoadPost(boardUid uint, postUid uint, userUid uint) (models.EditorLoadPostResult, error)
 	MovePost(param models.BoardMovePostParameter)
-	ModifyPost(param models.EditorModifyParameter) error
+	ModifyPost(param models.EditorModifyParameter) (models.MentionParameter, error)
 	RemoveAttachedFile(param models.EditorRemoveAttachedParameter)
 	RemoveInsertedImage(imageUid uint, userUid uint)
 	RemovePost(boardUid uint, postUid uint, userUid uint)
-	SaveAttachments(boardUid uint, postUid uint, files []*multipart.FileHeader)
+	RestoreRevision(boardUid uint, postUid uint, revisionUid uint, userUid uint) error
+	SaveAttachments(boardUid uint, postUid uint, files []*multipart.FileHeader, keepLocation bool)
 	SaveTags(boardUid uint, postUid uint, tags []string)
 	SaveThumbnail(fileUid uint, postUid uint, path string) models.BoardThumbnail
-	UploadInsertImage(boardUid uint, userUid uint, images []*multipart.FileHeader) ([]string, error)
-	WritePost(param models.EditorWriteParameter) (uint, error)
+	UploadInsertImage(boardUid uint, userUid uint, draftUid uint, images []*multipart.FileHeader) ([]string, error)
+	WritePost(param models.EditorWriteParameter) (models.MentionParameter, error)
 }
 
 type TsboardBoardService struct {
@@ -91,6 +99,11 @@ func (s *TsboardBoardService) GetBoardConfig(boardUid uint) models.BoardConfig {
 	return s.repos.Board.GetBoardConfig(boardUid)
 }
 
+// 게시판에 정의된 사용자 정의 필드 목록 가져오기
+func (s *TsboardBoardService) GetBoardFields(boardUid uint) []models.BoardField {
+	return s.repos.Field.GetFields(boardUid)
+}
+
 // 게시글 이동할 대상 게시판 목록 가져오기
 func (s *TsboardBoardService) GetBoardList(boardUid uint, userUid uint) ([]models.BoardItem, error) {
 	if isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid); !isAdmin {
@@ -100,12 +113,20 @@ func (s *TsboardBoardService) GetBoardList(boardUid uint, userUid uint) ([]model
 	return boards, nil
 }
 
-// 게시판 설정 및 카테고리, 관리자 여부 반환
+// 게시판 설정 및 카테고리, 관리자 여부 반환 (글쓰기 레벨이 부족한 카테고리는 제외)
 func (s *TsboardBoardService) GetEditorConfig(boardUid uint, userUid uint) models.EditorConfigResult {
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+	userLv, _ := s.repos.User.GetUserLevelPoint(userUid)
+	cats := make([]models.BoardCategory, 0)
+	for _, cat := range s.repos.Board.GetBoardCategories(boardUid) {
+		if isAdmin || userLv >= cat.LevelWrite {
+			cats = append(cats, cat)
+		}
+	}
 	return models.EditorConfigResult{
 		Config:     s.repos.Board.GetBoardConfig(boardUid),
-		IsAdmin:    s.repos.Auth.CheckPermissionByUid(userUid, boardUid),
-		Categories: s.repos.Board.GetBoardCategories(boardUid),
+		IsAdmin:    isAdmin,
+		Categories: cats,
 	}
 }
 
@@ -121,8 +142,7 @@ func (s *TsboardBoardService) GetGalleryGridItem(param models.BoardListParameter
 		switch param.Option {
 		case models.SEARCH_TAG:
 			posts, err = s.repos.Board.FindPostsByHashtag(param)
-		case models.SEARCH_CATEGORY:
-		case models.SEARCH_WRITER:
+		case models.SEARCH_CATEGORY, models.SEARCH_WRITER:
 			posts, err = s.repos.Board.FindPostsByNameCategory(param)
 		default:
 			posts, err = s.repos.Board.FindPostsByTitleContent(param)
@@ -225,8 +245,7 @@ func (s *TsboardBoardService) GetListItem(param models.BoardListParameter) (mode
 		switch param.Option {
 		case models.SEARCH_TAG:
 			items, err = s.repos.Board.FindPostsByHashtag(param)
-		case models.SEARCH_CATEGORY:
-		case models.SEARCH_WRITER:
+		case models.SEARCH_CATEGORY, models.SEARCH_WRITER:
 			items, err = s.repos.Board.FindPostsByNameCategory(param)
 		default:
 			items, err = s.repos.Board.FindPostsByTitleContent(param)
@@ -243,6 +262,7 @@ func (s *TsboardBoardService) GetListItem(param models.BoardListParameter) (mode
 		Posts:          items,
 		BlackList:      s.repos.User.GetUserBlackList(param.UserUid),
 		IsAdmin:        s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid),
+		Fields:         s.repos.Field.GetFields(param.BoardUid),
 	}
 	return result, nil
 }
@@ -252,6 +272,28 @@ func (s *TsboardBoardService) GetSuggestionTags(input string, bunch uint) []mode
 	return s.repos.BoardEdit.GetSuggestionTags(input, bunch)
 }
 
+// 게시글과 해시태그, 카테고리 등이 겹치는 관련 글들 가져오기
+func (s *TsboardBoardService) GetRelatedPosts(param models.BoardViewParameter) ([]models.BoardRelatedPost, error) {
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_VIEW)
+	if userLv < needLv {
+		return nil, fmt.Errorf("level restriction")
+	}
+
+	post, err := s.repos.BoardView.GetPost(param.PostUid, param.UserUid)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status == models.CONTENT_SECRET || post.Status == models.CONTENT_PENDING {
+		isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
+		if !isAdmin && post.Writer.UserUid != param.UserUid {
+			return nil, fmt.Errorf("you have no permission to see this post")
+		}
+	}
+	tags := s.repos.BoardView.GetTags(param.PostUid)
+	return s.findRelatedPosts(param.BoardUid, post, tags, userLv, param.Limit), nil
+}
+
 // 게시글 가져오기
 func (s *TsboardBoardService) GetViewItem(param models.BoardViewParameter) (models.BoardViewResult, error) {
 	result := models.BoardViewResult{}
@@ -280,6 +322,13 @@ func (s *TsboardBoardService) GetViewItem(param models.BoardViewParameter) (mode
 	if err != nil {
 		return result, err
 	}
+	if post.Status == models.CONTENT_PENDING {
+		isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
+		isWriter := post.Writer.UserUid == param.UserUid
+		if !isAdmin && !isWriter {
+			return result, fmt.Errorf("post has not been published yet")
+		}
+	}
 
 	config := s.repos.Board.GetBoardConfig(param.BoardUid)
 	result.Config = config
@@ -301,10 +350,6 @@ func (s *TsboardBoardService) GetViewItem(param models.BoardViewParameter) (mode
 	}
 	result.Images = images
 
-	if param.UpdateHit {
-		s.repos.BoardView.UpdatePostHit(param.PostUid)
-	}
-
 	if post.Status == models.CONTENT_SECRET {
 		isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
 		isWriter := post.Writer.UserUid == param.UserUid
@@ -317,25 +362,71 @@ func (s *TsboardBoardService) GetViewItem(param models.BoardViewParameter) (mode
 	}
 
 	result.Tags = s.repos.BoardView.GetTags(param.PostUid)
+	result.Bookmarked = s.repos.Bookmark.IsBookmarked(param.PostUid, param.UserUid)
 	result.PrevPostUid = s.repos.BoardView.GetPrevPostUid(param.BoardUid, param.PostUid)
 	result.NextPostUid = s.repos.BoardView.GetNextPostUid(param.BoardUid, param.PostUid)
+	result.Series = s.repos.Series.GetSeriesNavigation(param.PostUid)
+	result.Previews = s.repos.Unfurl.FindPreviews(utils.ExtractUrls(result.Post.Content))
 	result.WriterPosts, _ = s.repos.BoardView.GetWriterLatestPost(post.Writer.UserUid, param.Limit)
 	result.WriterComments, _ = s.repos.BoardView.GetWriterLatestComment(post.Writer.UserUid, param.Limit)
+	result.RelatedPosts = s.findRelatedPosts(param.BoardUid, post, result.Tags, userLv, param.Limit)
 	return result, nil
 }
 
+// 볼 수 있는 게시판의 관련 글들을 점수가 높은 순서로 찾기
+func (s *TsboardBoardService) findRelatedPosts(boardUid uint, post models.BoardListItem, tags []models.Pair, userLv int, limit uint) []models.BoardRelatedPost {
+	if limit > models.RELATED_MAX_LIMIT {
+		limit = models.RELATED_MAX_LIMIT
+	}
+	tagUids := make([]uint, 0)
+	for _, tag := range tags {
+		tagUids = append(tagUids, tag.Uid)
+	}
+
+	items, err := s.repos.BoardView.GetRelatedPosts(models.BoardRelatedParameter{
+		PostUid:     post.Uid,
+		BoardUid:    boardUid,
+		CategoryUid: post.Category.Uid,
+		TagUids:     tagUids,
+		Title:       post.Title,
+		UserLevel:   userLv,
+		UseFulltext: configs.Env.RelatedFulltext == "true",
+		Limit:       limit,
+	})
+	if err != nil {
+		return make([]models.BoardRelatedPost, 0)
+	}
+	return items
+}
+
 // 글 작성자에게 차단당했는지 확인
 func (s *TsboardBoardService) IsBannedByWriter(postUid uint, viewerUid uint) bool {
 	return s.repos.BoardView.CheckBannedByWriter(postUid, viewerUid)
 }
 
-// 게시글에 좋아요 클릭
+// 게시글에 좋아요 클릭 (기본 반응 👍 에도 반영)
 func (s *TsboardBoardService) LikeThisPost(param models.BoardViewLikeParameter) {
 	if isLiked := s.repos.BoardView.IsLikedPost(param.PostUid, param.UserUid); isLiked {
 		s.repos.BoardView.UpdateLikePost(param)
 	} else {
 		s.repos.BoardView.InsertLikePost(param)
 	}
+
+	reaction := models.ReactionParameter{
+		BoardUid:  param.BoardUid,
+		TargetUid: param.PostUid,
+		UserUid:   param.UserUid,
+		Table:     models.TABLE_POST_REACTION,
+		Emoji:     models.REACTION_LIKE,
+	}
+	if !param.Liked {
+		s.repos.Reaction.RemoveReaction(reaction)
+		return
+	}
+	if opt, _ := s.repos.Board.GetReactionOption(param.BoardUid); !opt.Multiple {
+		s.repos.Reaction.RemoveUserReactions(reaction.Table, reaction.TargetUid, reaction.UserUid)
+	}
+	s.repos.Reaction.InsertReaction(reaction)
 }
 
 // 게시글 수정 시 기존 정보들 가져오기
@@ -357,9 +448,14 @@ func (s *TsboardBoardService) LoadPost(boardUid uint, postUid uint, userUid uint
 	}
 	tags := s.repos.BoardView.GetTags(postUid)
 
+	format, source := s.repos.BoardEdit.GetPostSource(postUid)
 	result.Post = post
+	result.Format = format.String()
+	result.Source = source
 	result.Files = files
 	result.Tags = tags
+	result.Schedule = s.repos.BoardEdit.GetPostSchedule(postUid)
+	result.Fields = post.Fields
 	return result, nil
 }
 
@@ -369,18 +465,23 @@ func (s *TsboardBoardService) MovePost(param models.BoardMovePostParameter) {
 		return
 	}
 	s.repos.BoardView.UpdatePostBoardUid(param.TargetBoardUid, param.PostUid)
+	s.repos.Series.RemoveSeriesPost(param.PostUid)
+	s.repos.Field.RemoveFieldValues(param.PostUid)
 }
 
-// 게시글 수정하기
-func (s *TsboardBoardService) ModifyPost(param models.EditorModifyParameter) error {
+// 게시글 수정하기 (스팸 필터를 거친 본문으로 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardBoardService) ModifyPost(param models.EditorModifyParameter) (models.MentionParameter, error) {
 	isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
 	isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, param.PostUid, param.UserUid)
 	if !isAdmin && !isAuthor {
-		return fmt.Errorf("only the author can edit this post")
+		return models.MentionParameter{}, fmt.Errorf("only the author can edit this post")
+	}
+	if status := s.repos.Comment.GetPostStatus(param.PostUid); status == models.CONTENT_REMOVED {
+		return models.MentionParameter{}, fmt.Errorf("unable to edit a removed post, restore it first")
 	}
 
 	if hasPerm := s.repos.Auth.CheckPermissionForAction(param.UserUid, models.USER_ACTION_WRITE_POST); !hasPerm {
-		return fmt.Errorf("you have no permission to edit post")
+		return models.MentionParameter{}, fmt.Errorf("you have no permission to edit post")
 	}
 
 	if param.IsNotice {
@@ -388,10 +489,156 @@ func (s *TsboardBoardService) ModifyPost(param models.EditorModifyParameter) err
 			param.IsNotice = false
 		}
 	}
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	if err := s.checkCategoryLevel(param.BoardUid, param.CategoryUid, param.UserUid, userLv); err != nil {
+		return models.MentionParameter{}, err
+	}
+
+	spam := models.SpamCheckParameter{
+		Target:    models.SPAM_TARGET_POST,
+		TargetUid: param.PostUid,
+		BoardUid:  param.BoardUid,
+		UserUid:   param.UserUid,
+		Title:     param.Title,
+		Content:   param.Content,
+		Source:    param.Source,
+	}
+	verdict := filterSpam(s.repos, spam)
+	s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, param.PostUid)
+	if verdict.Action == models.SPAM_ACTION_REJECT {
+		return models.MentionParameter{}, fmt.Errorf("your post has been blocked by spam filter")
+	}
+	param.Title, param.Content, param.Source = verdict.Title, verdict.Content, verdict.Source
+
+	if count := s.repos.Revision.GetRevisionCount(param.PostUid); count < 1 {
+		post, err := s.repos.BoardView.GetPost(param.PostUid, param.UserUid)
+		if err == nil {
+			s.saveRevision(param.BoardUid, param.PostUid, post.Writer.UserUid, 0, int64(max(post.Submitted, post.Modified)))
+		}
+	}
+
+	param.IsPending = verdict.Action == models.SPAM_ACTION_MODERATE || s.repos.Moderation.IsWaiting(param.PostUid, 0)
 	s.repos.BoardView.RemovePostTags(param.PostUid)
 	s.repos.BoardEdit.UpdatePost(param)
+	if param.IsPending {
+		s.repos.Moderation.InsertModeration(param.BoardUid, param.PostUid, 0, param.UserUid)
+	}
+	s.repos.Field.UpdateFieldValues(param.PostUid, param.Fields)
 	s.SaveTags(param.BoardUid, param.PostUid, param.Tags)
-	s.SaveAttachments(param.BoardUid, param.PostUid, param.Files)
+	s.SaveAttachments(param.BoardUid, param.PostUid, param.Files, param.KeepLocation)
+	s.saveRevision(param.BoardUid, param.PostUid, param.UserUid, 0, time.Now().UnixMilli())
+	return models.MentionParameter{
+		BoardUid: param.BoardUid,
+		PostUid:  param.PostUid,
+		UserUid:  param.UserUid,
+		Content:  param.Content,
+	}, nil
+}
+
+// 글을 쓰려는 카테고리가 게시판에 속해 있고 카테고리의 글쓰기 레벨을 충족하는지 확인
+func (s *TsboardBoardService) checkCategoryLevel(boardUid uint, categoryUid uint, userUid uint, userLv int) error {
+	if categoryUid < 1 {
+		return nil
+	}
+	cat := s.repos.Board.GetCategory(boardUid, categoryUid)
+	if cat.Uid < 1 {
+		return fmt.Errorf("invalid category")
+	}
+	if userLv < cat.LevelWrite && !s.repos.Auth.CheckPermissionByUid(userUid, boardUid) {
+		return fmt.Errorf("level restriction for this category")
+	}
+	return nil
+}
+
+// 게시글의 현재 제목, 내용, 태그를 수정 이력으로 남기기 (마크다운 글은 원문을 남김)
+func (s *TsboardBoardService) saveRevision(boardUid uint, postUid uint, editorUid uint, restoredFrom uint, created int64) {
+	post, err := s.repos.BoardView.GetPost(postUid, editorUid)
+	if err != nil {
+		return
+	}
+	format, source := s.repos.BoardEdit.GetPostSource(postUid)
+	if format == models.FORMAT_MARKDOWN {
+		post.Content = source
+	}
+	tags := make([]string, 0)
+	for _, tag := range s.repos.BoardView.GetTags(postUid) {
+		tags = append(tags, tag.Name)
+	}
+	s.repos.Revision.InsertRevision(models.RevisionInsertParameter{
+		PostUid:      postUid,
+		BoardUid:     boardUid,
+		EditorUid:    editorUid,
+		Title:        post.Title,
+		Content:      post.Content,
+		Format:       format,
+		Tags:         utils.CutString(strings.Join(tags, ","), 300),
+		Created:      created,
+		RestoredFrom: restoredFrom,
+	})
+}
+
+// 게시글 수정 이력 목록 가져오기 (작성자 혹은 관리자만 가능)
+func (s *TsboardBoardService) GetRevisions(boardUid uint, postUid uint, userUid uint) ([]models.RevisionListItem, error) {
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+	isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, userUid)
+	if !isAdmin && !isAuthor {
+		return nil, fmt.Errorf("only the author or admin can see revisions")
+	}
+	return s.repos.Revision.GetRevisionList(postUid), nil
+}
+
+// 두 수정 이력 간의 차이 비교하기 (작성자 혹은 관리자만 가능)
+func (s *TsboardBoardService) GetRevisionDiff(boardUid uint, postUid uint, fromUid uint, toUid uint, userUid uint) (models.RevisionDiffResult, error) {
+	result := models.RevisionDiffResult{}
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+	isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, userUid)
+	if !isAdmin && !isAuthor {
+		return result, fmt.Errorf("only the author or admin can see revisions")
+	}
+
+	from, err := s.repos.Revision.GetRevision(postUid, fromUid)
+	if err != nil {
+		return result, fmt.Errorf("unable to find the revision to compare from")
+	}
+	to, err := s.repos.Revision.GetRevision(postUid, toUid)
+	if err != nil {
+		return result, fmt.Errorf("unable to find the revision to compare to")
+	}
+
+	result.From = models.RevisionListItem{
+		Uid: from.Uid, Editor: from.Editor, Title: from.Title, Created: from.Created, RestoredFrom: from.RestoredFrom,
+	}
+	result.To = models.RevisionListItem{
+		Uid: to.Uid, Editor: to.Editor, Title: to.Title, Created: to.Created, RestoredFrom: to.RestoredFrom,
+	}
+	result.Title = utils.DiffLines([]string{from.Title}, []string{to.Title})
+	result.Content = utils.DiffLines(utils.SplitContentLines(from.Content), utils.SplitContentLines(to.Content))
+	result.Tags = utils.DiffLines(utils.SplitTags(from.Tags), utils.SplitTags(to.Tags))
+	return result, nil
+}
+
+// 이전 수정 이력으로 게시글 복원하기 (작성자 혹은 관리자만 가능, 복원도 새 이력으로 남김)
+func (s *TsboardBoardService) RestoreRevision(boardUid uint, postUid uint, revisionUid uint, userUid uint) error {
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+	isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, userUid)
+	if !isAdmin && !isAuthor {
+		return fmt.Errorf("only the author or admin can restore this post")
+	}
+
+	revision, err := s.repos.Revision.GetRevision(postUid, revisionUid)
+	if err != nil {
+		return fmt.Errorf("unable to find the revision")
+	}
+
+	content, format, source := revision.Content, models.FORMAT_HTML, ""
+	if revision.Format == models.FORMAT_MARKDOWN.String() {
+		content, format, source = utils.RenderMarkdown(revision.Content), models.FORMAT_MARKDOWN, revision.Content
+	}
+
+	s.repos.BoardView.RemovePostTags(postUid)
+	s.repos.BoardEdit.UpdatePostContent(postUid, revision.Title, content, format, source)
+	s.SaveTags(boardUid, postUid, utils.SplitTags(revision.Tags))
+	s.saveRevision(boardUid, postUid, userUid, revisionUid, time.Now().UnixMilli())
 	return nil
 }
 
@@ -405,6 +652,7 @@ func (s *TsboardBoardService) RemoveAttachedFile(param models.EditorRemoveAttach
 
 	filePath := s.repos.BoardEdit.FindAttachedPathByUid(param.FileUid)
 	removes := s.repos.BoardView.RemoveAttachedFile(param.FileUid, filePath)
+	changePostStorageUsage(s.repos, param.PostUid, removes, false)
 
 	for _, target := range removes {
 		os.Remove("." + target)
@@ -415,6 +663,7 @@ func (s *TsboardBoardService) RemoveAttachedFile(param models.EditorRemoveAttach
 func (s *TsboardBoardService) RemoveInsertedImage(imageUid uint, userUid uint) {
 	removePath := s.repos.BoardEdit.RemoveInsertedImage(imageUid, userUid)
 	if len(removePath) > 0 {
+		changeStorageUsage(s.repos, userUid, models.STORAGE_IMAGE, []string{removePath}, false)
 		os.Remove("." + removePath)
 	}
 }
@@ -427,75 +676,92 @@ func (s *TsboardBoardService) RemovePost(boardUid uint, postUid uint, userUid ui
 		return
 	}
 
-	s.repos.BoardView.RemovePost(postUid)
-	s.repos.BoardView.RemoveComments(postUid)
-	s.repos.BoardView.RemovePostTags(postUid)
-	removes := s.repos.BoardView.RemoveAttachments(postUid)
+	s.repos.Trash.MovePostToTrash(postUid, userUid)
+}
 
-	for _, path := range removes {
-		_ = os.Remove("." + path)
+// 첨부파일들을 저장하기 (썸네일, 설명글은 백그라운드 작업으로 처리)
+func (s *TsboardBoardService) SaveAttachments(boardUid uint, postUid uint, files []*multipart.FileHeader, keepLocation bool) {
+	for _, file := range files {
+		savedPath, err := utils.SaveAttachmentFile(file)
+		if err != nil {
+			continue
+		}
+		s.registerAttachment(boardUid, postUid, file.Filename, savedPath, keepLocation)
 	}
 }
 
-// 첨부파일들을 저장하기
-func (s *TsboardBoardService) SaveAttachments(boardUid uint, postUid uint, files []*multipart.FileHeader) {
-	var wg sync.WaitGroup
-	for _, file := range files {
-		wg.Add(1)
+// 저장된 첨부파일을 게시글에 등록하기 (이미지는 EXIF 추출 후 민감한 메타데이터 제거)
+func (s *TsboardBoardService) registerAttachment(boardUid uint, postUid uint, name string, savedPath string, keepLocation bool) {
+	isImage := utils.IsImage(name)
+	exif := models.BoardExif{}
+	if isImage {
+		exif = utils.ExtractExif(savedPath)
+		if !keepLocation {
+			exif.Latitude, exif.Longitude = 0, 0
+		}
+		utils.StripImageMetadata(savedPath)
+	}
 
-		go func(f *multipart.FileHeader) {
-			defer wg.Done()
+	fileUid := s.repos.BoardEdit.InsertFile(models.EditorSaveFileParameter{
+		BoardUid: boardUid,
+		PostUid:  postUid,
+		Name:     utils.CutString(name, 100),
+		Path:     savedPath[1:],
+	})
+	changePostStorageUsage(s.repos, postUid, []string{savedPath[1:]}, true)
+
+	if isImage {
+		s.repos.BoardEdit.InsertExif(fileUid, postUid, exif)
+		s.repos.Job.InsertJob(models.JobInsertParameter{
+			Type:     models.JOB_THUMBNAIL,
+			BoardUid: boardUid,
+			PostUid:  postUid,
+			FileUid:  fileUid,
+			Path:     savedPath[1:],
+		})
+	}
+}
 
-			savedPath, err := utils.SaveAttachmentFile(f)
-			if err != nil {
-				return
-			}
-			fileUid := s.repos.BoardEdit.InsertFile(models.EditorSaveFileParameter{
-				BoardUid: boardUid,
-				PostUid:  postUid,
-				Name:     utils.CutString(f.Filename, 100),
-				Path:     savedPath[1:],
-			})
-
-			if utils.IsImage(f.Filename) {
-				thumb, err := utils.SaveThumbnailImage(savedPath)
-				if err != nil {
-					return
-				}
-				s.repos.BoardEdit.InsertFileThumbnail(models.EditorSaveThumbnailParameter{
-					BoardThumbnail: models.BoardThumbnail{
-						Large: thumb.Large[1:],
-						Small: thumb.Small[1:],
-					},
-					FileUid: fileUid,
-					PostUid: postUid,
-				})
-				exif := utils.ExtractExif(savedPath)
-				s.repos.BoardEdit.InsertExif(fileUid, postUid, exif)
-				if imgDesc, err := utils.AskImageDescription(thumb.Small); err == nil {
-					s.repos.BoardEdit.InsertImageDescription(fileUid, postUid, imgDesc)
-				}
-			}
-		}(file)
+// 임시저장 글에 미리 올려둔 첨부파일들을 새 게시글로 옮기고 임시저장 글 삭제하기
+func (s *TsboardBoardService) publishDraft(param models.EditorWriteParameter, postUid uint) {
+	draft, err := s.repos.Draft.GetDraft(param.DraftUid, param.UserUid)
+	if err != nil || draft.BoardUid != param.BoardUid {
+		return
 	}
-	wg.Wait()
+	paths := make([]string, 0, len(draft.Files))
+	for _, file := range draft.Files {
+		s.registerAttachment(param.BoardUid, postUid, file.Name, "."+file.Path, param.KeepLocation)
+		paths = append(paths, file.Path)
+	}
+	changeStorageUsage(s.repos, param.UserUid, models.STORAGE_ATTACHMENT, paths, false)
+	s.repos.Draft.PublishStagedImages(param.DraftUid)
+	s.repos.Draft.RemoveDraft(param.DraftUid)
 }
 
 // 해시태그들 저장하기
 func (s *TsboardBoardService) SaveTags(boardUid uint, postUid uint, tags []string) {
+	saved := make(map[uint]bool)
 	for _, tag := range tags {
 		tidyTag := utils.Purify(tag)
 		if len(tidyTag) < 2 {
 			continue
 		}
 
-		hashtagUid := s.repos.BoardEdit.FindTagUidByName(tag)
+		hashtag := s.repos.Hashtag.GetHashtagByName(tag)
+		if hashtag.Blocked || saved[hashtag.Uid] {
+			continue
+		}
+		hashtagUid := hashtag.Uid
 		if hashtagUid > 0 {
 			s.repos.BoardEdit.UpdateTag(hashtagUid)
 		} else {
-			hashtagUid = s.repos.BoardEdit.InsertTag(boardUid, postUid, tag)
+			hashtagUid = s.repos.BoardEdit.InsertTag(tag)
+			if hashtagUid == models.FAILED {
+				continue
+			}
 		}
 		s.repos.BoardEdit.InsertPostHashtag(boardUid, postUid, hashtagUid)
+		saved[hashtagUid] = true
 	}
 }
 
@@ -513,11 +779,12 @@ func (s *TsboardBoardService) SaveThumbnail(fileUid uint, postUid uint, path str
 		FileUid: fileUid,
 		PostUid: postUid,
 	})
+	changePostStorageUsage(s.repos, postUid, []string{thumb.Small[1:], thumb.Large[1:]}, true)
 	return thumb
 }
 
-// 게시글에 삽입할 이미지 파일 업로드 처리하기
-func (s *TsboardBoardService) UploadInsertImage(boardUid uint, userUid uint, images []*multipart.FileHeader) ([]string, error) {
+// 게시글에 삽입할 이미지 파일 업로드 처리하기 (draftUid 가 있으면 임시저장 글에 딸린 이미지로 저장)
+func (s *TsboardBoardService) UploadInsertImage(boardUid uint, userUid uint, draftUid uint, images []*multipart.FileHeader) ([]string, error) {
 	imagePaths := make([]string, 0)
 	if hasPerm := s.repos.Auth.CheckPermissionForAction(userUid, models.USER_ACTION_WRITE_POST); !hasPerm {
 		return imagePaths, fmt.Errorf("you have no permission to write a new post")
@@ -535,6 +802,11 @@ func (s *TsboardBoardService) UploadInsertImage(boardUid uint, userUid uint, ima
 	if needPt < 0 && userPt < utils.Abs(needPt) {
 		return imagePaths, fmt.Errorf("not enough point")
 	}
+	if draftUid > 0 {
+		if _, _, isMine := s.repos.Draft.GetDraftVersion(draftUid, userUid); !isMine {
+			return imagePaths, fmt.Errorf("unable to find the draft")
+		}
+	}
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -588,7 +860,8 @@ func (s *TsboardBoardService) UploadInsertImage(boardUid uint, userUid uint, ima
 		return imagePaths, errors[0]
 	}
 
-	s.repos.BoardEdit.InsertImagePaths(boardUid, userUid, imagePaths)
+	s.repos.BoardEdit.InsertImagePaths(boardUid, userUid, draftUid, imagePaths)
+	changeStorageUsage(s.repos, userUid, models.STORAGE_IMAGE, imagePaths, true)
 
 	for _, tempPath := range tempPaths {
 		os.Remove(tempPath)
@@ -596,23 +869,41 @@ func (s *TsboardBoardService) UploadInsertImage(boardUid uint, userUid uint, ima
 	return imagePaths, nil
 }
 
-// 새 게시글 작성하기
-func (s *TsboardBoardService) WritePost(param models.EditorWriteParameter) (uint, error) {
+// 새 게시글 작성하기 (스팸 필터를 거친 본문으로 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardBoardService) WritePost(param models.EditorWriteParameter) (models.MentionParameter, error) {
 	if hasPerm := s.repos.Auth.CheckPermissionForAction(param.UserUid, models.USER_ACTION_WRITE_POST); !hasPerm {
-		return models.FAILED, fmt.Errorf("you have no permission to write a new post")
+		return models.MentionParameter{}, fmt.Errorf("you have no permission to write a new post")
 	}
 	if hasPerm := s.repos.BoardEdit.CheckWriterForBlog(param.BoardUid, param.UserUid); !hasPerm {
-		return models.FAILED, fmt.Errorf("only blog owner can write a new post")
+		return models.MentionParameter{}, fmt.Errorf("only blog owner can write a new post")
 	}
 
 	userLv, userPt := s.repos.User.GetUserLevelPoint(param.UserUid)
 	needLv, needPt := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_WRITE)
 	if userLv < needLv {
-		return models.FAILED, fmt.Errorf("level restriction")
+		return models.MentionParameter{}, fmt.Errorf("level restriction")
 	}
 	if needPt < 0 && userPt < utils.Abs(needPt) {
-		return models.FAILED, fmt.Errorf("not enough point")
+		return models.MentionParameter{}, fmt.Errorf("not enough point")
+	}
+	if err := s.checkCategoryLevel(param.BoardUid, param.CategoryUid, param.UserUid, userLv); err != nil {
+		return models.MentionParameter{}, err
+	}
+
+	spam := models.SpamCheckParameter{
+		Target:   models.SPAM_TARGET_POST,
+		BoardUid: param.BoardUid,
+		UserUid:  param.UserUid,
+		Title:    param.Title,
+		Content:  param.Content,
+		Source:   param.Source,
 	}
+	verdict := filterSpam(s.repos, spam)
+	if verdict.Action == models.SPAM_ACTION_REJECT {
+		s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, 0)
+		return models.MentionParameter{}, fmt.Errorf("your post has been blocked by spam filter")
+	}
+	param.Title, param.Content, param.Source = verdict.Title, verdict.Content, verdict.Source
 	s.repos.User.UpdateUserPoint(param.UserUid, uint(userPt+needPt))
 
 	if param.IsNotice {
@@ -621,9 +912,31 @@ func (s *TsboardBoardService) WritePost(param models.EditorWriteParameter) (uint
 		}
 	}
 
+	param.IsPending = verdict.Action == models.SPAM_ACTION_MODERATE
+	opt := s.repos.Moderation.GetModerationOption(param.BoardUid)
+	if !param.IsPending && opt.Mode != models.MODERATION_OFF && !s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid) {
+		param.IsPending = utils.NeedModeration(opt, userLv, s.repos.Moderation.GetPublishedCount(param.BoardUid, param.UserUid))
+	}
+
 	postUid := s.repos.BoardEdit.InsertPost(param)
+	s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, postUid)
+	if param.IsPending {
+		s.repos.Moderation.InsertModeration(param.BoardUid, postUid, 0, param.UserUid)
+	}
+	if len(param.Poll.Options) > 0 {
+		s.repos.Poll.InsertPoll(param.BoardUid, postUid, param.UserUid, param.Poll)
+	}
+	s.repos.Field.UpdateFieldValues(postUid, param.Fields)
 	s.SaveTags(param.BoardUid, postUid, param.Tags)
-	s.SaveAttachments(param.BoardUid, postUid, param.Files)
+	s.SaveAttachments(param.BoardUid, postUid, param.Files, param.KeepLocation)
+	if param.DraftUid > 0 {
+		s.publishDraft(param, postUid)
+	}
 
-	return postUid, nil
+	return models.MentionParameter{
+		BoardUid: param.BoardUid,
+		PostUid:  postUid,
+		UserUid:  param.UserUid,
+		Content:  param.Content,
+	}, nil
 }
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type BookmarkService interface {
+	AddBookmark(param models.BookmarkParameter) error
+	GetBookmarkFolders(userUid uint) []models.BookmarkFolder
+	GetBookmarks(param models.BookmarkListParameter) models.BookmarkListResult
+	RemoveBookmark(postUid uint, userUid uint) error
+}
+
+type TsboardBookmarkService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardBookmarkService(repos *repositories.Repository) *TsboardBookmarkService {
+	return &TsboardBookmarkService{repos: repos}
+}
+
+// 게시글을 북마크에 추가하기 (이미 추가한 글이면 폴더와 메모 변경)
+func (s *TsboardBookmarkService) AddBookmark(param models.BookmarkParameter) error {
+	if param.UserUid < 1 {
+		return fmt.Errorf("please log in to bookmark")
+	}
+	if boardUid := s.repos.Admin.FindBoardUidByPostUid(param.PostUid); boardUid != param.BoardUid {
+		return fmt.Errorf("unable to find the post")
+	}
+
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_VIEW)
+	if userLv < needLv {
+		return fmt.Errorf("level restriction")
+	}
+
+	post, err := s.repos.BoardView.GetPost(param.PostUid, param.UserUid)
+	if err != nil {
+		return fmt.Errorf("unable to find the post")
+	}
+	if post.Status == models.CONTENT_PENDING && post.Writer.UserUid != param.UserUid {
+		return fmt.Errorf("post has not been published yet")
+	}
+	return s.repos.Bookmark.InsertBookmark(param)
+}
+
+// 북마크 폴더 목록 가져오기
+func (s *TsboardBookmarkService) GetBookmarkFolders(userUid uint) []models.BookmarkFolder {
+	return s.repos.Bookmark.GetBookmarkFolders(userUid)
+}
+
+// 북마크 목록 가져오기 (볼 수 없는 글은 제외, 비밀글 내용은 작성자와 관리자에게만 보여줌)
+func (s *TsboardBookmarkService) GetBookmarks(param models.BookmarkListParameter) models.BookmarkListResult {
+	result := models.BookmarkListResult{Items: make([]models.BookmarkItem, 0)}
+	param.UserLevel, _ = s.repos.User.GetUserLevelPoint(param.UserUid)
+	result.Total = s.repos.Bookmark.GetBookmarkCount(param)
+
+	for _, record := range s.repos.Bookmark.GetBookmarks(param) {
+		post, err := s.repos.BoardView.GetPost(record.PostUid, param.UserUid)
+		if err != nil {
+			continue
+		}
+		if post.Status == models.CONTENT_SECRET && post.Writer.UserUid != param.UserUid {
+			if isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, record.BoardUid); !isAdmin {
+				post.Content = ""
+			}
+		}
+		result.Items = append(result.Items, models.BookmarkItem{
+			Uid:       record.Uid,
+			Board:     s.repos.BoardView.GetBasicBoardConfig(record.BoardUid),
+			Post:      post,
+			Folder:    record.Folder,
+			Note:      record.Note,
+			Timestamp: record.Timestamp,
+		})
+	}
+	return result
+}
+
+// 북마크에서 게시글 삭제하기
+func (s *TsboardBookmarkService) RemoveBookmark(postUid uint, userUid uint) error {
+	return s.repos.Bookmark.RemoveBookmark(postUid, userUid)
+}
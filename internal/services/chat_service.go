@@ -36,7 +36,18 @@ func (s *TsboardChatService) SaveChatMessage(actionUserUid uint, targetUserUid u
 	if isBanned := s.repos.User.IsBannedByTarget(actionUserUid, targetUserUid); isBanned {
 		return 0
 	}
-	insertId := s.repos.Chat.InsertNewChat(actionUserUid, targetUserUid, utils.Escape(message))
+	spam := models.SpamCheckParameter{
+		Target:  models.SPAM_TARGET_CHAT,
+		UserUid: actionUserUid,
+		Content: utils.Escape(message),
+	}
+	verdict := filterSpam(s.repos, spam)
+	if verdict.Action == models.SPAM_ACTION_REJECT {
+		s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, 0)
+		return 0
+	}
+	insertId := s.repos.Chat.InsertNewChat(actionUserUid, targetUserUid, verdict.Content)
+	s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, insertId)
 	parameter := models.InsertNotificationParameter{
 		ActionUserUid: actionUserUid,
 		TargetUserUid: targetUserUid,
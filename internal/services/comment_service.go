@@ -14,10 +14,10 @@ import (
 type CommentService interface {
 	Like(param models.CommentLikeParameter)
 	LoadList(param models.CommentListParameter) (models.CommentListResult, error)
-	Modify(param models.CommentModifyParameter) error
+	Modify(param models.CommentModifyParameter) (models.MentionParameter, error)
 	Remove(commentUid uint, boardUid uint, userUid uint) error
-	Reply(param models.CommentReplyParameter) (uint, error)
-	Write(param models.CommentWriteParameter) (uint, error)
+	Reply(param models.CommentReplyParameter) (models.MentionParameter, error)
+	Write(param models.CommentWriteParameter) (models.MentionParameter, error)
 }
 
 type TsboardCommentService struct {
@@ -29,7 +29,7 @@ func NewTsboardCommentService(repos *repositories.Repository) *TsboardCommentSer
 	return &TsboardCommentService{repos: repos}
 }
 
-// 댓글에 좋아요 클릭하기
+// 댓글에 좋아요 클릭하기 (기본 반응 👍 에도 반영)
 func (s *TsboardCommentService) Like(param models.CommentLikeParameter) {
 	if isLiked := s.repos.Comment.IsLikedComment(param.CommentUid, param.UserUid); !isLiked {
 		s.repos.Comment.InsertLikeComment(param)
@@ -47,6 +47,22 @@ func (s *TsboardCommentService) Like(param models.CommentLikeParameter) {
 	} else {
 		s.repos.Comment.UpdateLikeComment(param)
 	}
+
+	reaction := models.ReactionParameter{
+		BoardUid:  param.BoardUid,
+		TargetUid: param.CommentUid,
+		UserUid:   param.UserUid,
+		Table:     models.TABLE_COMMENT_REACTION,
+		Emoji:     models.REACTION_LIKE,
+	}
+	if !param.Liked {
+		s.repos.Reaction.RemoveReaction(reaction)
+		return
+	}
+	if opt, _ := s.repos.Board.GetReactionOption(param.BoardUid); !opt.Multiple {
+		s.repos.Reaction.RemoveUserReactions(reaction.Table, reaction.TargetUid, reaction.UserUid)
+	}
+	s.repos.Reaction.InsertReaction(reaction)
 }
 
 // 댓글 목록 가져오기
@@ -59,7 +75,7 @@ func (s *TsboardCommentService) LoadList(param models.CommentListParameter) (mod
 	}
 
 	status := s.repos.Comment.GetPostStatus(param.PostUid)
-	if status == models.CONTENT_SECRET {
+	if status == models.CONTENT_SECRET || status == models.CONTENT_PENDING {
 		isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
 		isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, param.PostUid, param.UserUid)
 		if !isAdmin && !isAuthor {
@@ -81,19 +97,45 @@ func (s *TsboardCommentService) LoadList(param models.CommentListParameter) (mod
 	if err != nil {
 		return result, err
 	}
+	for index, comment := range comments {
+		comments[index].Previews = s.repos.Unfurl.FindPreviews(utils.ExtractUrls(comment.Content))
+	}
 	result.Comments = comments
 	return result, nil
 }
 
-// 기존 댓글 수정하기
-func (s *TsboardCommentService) Modify(param models.CommentModifyParameter) error {
+// 기존 댓글 수정하기 (스팸 필터를 거친 내용으로 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardCommentService) Modify(param models.CommentModifyParameter) (models.MentionParameter, error) {
 	isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
 	isAuthor := s.repos.BoardView.IsWriter(models.TABLE_COMMENT, param.CommentUid, param.UserUid)
 	if !isAdmin && !isAuthor {
-		return fmt.Errorf("you have no permission to edit this comment")
+		return models.MentionParameter{}, fmt.Errorf("you have no permission to edit this comment")
 	}
-	s.repos.Comment.UpdateComment(param.CommentUid, param.Content)
-	return nil
+
+	spam := models.SpamCheckParameter{
+		Target:    models.SPAM_TARGET_COMMENT,
+		TargetUid: param.CommentUid,
+		BoardUid:  param.BoardUid,
+		PostUid:   param.PostUid,
+		UserUid:   param.UserUid,
+		Content:   param.Content,
+	}
+	verdict := filterSpam(s.repos, spam)
+	s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, param.CommentUid)
+	if verdict.Action == models.SPAM_ACTION_REJECT {
+		return models.MentionParameter{}, fmt.Errorf("your comment has been blocked by spam filter")
+	}
+	if verdict.Action == models.SPAM_ACTION_MODERATE {
+		s.repos.Moderation.HoldComment(param.BoardUid, param.PostUid, param.CommentUid, param.UserUid)
+	}
+	s.repos.Comment.UpdateComment(param.CommentUid, verdict.Content)
+	return models.MentionParameter{
+		BoardUid:   param.BoardUid,
+		PostUid:    param.PostUid,
+		CommentUid: param.CommentUid,
+		UserUid:    param.UserUid,
+		Content:    verdict.Content,
+	}, nil
 }
 
 // 댓글 삭제하기
@@ -104,44 +146,61 @@ func (s *TsboardCommentService) Remove(commentUid uint, boardUid uint, userUid u
 		return fmt.Errorf("you have no permission to remove this comment")
 	}
 
-	if hasReply := s.repos.Comment.HasReplyComment(commentUid); hasReply {
-		s.repos.Comment.UpdateComment(commentUid, "")
-	} else {
-		s.repos.Comment.RemoveComment(commentUid)
+	hasReply := s.repos.Comment.HasReplyComment(commentUid)
+	if trashUid := s.repos.Trash.MoveCommentToTrash(commentUid, userUid, hasReply); trashUid == models.FAILED {
+		return fmt.Errorf("unable to remove this comment")
 	}
 	return nil
 }
 
-// 새로운 답글 작성하기
-func (s *TsboardCommentService) Reply(param models.CommentReplyParameter) (uint, error) {
-	insertId, err := s.Write(param.CommentWriteParameter)
+// 새로운 답글 작성하기 (스팸 필터를 거친 내용으로 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardCommentService) Reply(param models.CommentReplyParameter) (models.MentionParameter, error) {
+	mention, err := s.Write(param.CommentWriteParameter)
 	if err != nil {
-		return models.FAILED, err
+		return mention, err
 	}
-	s.repos.Comment.UpdateReplyUid(insertId, param.ReplyTargetUid)
-	return insertId, nil
+	s.repos.Comment.UpdateReplyUid(mention.CommentUid, param.ReplyTargetUid)
+	return mention, nil
 }
 
-// 새로운 댓글 작성하기
-func (s *TsboardCommentService) Write(param models.CommentWriteParameter) (uint, error) {
+// 새로운 댓글 작성하기 (스팸 필터를 거친 내용으로 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardCommentService) Write(param models.CommentWriteParameter) (models.MentionParameter, error) {
 	if hasPerm := s.repos.Auth.CheckPermissionForAction(param.UserUid, models.USER_ACTION_WRITE_COMMENT); !hasPerm {
-		return models.FAILED, fmt.Errorf("you have no permission to write a comment")
+		return models.MentionParameter{}, fmt.Errorf("you have no permission to write a comment")
 	}
 	if isBanned := s.repos.BoardView.CheckBannedByWriter(param.PostUid, param.UserUid); isBanned {
-		return models.FAILED, fmt.Errorf("you have been blocked by writer")
+		return models.MentionParameter{}, fmt.Errorf("you have been blocked by writer")
 	}
-	if status := s.repos.Comment.GetPostStatus(param.PostUid); status == models.CONTENT_REMOVED {
-		return models.FAILED, fmt.Errorf("leaving a comment on a removed post is not allowed")
+	status := s.repos.Comment.GetPostStatus(param.PostUid)
+	if status == models.CONTENT_REMOVED {
+		return models.MentionParameter{}, fmt.Errorf("leaving a comment on a removed post is not allowed")
+	}
+	if status == models.CONTENT_PENDING {
+		return models.MentionParameter{}, fmt.Errorf("leaving a comment on an unpublished post is not allowed")
 	}
 
 	userLv, userPt := s.repos.User.GetUserLevelPoint(param.UserUid)
 	needLv, needPt := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_COMMENT)
 	if userLv < needLv {
-		return models.FAILED, fmt.Errorf("level restriction")
+		return models.MentionParameter{}, fmt.Errorf("level restriction")
 	}
 	if needPt < 0 && userPt < utils.Abs(needPt) {
-		return models.FAILED, fmt.Errorf("not enough point")
+		return models.MentionParameter{}, fmt.Errorf("not enough point")
+	}
+
+	spam := models.SpamCheckParameter{
+		Target:   models.SPAM_TARGET_COMMENT,
+		BoardUid: param.BoardUid,
+		PostUid:  param.PostUid,
+		UserUid:  param.UserUid,
+		Content:  param.Content,
 	}
+	verdict := filterSpam(s.repos, spam)
+	if verdict.Action == models.SPAM_ACTION_REJECT {
+		s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, 0)
+		return models.MentionParameter{}, fmt.Errorf("your comment has been blocked by spam filter")
+	}
+	param.Content = verdict.Content
 	s.repos.User.UpdateUserPoint(param.UserUid, uint(userPt+needPt))
 	s.repos.User.UpdatePointHistory(models.UpdatePointParameter{
 		UserUid:  param.UserUid,
@@ -150,11 +209,29 @@ func (s *TsboardCommentService) Write(param models.CommentWriteParameter) (uint,
 		Point:    needPt,
 	})
 
+	param.IsPending = verdict.Action == models.SPAM_ACTION_MODERATE
+	opt := s.repos.Moderation.GetModerationOption(param.BoardUid)
+	if !param.IsPending && opt.Mode != models.MODERATION_OFF && !s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid) {
+		param.IsPending = utils.NeedModeration(opt, userLv, s.repos.Moderation.GetPublishedCount(param.BoardUid, param.UserUid))
+	}
+
 	insertId, err := s.repos.Comment.InsertComment(param)
 	if err != nil {
-		return models.FAILED, err
+		return models.MentionParameter{}, err
 	}
 	s.repos.Comment.UpdateReplyUid(insertId, insertId)
+	s.repos.Spam.InsertSpamLogs(spam, verdict.Hits, insertId)
+	mention := models.MentionParameter{
+		BoardUid:   param.BoardUid,
+		PostUid:    param.PostUid,
+		CommentUid: insertId,
+		UserUid:    param.UserUid,
+		Content:    param.Content,
+	}
+	if param.IsPending {
+		s.repos.Moderation.InsertModeration(param.BoardUid, param.PostUid, insertId, param.UserUid)
+		return mention, nil
+	}
 
 	targetUserUid := s.repos.Comment.GetPostWriterUid(param.PostUid)
 	if param.UserUid != targetUserUid {
@@ -185,5 +262,5 @@ func (s *TsboardCommentService) Write(param models.CommentWriteParameter) (uint,
 		}
 	}
 
-	return insertId, nil
+	return mention, nil
 }
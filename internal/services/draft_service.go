@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type DraftService interface {
+	GetDraftList(boardUid uint, userUid uint) []models.DraftListItem
+	LoadDraft(draftUid uint, userUid uint) (models.DraftItem, error)
+	RemoveDraft(draftUid uint, userUid uint) error
+	RemoveStagedFile(draftUid uint, fileUid uint, userUid uint) error
+	SaveDraft(param models.DraftSaveParameter) (models.DraftSaveResult, error)
+}
+
+type TsboardDraftService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardDraftService(repos *repositories.Repository) *TsboardDraftService {
+	return &TsboardDraftService{repos: repos}
+}
+
+// 게시판에 내가 임시저장한 글 목록 가져오기
+func (s *TsboardDraftService) GetDraftList(boardUid uint, userUid uint) []models.DraftListItem {
+	return s.repos.Draft.GetDraftList(boardUid, userUid)
+}
+
+// 임시저장한 글 불러오기
+func (s *TsboardDraftService) LoadDraft(draftUid uint, userUid uint) (models.DraftItem, error) {
+	draft, err := s.repos.Draft.GetDraft(draftUid, userUid)
+	if err != nil {
+		return draft, fmt.Errorf("unable to find the draft")
+	}
+	return draft, nil
+}
+
+// 임시저장한 글과 미리 올려둔 첨부파일들, 삽입한 이미지들 삭제하기
+func (s *TsboardDraftService) RemoveDraft(draftUid uint, userUid uint) error {
+	if _, _, isMine := s.repos.Draft.GetDraftVersion(draftUid, userUid); !isMine {
+		return fmt.Errorf("unable to find the draft")
+	}
+	files := s.repos.Draft.GetStagedFiles(draftUid)
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	changeStorageUsage(s.repos, userUid, models.STORAGE_ATTACHMENT, paths, false)
+	images := s.repos.Draft.GetStagedImages(draftUid)
+	changeStorageUsage(s.repos, userUid, models.STORAGE_IMAGE, images, false)
+	for _, path := range append(paths, images...) {
+		os.Remove("." + path)
+	}
+	s.repos.Draft.RemoveDraft(draftUid)
+	return nil
+}
+
+// 임시저장한 글에 미리 올려둔 첨부파일 하나 삭제하기
+func (s *TsboardDraftService) RemoveStagedFile(draftUid uint, fileUid uint, userUid uint) error {
+	if _, _, isMine := s.repos.Draft.GetDraftVersion(draftUid, userUid); !isMine {
+		return fmt.Errorf("unable to find the draft")
+	}
+	path := s.repos.Draft.RemoveStagedFile(draftUid, fileUid)
+	if len(path) < 1 {
+		return fmt.Errorf("unable to find the staged file")
+	}
+	changeStorageUsage(s.repos, userUid, models.STORAGE_ATTACHMENT, []string{path}, false)
+	os.Remove("." + path)
+	return nil
+}
+
+// 글을 임시저장하기 (마지막으로 불러온 이후 다른 곳에서 저장했다면 충돌로 처리)
+func (s *TsboardDraftService) SaveDraft(param models.DraftSaveParameter) (models.DraftSaveResult, error) {
+	result := models.DraftSaveResult{}
+	if hasPerm := s.repos.Auth.CheckPermissionForAction(param.UserUid, models.USER_ACTION_WRITE_POST); !hasPerm {
+		return result, fmt.Errorf("you have no permission to write a new post")
+	}
+	if hasPerm := s.repos.BoardEdit.CheckWriterForBlog(param.BoardUid, param.UserUid); !hasPerm {
+		return result, fmt.Errorf("only blog owner can write a new post")
+	}
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_WRITE)
+	if userLv < needLv {
+		return result, fmt.Errorf("level restriction")
+	}
+
+	if param.DraftUid < 1 {
+		if count := s.repos.Draft.GetDraftCount(param.BoardUid, param.UserUid); count >= models.DRAFT_MAX_COUNT {
+			return result, fmt.Errorf("too many drafts, please remove old ones first")
+		}
+		param.DraftUid = s.repos.Draft.InsertDraft(param)
+		if param.DraftUid == models.FAILED {
+			return result, fmt.Errorf("failed to save a new draft")
+		}
+	} else if isUpdated := s.repos.Draft.UpdateDraft(param); !isUpdated {
+		version, updated, isMine := s.repos.Draft.GetDraftVersion(param.DraftUid, param.UserUid)
+		if !isMine {
+			return result, fmt.Errorf("unable to find the draft")
+		}
+		result.Uid = param.DraftUid
+		result.Version = version
+		result.Updated = updated
+		result.Conflict = true
+		if server, err := s.repos.Draft.GetDraft(param.DraftUid, param.UserUid); err == nil {
+			result.Server = &server
+		}
+		return result, nil
+	}
+
+	for _, file := range param.Files {
+		savedPath, err := utils.SaveAttachmentFile(file)
+		if err != nil {
+			continue
+		}
+		s.repos.Draft.InsertStagedFile(param.DraftUid, param.UserUid,
+			utils.CutString(file.Filename, 100), savedPath[1:], file.Size)
+		changeStorageUsage(s.repos, param.UserUid, models.STORAGE_ATTACHMENT, []string{savedPath[1:]}, true)
+	}
+
+	version, updated, _ := s.repos.Draft.GetDraftVersion(param.DraftUid, param.UserUid)
+	result.Uid = param.DraftUid
+	result.Version = version
+	result.Updated = updated
+	return result, nil
+}
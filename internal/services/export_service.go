@@ -0,0 +1,267 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type ExportService interface {
+	ExportBoard(boardUid uint, format models.ExportFormat) (models.ExportItem, error)
+	GetArchive(exportUid uint, userUid uint) (models.ExportDownloadResult, error)
+	GetExport(exportUid uint, userUid uint) (models.ExportItem, error)
+	RequestExport(param models.ExportInsertParameter) (uint, error)
+}
+
+type TsboardExportService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardExportService(repos *repositories.Repository) *TsboardExportService {
+	return &TsboardExportService{repos: repos}
+}
+
+// 게시판 전체를 바로 압축 파일로 내보내기 (명령줄에서 사용하며 최고 관리자 이름으로 기록)
+func (s *TsboardExportService) ExportBoard(boardUid uint, format models.ExportFormat) (models.ExportItem, error) {
+	exportUid := s.repos.Export.InsertExport(models.ExportInsertParameter{
+		BoardUid: boardUid,
+		UserUid:  1,
+		Format:   format,
+	})
+	if exportUid == models.FAILED {
+		return models.ExportItem{}, fmt.Errorf("failed to add a new export request")
+	}
+	err := runExport(s.repos, s.repos.Export.GetExport(exportUid))
+	return s.repos.Export.GetExport(exportUid), err
+}
+
+// 내보낸 압축 파일의 실제 경로와 다운로드 이름 가져오기 (게시판 관리자만 가능)
+func (s *TsboardExportService) GetArchive(exportUid uint, userUid uint) (models.ExportDownloadResult, error) {
+	result := models.ExportDownloadResult{}
+	item := s.repos.Export.GetExport(exportUid)
+	if item.Uid < 1 {
+		return result, fmt.Errorf("export request not found")
+	}
+	if isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, item.BoardUid); !isAdmin {
+		return result, fmt.Errorf("you are not an admin of this board")
+	}
+	if item.Status != models.EXPORT_DONE || len(item.Path) < 1 {
+		return result, fmt.Errorf("archive is not available")
+	}
+
+	config := s.repos.BoardView.GetBasicBoardConfig(item.BoardUid)
+	result.Path = "." + item.Path
+	result.Name = fmt.Sprintf("%s-%d.zip", config.Id, item.Uid)
+	return result, nil
+}
+
+// 내보내기 진행 상태 가져오기 (요청한 회원 혹은 게시판 관리자만 가능)
+func (s *TsboardExportService) GetExport(exportUid uint, userUid uint) (models.ExportItem, error) {
+	item := s.repos.Export.GetExport(exportUid)
+	if item.Uid < 1 {
+		return item, fmt.Errorf("export request not found")
+	}
+	if item.UserUid != userUid && !s.repos.Auth.CheckPermissionByUid(userUid, item.BoardUid) {
+		return models.ExportItem{}, fmt.Errorf("you are not an admin of this board")
+	}
+	return item, nil
+}
+
+// 게시글 혹은 게시판 전체 내보내기를 요청하고 백그라운드 작업 추가하기 (게시판 관리자만 가능)
+func (s *TsboardExportService) RequestExport(param models.ExportInsertParameter) (uint, error) {
+	if !param.Format.IsValid() {
+		return models.FAILED, fmt.Errorf("invalid export format")
+	}
+	if isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid); !isAdmin {
+		return models.FAILED, fmt.Errorf("you are not an admin of this board")
+	}
+	if param.PostUid > 0 {
+		if _, err := s.repos.Export.GetPost(param.BoardUid, param.PostUid); err != nil {
+			return models.FAILED, fmt.Errorf("post not found")
+		}
+	}
+
+	exportUid := s.repos.Export.InsertExport(param)
+	if exportUid == models.FAILED {
+		return models.FAILED, fmt.Errorf("failed to add a new export request")
+	}
+	s.repos.Job.InsertJob(models.JobInsertParameter{
+		Type:     models.JOB_EXPORT,
+		BoardUid: param.BoardUid,
+		PostUid:  param.PostUid,
+		FileUid:  exportUid,
+	})
+	return exportUid, nil
+}
+
+// 내보내기 요청대로 압축 파일을 만들고 결과 기록하기 (작업 처리기와 명령줄에서 함께 사용)
+func runExport(repos *repositories.Repository, item models.ExportItem) error {
+	if item.Uid < 1 {
+		return fmt.Errorf("export request not found")
+	}
+	savedPath, err := writeExportArchive(repos, item)
+	if err != nil {
+		repos.Export.UpdateExportFailed(item.Uid, utils.CutString(err.Error(), 300))
+		return err
+	}
+	repos.Export.UpdateExportDone(item.Uid, savedPath[1:], utils.GetFileSize(savedPath[1:]))
+	return nil
+}
+
+// 보관 기간이 지난 압축 파일들 삭제하기
+func removeExpiredExports(repos *repositories.Repository) uint {
+	var count uint
+	before := uint64(time.Now().Add(-models.EXPORT_KEEP_DURATION).UnixMilli())
+	for _, item := range repos.Export.FindExpiredExports(before, models.EXPORT_CLEANUP_BUNCH) {
+		if len(item.Path) > 0 {
+			if err := os.Remove("." + item.Path); err != nil && !os.IsNotExist(err) {
+				continue
+			}
+		}
+		repos.Export.UpdateExportExpired(item.Uid)
+		count++
+	}
+	return count
+}
+
+// 압축 파일을 만들어 저장하고 경로 반환하기 (실패하면 만들던 파일은 삭제)
+func writeExportArchive(repos *repositories.Repository, item models.ExportItem) (string, error) {
+	config := repos.BoardView.GetBasicBoardConfig(item.BoardUid)
+	if len(config.Id) < 1 {
+		return "", fmt.Errorf("board not found")
+	}
+	savePath, err := utils.MakeExportSavePath()
+	if err != nil {
+		return "", err
+	}
+	savedPath := fmt.Sprintf("%s/%s-%s.zip", savePath, config.Id, uuid.New().String())
+	file, err := os.OpenFile(savedPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	archive := zip.NewWriter(file)
+	err = fillExportArchive(repos, archive, item, config)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	file.Close()
+
+	if err != nil {
+		os.Remove(savedPath)
+		return "", err
+	}
+	return savedPath, nil
+}
+
+// 압축 파일에 게시글들과 목차 채워넣기
+func fillExportArchive(repos *repositories.Repository, archive *zip.Writer, item models.ExportItem, config models.BoardBasicConfig) error {
+	manifest := models.ExportManifest{
+		Version: configs.Env.Version,
+		Board:   config,
+		Format:  item.Format.String(),
+		Posts:   make([]uint, 0),
+		Created: uint64(time.Now().UnixMilli()),
+	}
+
+	if item.PostUid > 0 {
+		post, err := repos.Export.GetPost(item.BoardUid, item.PostUid)
+		if err != nil {
+			return fmt.Errorf("post not found")
+		}
+		if err := writeExportPost(repos, archive, item.Format, post); err != nil {
+			return err
+		}
+		manifest.Posts = append(manifest.Posts, post.Uid)
+	} else {
+		var sinceUid uint
+		for {
+			postUids := repos.Export.FindPostUids(item.BoardUid, sinceUid, models.EXPORT_BUNCH)
+			for _, postUid := range postUids {
+				post, err := repos.Export.GetPost(item.BoardUid, postUid)
+				if err != nil {
+					continue
+				}
+				if err := writeExportPost(repos, archive, item.Format, post); err != nil {
+					return err
+				}
+				manifest.Posts = append(manifest.Posts, post.Uid)
+			}
+			if len(postUids) < models.EXPORT_BUNCH {
+				break
+			}
+			sinceUid = postUids[len(postUids)-1]
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.AddZipContent(archive, "manifest.json", data)
+}
+
+// 게시글 하나를 댓글, 태그, 첨부파일과 함께 압축 파일에 넣기 (본문 속 업로드 경로는 상대 경로로 변경)
+func writeExportPost(repos *repositories.Repository, archive *zip.Writer, format models.ExportFormat, post models.ExportPost) error {
+	dir := fmt.Sprintf("posts/%d", post.Uid)
+	paths := make([]string, 0)
+
+	post.Tags = make([]string, 0)
+	for _, tag := range repos.BoardView.GetTags(post.Uid) {
+		post.Tags = append(post.Tags, tag.Name)
+	}
+
+	post.Files = repos.Export.GetFiles(post.Uid)
+	for i, file := range post.Files {
+		post.Files[i].Path = utils.GetExportFilePath(file.Origin)
+		paths = append(paths, file.Origin)
+	}
+
+	var found []string
+	post.Content, found = utils.RewriteUploadLinks(post.Content)
+	paths = append(paths, found...)
+	post.Source, found = utils.RewriteUploadLinks(post.Source)
+	paths = append(paths, found...)
+
+	post.Comments = repos.Export.GetComments(post.Uid)
+	for i, comment := range post.Comments {
+		post.Comments[i].Content, found = utils.RewriteUploadLinks(comment.Content)
+		paths = append(paths, found...)
+	}
+
+	copied := make(map[string]bool)
+	for _, path := range paths {
+		if copied[path] || utils.GetFileSize(path) < 1 {
+			continue
+		}
+		if err := utils.AddZipFile(archive, fmt.Sprintf("%s/%s", dir, utils.GetExportFilePath(path)), path); err != nil {
+			return err
+		}
+		copied[path] = true
+	}
+
+	if format == models.EXPORT_JSON {
+		data, err := json.MarshalIndent(post, "", "  ")
+		if err != nil {
+			return err
+		}
+		return utils.AddZipContent(archive, dir+"/post.json", data)
+	}
+
+	if err := utils.AddZipContent(archive, dir+"/index.md", []byte(utils.MakeExportMarkdown(post))); err != nil {
+		return err
+	}
+	if len(post.Comments) > 0 {
+		return utils.AddZipContent(archive, dir+"/comments.md", []byte(utils.MakeExportCommentsMarkdown(post.Comments)))
+	}
+	return nil
+}
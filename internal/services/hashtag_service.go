@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type HashtagService interface {
+	BlockHashtag(hashtagUid uint, blocked bool) error
+	GetHashtagDetail(name string) (models.Hashtag, error)
+	GetHashtagList(param models.HashtagListParameter) models.HashtagListResult
+	GetTrendingTags(days uint, bunch uint) []models.HashtagCloudItem
+	MergeHashtag(fromUid uint, toUid uint) (models.HashtagMergeResult, error)
+	RecountHashtags() models.HashtagRecountResult
+	RemoveHashtag(hashtagUid uint) error
+	RenameHashtag(hashtagUid uint, name string) error
+}
+
+type TsboardHashtagService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardHashtagService(repos *repositories.Repository) *TsboardHashtagService {
+	return &TsboardHashtagService{repos: repos}
+}
+
+// 해시태그 차단 혹은 차단 해제하기
+func (s *TsboardHashtagService) BlockHashtag(hashtagUid uint, blocked bool) error {
+	if tag := s.repos.Hashtag.GetHashtag(hashtagUid); tag.Uid < 1 {
+		return fmt.Errorf("hashtag not found")
+	}
+	return s.repos.Hashtag.BlockHashtag(hashtagUid, blocked)
+}
+
+// 해시태그 상세 정보 가져오기 (차단된 태그는 보여주지 않음)
+func (s *TsboardHashtagService) GetHashtagDetail(name string) (models.Hashtag, error) {
+	tag := s.repos.Hashtag.GetHashtagByName(name)
+	if tag.Uid < 1 || tag.Blocked {
+		return tag, fmt.Errorf("hashtag not found")
+	}
+	return tag, nil
+}
+
+// (관리화면) 해시태그 목록 가져오기
+func (s *TsboardHashtagService) GetHashtagList(param models.HashtagListParameter) models.HashtagListResult {
+	param.MaxUid = s.repos.Board.GetMaxUid(models.TABLE_HASHTAG)
+	return models.HashtagListResult{
+		Items:  s.repos.Hashtag.GetHashtagList(param),
+		MaxUid: param.MaxUid,
+	}
+}
+
+// 최근 며칠 동안 많이 사용된 해시태그들 가져오기
+func (s *TsboardHashtagService) GetTrendingTags(days uint, bunch uint) []models.HashtagCloudItem {
+	days = min(max(days, 1), models.HASHTAG_MAX_TREND_DAYS)
+	bunch = min(max(bunch, 1), models.HASHTAG_MAX_CLOUD)
+	since := time.Now().AddDate(0, 0, -int(days)).UnixMilli()
+	return s.repos.Hashtag.GetTrendingTags(since, bunch)
+}
+
+// 해시태그를 다른 해시태그로 합치기 (합쳐진 태그는 삭제)
+func (s *TsboardHashtagService) MergeHashtag(fromUid uint, toUid uint) (models.HashtagMergeResult, error) {
+	result := models.HashtagMergeResult{}
+	if fromUid == toUid {
+		return result, fmt.Errorf("unable to merge a hashtag into itself")
+	}
+	from := s.repos.Hashtag.GetHashtag(fromUid)
+	to := s.repos.Hashtag.GetHashtag(toUid)
+	if from.Uid < 1 || to.Uid < 1 {
+		return result, fmt.Errorf("hashtag not found")
+	}
+	if to.Blocked {
+		return result, fmt.Errorf("unable to merge into a blocked hashtag")
+	}
+
+	moved, err := s.repos.Hashtag.MergeHashtag(fromUid, toUid)
+	if err != nil {
+		return result, err
+	}
+	result.Moved = moved
+	result.Used = s.repos.Hashtag.GetHashtag(toUid).Used
+	return result, nil
+}
+
+// 모든 해시태그의 사용 횟수를 다시 계산하고 사용되지 않는 태그들 정리하기
+func (s *TsboardHashtagService) RecountHashtags() models.HashtagRecountResult {
+	return models.HashtagRecountResult{
+		Updated: s.repos.Hashtag.RecountHashtags(),
+		Removed: s.repos.Hashtag.RemoveUnusedHashtags(),
+	}
+}
+
+// 해시태그 삭제하기 (게시글들과의 연결도 함께 삭제)
+func (s *TsboardHashtagService) RemoveHashtag(hashtagUid uint) error {
+	if tag := s.repos.Hashtag.GetHashtag(hashtagUid); tag.Uid < 1 {
+		return fmt.Errorf("hashtag not found")
+	}
+	return s.repos.Hashtag.RemoveHashtag(hashtagUid)
+}
+
+// 해시태그 이름 변경하기 (이미 있는 이름이면 병합하도록 안내)
+func (s *TsboardHashtagService) RenameHashtag(hashtagUid uint, name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < 2 || length > models.HASHTAG_MAX_NAME || strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("invalid hashtag name")
+	}
+	if tag := s.repos.Hashtag.GetHashtag(hashtagUid); tag.Uid < 1 {
+		return fmt.Errorf("hashtag not found")
+	}
+	if exist := s.repos.Hashtag.GetHashtagByName(name); exist.Uid > 0 && exist.Uid != hashtagUid {
+		return fmt.Errorf("hashtag name already exists, merge them instead")
+	}
+	return s.repos.Hashtag.RenameHashtag(hashtagUid, name)
+}
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type ImportService interface {
+	Import(param models.ImportParameter) (models.ImportReport, error)
+}
+
+type TsboardImportService struct {
+	repos *repositories.Repository
+}
+
+// 가져오기 한 번에 필요한 고유번호 대응표와 진행 상황 (미리보기에서는 새로 만들 항목을 0 으로 표시)
+type importState struct {
+	param  models.ImportParameter
+	report *models.ImportReport
+	ids    map[models.ImportKind]map[string]uint
+	emails map[string]uint
+	names  map[string]bool
+	saved  map[string]string
+	links  map[string]map[string]string
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardImportService(repos *repositories.Repository) *TsboardImportService {
+	return &TsboardImportService{repos: repos}
+}
+
+// 다른 게시판 엔진의 데이터를 가져오기 (DryRun 이면 실제로 저장하지 않고 보고서만 작성)
+func (s *TsboardImportService) Import(param models.ImportParameter) (models.ImportReport, error) {
+	report := models.ImportReport{
+		Format:   param.Format.String(),
+		DryRun:   param.DryRun,
+		Warnings: make([]string, 0),
+	}
+	if len(param.Prefix) < 1 {
+		param.Prefix = param.Format.DefaultPrefix()
+	}
+	if len(param.FileRoot) < 1 {
+		param.FileRoot = filepath.Dir(param.Path)
+	}
+
+	data, err := utils.ReadImportData(param)
+	if err != nil {
+		return report, err
+	}
+
+	state := &importState{
+		param:  param,
+		report: &report,
+		ids:    make(map[models.ImportKind]map[string]uint),
+		emails: make(map[string]uint),
+		names:  make(map[string]bool),
+		saved:  make(map[string]string),
+		links:  make(map[string]map[string]string),
+	}
+	for _, kind := range []models.ImportKind{
+		models.IMPORT_USER, models.IMPORT_BOARD, models.IMPORT_CATEGORY,
+		models.IMPORT_POST, models.IMPORT_COMMENT, models.IMPORT_FILE,
+	} {
+		state.ids[kind] = s.repos.Import.GetImportMap(param.Format, kind)
+	}
+	for _, warning := range data.Warnings {
+		state.warn(warning)
+	}
+
+	s.importUsers(state, data.Users)
+	s.importBoards(state, data.Boards, data.Categories)
+	s.importCategories(state, data.Categories)
+	s.copyFiles(state, data.Files)
+	s.importPosts(state, data.Posts)
+	s.importComments(state, data.Comments)
+	s.registerFiles(state, data.Files)
+	return report, nil
+}
+
+// 회원 가져오기 (같은 이메일의 회원이 이미 있다면 그 회원에 연결, 새 회원은 비밀번호 재설정 필요)
+func (s *TsboardImportService) importUsers(state *importState, users []models.ImportUser) {
+	count := &state.report.Users
+	ids := state.ids[models.IMPORT_USER]
+
+	for _, user := range users {
+		count.Found++
+		if _, isMapped := ids[user.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+
+		user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+		if !utils.IsValidEmail(user.Email) {
+			count.Skipped++
+			state.warn(fmt.Sprintf("user %s skipped, invalid email: %s", user.SourceId, user.Email))
+			continue
+		}
+
+		userUid, isExist := state.emails[user.Email]
+		if !isExist {
+			userUid = s.repos.Auth.FindUserUidById(user.Email)
+			isExist = userUid > 0
+		}
+		if isExist {
+			s.mapImported(state, models.IMPORT_USER, user.SourceId, userUid, "")
+			count.Mapped++
+			continue
+		}
+
+		name := s.makeUniqueName(state, user)
+		if state.param.DryRun {
+			ids[user.SourceId] = 0
+			state.emails[user.Email] = 0
+			count.Created++
+			state.report.ResetUsers++
+			continue
+		}
+
+		user.Signature = utils.CutString(utils.Escape(user.Signature), 300)
+		userUid = s.repos.Import.InsertUser(user, name)
+		if userUid == models.FAILED {
+			count.Skipped++
+			state.warn(fmt.Sprintf("user %s skipped, failed to add a new user", user.SourceId))
+			continue
+		}
+		s.mapImported(state, models.IMPORT_USER, user.SourceId, userUid, utils.CutString(user.Password, 300))
+		state.emails[user.Email] = userUid
+		count.Created++
+		state.report.ResetUsers++
+	}
+}
+
+// 게시판 가져오기 (같은 아이디의 게시판이 이미 있다면 그 게시판에 글을 추가)
+func (s *TsboardImportService) importBoards(state *importState, boards []models.ImportBoard, categories []models.ImportCategory) {
+	count := &state.report.Boards
+	ids := state.ids[models.IMPORT_BOARD]
+	hasCategory := make(map[string]bool)
+	for _, category := range categories {
+		hasCategory[category.BoardSourceId] = true
+	}
+
+	for _, board := range boards {
+		count.Found++
+		if _, isMapped := ids[board.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+		if boardUid := s.repos.Board.GetBoardUidById(board.Id); boardUid > 0 {
+			s.mapImported(state, models.IMPORT_BOARD, board.SourceId, boardUid, "")
+			count.Mapped++
+			state.warn(fmt.Sprintf("board %s already exists, imported posts will be added to it", board.Id))
+			continue
+		}
+		if state.param.DryRun {
+			ids[board.SourceId] = 0
+			count.Created++
+			continue
+		}
+
+		board.Name = utils.CutString(utils.Escape(html.UnescapeString(board.Name)), 20)
+		if len(board.Name) < 1 {
+			board.Name = board.Id
+		}
+		board.Info = utils.CutString(utils.Escape(html.UnescapeString(board.Info)), 100)
+		boardUid := s.repos.Import.InsertBoard(s.repos.Admin.GetDefaultGroupUid(0), board)
+		if boardUid == models.FAILED {
+			count.Skipped++
+			state.warn(fmt.Sprintf("board %s skipped, failed to add a new board", board.Id))
+			continue
+		}
+		if !hasCategory[board.SourceId] {
+			s.repos.Admin.CreateDefaultCategories(boardUid, []string{"etc"})
+		}
+		s.mapImported(state, models.IMPORT_BOARD, board.SourceId, boardUid, "")
+		count.Created++
+	}
+}
+
+// 카테고리 가져오기 (상위 카테고리가 먼저 만들어지도록 여러 번 나눠서 처리)
+func (s *TsboardImportService) importCategories(state *importState, categories []models.ImportCategory) {
+	count := &state.report.Categories
+	ids := state.ids[models.IMPORT_CATEGORY]
+	boards := state.ids[models.IMPORT_BOARD]
+	remains := make([]models.ImportCategory, 0)
+
+	for _, category := range categories {
+		count.Found++
+		if _, isMapped := ids[category.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+		if _, isExist := boards[category.BoardSourceId]; !isExist {
+			count.Skipped++
+			continue
+		}
+		remains = append(remains, category)
+	}
+
+	for len(remains) > 0 {
+		pending := make([]models.ImportCategory, 0)
+		for _, category := range remains {
+			parentUid, isReady := ids[category.ParentSourceId]
+			if len(category.ParentSourceId) > 0 && !isReady {
+				pending = append(pending, category)
+				continue
+			}
+			s.importCategory(state, category, parentUid)
+		}
+
+		if len(pending) == len(remains) {
+			for _, category := range pending {
+				state.warn(fmt.Sprintf("category %s has no parent, moved to the top level", category.Name))
+				s.importCategory(state, category, 0)
+			}
+			break
+		}
+		remains = pending
+	}
+}
+
+// 카테고리 하나 가져오기 (같은 이름의 카테고리가 이미 있다면 연결)
+func (s *TsboardImportService) importCategory(state *importState, category models.ImportCategory, parentUid uint) {
+	count := &state.report.Categories
+	boardUid := state.ids[models.IMPORT_BOARD][category.BoardSourceId]
+	name := utils.CutString(utils.Escape(html.UnescapeString(category.Name)), models.CATEGORY_MAX_NAME)
+
+	if boardUid > 0 {
+		if categoryUid := s.repos.Board.GetCategoryUidByName(boardUid, name); categoryUid > 0 {
+			s.mapImported(state, models.IMPORT_CATEGORY, category.SourceId, categoryUid, "")
+			count.Mapped++
+			return
+		}
+	}
+	if state.param.DryRun {
+		state.ids[models.IMPORT_CATEGORY][category.SourceId] = 0
+		count.Created++
+		return
+	}
+
+	categoryUid := s.repos.Admin.InsertCategory(boardUid, parentUid, name)
+	if categoryUid == models.FAILED {
+		count.Skipped++
+		state.warn(fmt.Sprintf("category %s skipped, failed to add a new category", category.Name))
+		return
+	}
+	s.mapImported(state, models.IMPORT_CATEGORY, category.SourceId, categoryUid, "")
+	count.Created++
+}
+
+// 첨부파일들을 먼저 복사해두고 본문 속 원래 주소를 바꿀 수 있도록 새 경로 기록하기
+func (s *TsboardImportService) copyFiles(state *importState, files []models.ImportFile) {
+	count := &state.report.Files
+	for _, file := range files {
+		count.Found++
+		if _, isMapped := state.ids[models.IMPORT_FILE][file.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+
+		srcPath := filepath.Join(state.param.FileRoot, filepath.FromSlash(file.Path))
+		if strings.Contains(file.Path, "..") {
+			count.Skipped++
+			state.warn(fmt.Sprintf("file %s skipped, invalid path: %s", file.SourceId, file.Path))
+			continue
+		}
+		if info, err := os.Stat(srcPath); err != nil || info.IsDir() {
+			count.Skipped++
+			state.warn(fmt.Sprintf("file %s skipped, not found: %s", file.SourceId, srcPath))
+			continue
+		}
+		if state.param.DryRun {
+			count.Created++
+			continue
+		}
+
+		savedPath, err := utils.SaveImportFile(srcPath, file.Name)
+		if err != nil {
+			count.Skipped++
+			state.warn(fmt.Sprintf("file %s skipped, %s", file.SourceId, err.Error()))
+			continue
+		}
+		state.saved[file.SourceId] = savedPath
+		if len(file.Url) > 0 {
+			if state.links[file.PostSourceId] == nil {
+				state.links[file.PostSourceId] = make(map[string]string)
+			}
+			state.links[file.PostSourceId][file.Url] = savedPath[1:]
+		}
+	}
+}
+
+// 게시글 가져오기 (작성자를 찾을 수 없으면 관리자 글로, 카테고리가 없으면 첫 번째 카테고리로)
+func (s *TsboardImportService) importPosts(state *importState, posts []models.ImportPost) {
+	count := &state.report.Posts
+	ids := state.ids[models.IMPORT_POST]
+
+	for _, post := range posts {
+		count.Found++
+		if _, isMapped := ids[post.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+		boardUid, isExist := state.ids[models.IMPORT_BOARD][post.BoardSourceId]
+		if !isExist {
+			count.Skipped++
+			continue
+		}
+		if state.param.DryRun {
+			ids[post.SourceId] = 0
+			count.Created++
+			state.report.Tags.Found += uint(len(post.Tags))
+			continue
+		}
+
+		categoryUid := state.ids[models.IMPORT_CATEGORY][post.CategorySourceId]
+		if categoryUid < 1 {
+			categoryUid = s.repos.Admin.GetLowestCategoryUid(boardUid)
+		}
+		content := post.Content
+		for from, to := range state.links[post.SourceId] {
+			content = strings.ReplaceAll(content, "."+from, to)
+			content = strings.ReplaceAll(content, from, to)
+		}
+		title := utils.CutString(utils.Escape(html.UnescapeString(post.Title)), 299)
+		if len(title) < 1 {
+			title = "untitled"
+		}
+		submitted := post.Submitted
+		if submitted < 1 {
+			submitted = uint64(time.Now().UnixMilli())
+		}
+
+		postUid := s.repos.Import.InsertPost(models.ImportPostParameter{
+			BoardUid:    boardUid,
+			UserUid:     s.findWriter(state, post.WriterSourceId),
+			CategoryUid: categoryUid,
+			Title:       title,
+			Content:     utils.Sanitize(content),
+			Status:      utils.GetContentStatus(post.IsNotice, post.IsSecret),
+			Hit:         post.Hit,
+			Submitted:   submitted,
+			Modified:    post.Modified,
+		})
+		if postUid == models.FAILED {
+			count.Skipped++
+			state.warn(fmt.Sprintf("post %s skipped, failed to add a new post", post.SourceId))
+			continue
+		}
+		s.mapImported(state, models.IMPORT_POST, post.SourceId, postUid, "")
+		s.saveTags(state, boardUid, postUid, post.Tags)
+		count.Created++
+	}
+}
+
+// 댓글 가져오기 (답글 대상이 없으면 게시글에 바로 단 댓글로)
+func (s *TsboardImportService) importComments(state *importState, comments []models.ImportComment) {
+	count := &state.report.Comments
+	ids := state.ids[models.IMPORT_COMMENT]
+
+	for _, comment := range comments {
+		count.Found++
+		if _, isMapped := ids[comment.SourceId]; isMapped {
+			count.Mapped++
+			continue
+		}
+		postUid, isExist := state.ids[models.IMPORT_POST][comment.PostSourceId]
+		if !isExist {
+			count.Skipped++
+			continue
+		}
+		if state.param.DryRun {
+			ids[comment.SourceId] = 0
+			count.Created++
+			continue
+		}
+
+		content := utils.CutString(utils.Sanitize(comment.Content), 9999)
+		if len(content) < 1 {
+			count.Skipped++
+			continue
+		}
+		submitted := comment.Submitted
+		if submitted < 1 {
+			submitted = uint64(time.Now().UnixMilli())
+		}
+
+		commentUid := s.repos.Import.InsertComment(models.ImportCommentParameter{
+			BoardUid:  s.repos.Admin.FindBoardUidByPostUid(postUid),
+			PostUid:   postUid,
+			UserUid:   s.findWriter(state, comment.WriterSourceId),
+			Content:   content,
+			Submitted: submitted,
+			Modified:  comment.Modified,
+		})
+		if commentUid == models.FAILED {
+			count.Skipped++
+			state.warn(fmt.Sprintf("comment %s skipped, failed to add a new comment", comment.SourceId))
+			continue
+		}
+
+		replyUid := commentUid
+		if targetUid := ids[comment.ReplySourceId]; len(comment.ReplySourceId) > 0 && targetUid > 0 {
+			replyUid = targetUid
+		}
+		s.repos.Comment.UpdateReplyUid(commentUid, replyUid)
+		s.mapImported(state, models.IMPORT_COMMENT, comment.SourceId, commentUid, "")
+		count.Created++
+	}
+}
+
+// 복사해둔 첨부파일들을 게시글에 등록하기 (이미지는 EXIF 정리와 썸네일 작업 추가)
+func (s *TsboardImportService) registerFiles(state *importState, files []models.ImportFile) {
+	count := &state.report.Files
+	for _, file := range files {
+		savedPath, isSaved := state.saved[file.SourceId]
+		if !isSaved {
+			continue
+		}
+		postUid := state.ids[models.IMPORT_POST][file.PostSourceId]
+		if postUid < 1 {
+			os.Remove(savedPath)
+			count.Skipped++
+			continue
+		}
+
+		boardUid := s.repos.Admin.FindBoardUidByPostUid(postUid)
+		fileUid := s.repos.BoardEdit.InsertFile(models.EditorSaveFileParameter{
+			BoardUid: boardUid,
+			PostUid:  postUid,
+			Name:     utils.CutString(file.Name, 100),
+			Path:     savedPath[1:],
+		})
+		if fileUid == models.FAILED {
+			os.Remove(savedPath)
+			count.Skipped++
+			continue
+		}
+		s.mapImported(state, models.IMPORT_FILE, file.SourceId, fileUid, "")
+		changePostStorageUsage(s.repos, postUid, []string{savedPath[1:]}, true)
+		count.Created++
+
+		if utils.IsImage(file.Name) {
+			for _, jobType := range []models.JobType{models.JOB_EXIF, models.JOB_THUMBNAIL} {
+				s.repos.Job.InsertJob(models.JobInsertParameter{
+					Type:     jobType,
+					BoardUid: boardUid,
+					PostUid:  postUid,
+					FileUid:  fileUid,
+					Path:     savedPath[1:],
+				})
+			}
+		}
+	}
+}
+
+// 옮겨온 게시글에 해시태그 연결하기 (차단된 태그와 규칙에 맞지 않는 태그는 제외)
+func (s *TsboardImportService) saveTags(state *importState, boardUid uint, postUid uint, tags []string) {
+	count := &state.report.Tags
+	saved := make(map[uint]bool)
+	for _, tag := range tags {
+		count.Found++
+		tag = strings.Join(strings.Fields(utils.Purify(tag)), "")
+		if length := utf8.RuneCountInString(tag); length < 2 || length > models.HASHTAG_MAX_NAME {
+			count.Skipped++
+			continue
+		}
+
+		hashtag := s.repos.Hashtag.GetHashtagByName(tag)
+		if hashtag.Blocked || saved[hashtag.Uid] {
+			count.Skipped++
+			continue
+		}
+		hashtagUid := hashtag.Uid
+		if hashtagUid > 0 {
+			s.repos.BoardEdit.UpdateTag(hashtagUid)
+			count.Mapped++
+		} else {
+			hashtagUid = s.repos.BoardEdit.InsertTag(tag)
+			if hashtagUid == models.FAILED {
+				count.Skipped++
+				continue
+			}
+			count.Created++
+		}
+		s.repos.BoardEdit.InsertPostHashtag(boardUid, postUid, hashtagUid)
+		saved[hashtagUid] = true
+	}
+}
+
+// 원본 작성자에 대응하는 회원 번호 찾기 (비회원이거나 옮겨오지 못한 회원이면 관리자)
+func (s *TsboardImportService) findWriter(state *importState, sourceId string) uint {
+	if userUid := state.ids[models.IMPORT_USER][sourceId]; len(sourceId) > 0 && userUid > 0 {
+		return userUid
+	}
+	return models.CREATE_BOARD_ADMIN
+}
+
+// 이미 쓰고 있는 이름이면 뒤에 숫자를 붙여서 겹치지 않는 회원 이름 만들기
+func (s *TsboardImportService) makeUniqueName(state *importState, user models.ImportUser) string {
+	base := strings.TrimSpace(user.Name)
+	if len(base) < 2 {
+		base = strings.Split(user.Email, "@")[0]
+	}
+	base = utils.CutString(utils.Escape(base), 25)
+
+	name := base
+	for suffix := 2; suffix <= models.IMPORT_MAX_NAME_SUFFIX; suffix++ {
+		if !state.names[name] && !s.repos.User.IsNameDuplicated(name, 0) {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", base, suffix)
+	}
+	state.names[name] = true
+	return name
+}
+
+// 원본 고유번호와 새 고유번호 대응 관계 기록하기 (미리보기에서는 메모리에만 기록)
+func (s *TsboardImportService) mapImported(state *importState, kind models.ImportKind, sourceId string, targetUid uint, legacyPassword string) {
+	state.ids[kind][sourceId] = targetUid
+	if !state.param.DryRun {
+		s.repos.Import.InsertImportMap(state.param.Format, kind, utils.CutString(sourceId, models.IMPORT_MAX_SOURCE_ID), targetUid, legacyPassword)
+	}
+}
+
+// 보고서에 경고 남기기 (너무 많으면 개수만 남김)
+func (st *importState) warn(message string) {
+	if len(st.report.Warnings) < models.IMPORT_MAX_WARNINGS {
+		st.report.Warnings = append(st.report.Warnings, message)
+	} else if len(st.report.Warnings) == models.IMPORT_MAX_WARNINGS {
+		st.report.Warnings = append(st.report.Warnings, "too many warnings, the rest are omitted")
+	}
+}
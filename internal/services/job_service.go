@@ -0,0 +1,298 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type JobService interface {
+	BackfillVariants(boardUid uint) uint
+	GetJobList(param models.JobListParameter) models.JobListResult
+	GetPostJobs(boardUid uint, postUid uint, userUid uint) ([]models.JobItem, error)
+	RetryJob(jobUid uint) error
+	Start()
+}
+
+type TsboardJobService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardJobService(repos *repositories.Repository) *TsboardJobService {
+	return &TsboardJobService{repos: repos}
+}
+
+// 변형 이미지가 없는 기존 첨부 이미지들에 대해 생성 작업 추가하기 (boardUid 가 0이면 전체)
+func (s *TsboardJobService) BackfillVariants(boardUid uint) uint {
+	var count uint
+	for _, target := range s.repos.Admin.FindVariantTargets(boardUid) {
+		opt, isNeeded := s.getVariantOption(target.BoardUid)
+		if !isNeeded {
+			continue
+		}
+		if target.SkippedWidth > 0 && slices.Min(opt.Widths) >= target.SkippedWidth {
+			continue
+		}
+		s.repos.Job.InsertJob(models.JobInsertParameter{
+			Type:     models.JOB_VARIANTS,
+			BoardUid: target.BoardUid,
+			PostUid:  target.PostUid,
+			FileUid:  target.FileUid,
+			Path:     target.Path,
+		})
+		count++
+	}
+	return count
+}
+
+// (관리화면) 작업 목록 가져오기
+func (s *TsboardJobService) GetJobList(param models.JobListParameter) models.JobListResult {
+	param.MaxUid = s.repos.Board.GetMaxUid(models.TABLE_JOB)
+	jobs := s.repos.Job.GetJobList(param)
+	return models.JobListResult{
+		Jobs:   jobs,
+		MaxUid: param.MaxUid,
+	}
+}
+
+// 게시글에 연결된 작업들의 진행 상태 가져오기 (작성자 혹은 관리자만 가능)
+func (s *TsboardJobService) GetPostJobs(boardUid uint, postUid uint, userUid uint) ([]models.JobItem, error) {
+	isWriter := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, userUid)
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+	if !isWriter && !isAdmin {
+		return nil, fmt.Errorf("you are not the writer of this post")
+	}
+	return s.repos.Job.GetJobsByPost(postUid), nil
+}
+
+// 실패한 작업을 다시 시도하기
+func (s *TsboardJobService) RetryJob(jobUid uint) error {
+	return s.repos.Job.UpdateJobPending(jobUid)
+}
+
+// 작업 처리기들을 띄우고 대기 중인 작업들을 주기적으로 나눠주기
+func (s *TsboardJobService) Start() {
+	workers, maxAttempts := configs.GetJobWorkerConfig()
+	s.repos.Job.ResetRunningJobs()
+
+	queue := make(chan models.JobItem, workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range queue {
+				s.process(job, maxAttempts)
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(models.JOB_POLL_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			jobs := s.repos.Job.FindPendingJobs(uint(workers))
+			for _, job := range jobs {
+				if isClaimed := s.repos.Job.ClaimJob(job.Uid); isClaimed {
+					queue <- job
+				}
+			}
+		}
+	}()
+	log.Printf("🧵 Background job workers: %d (max attempts: %d)\n", workers, maxAttempts)
+
+	go func() {
+		ticker := time.NewTicker(models.POST_SCHEDULE_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.updateScheduledPosts()
+			s.notifyClosedPolls()
+			s.cleanupExports()
+		}
+	}()
+}
+
+// 보관 기간이 지난 내보내기 압축 파일들 정리하기
+func (s *TsboardJobService) cleanupExports() {
+	if count := removeExpiredExports(s.repos); count > 0 {
+		log.Printf("📦 %d expired export archives have been removed\n", count)
+	}
+}
+
+// 마감된 투표들의 작성자에게 알림 보내기
+func (s *TsboardJobService) notifyClosedPolls() {
+	for _, poll := range s.repos.Poll.FindClosedPolls(time.Now().UnixMilli()) {
+		s.repos.Noti.InsertNotification(models.InsertNotificationParameter{
+			ActionUserUid: poll.UserUid,
+			TargetUserUid: poll.UserUid,
+			NotiType:      models.NOTI_POLL_CLOSED,
+			PostUid:       poll.PostUid,
+		})
+		s.repos.Poll.UpdateNotified(poll.Uid)
+	}
+}
+
+// 예약 시각이 된 게시글은 공개하면서 언급 알림을 보내고, 게시 종료 시각이 지난 게시글은 내리거나 숨기기
+func (s *TsboardJobService) updateScheduledPosts() {
+	now := time.Now().UnixMilli()
+	if posts := s.repos.BoardEdit.PublishScheduledPosts(now); len(posts) > 0 {
+		for _, post := range posts {
+			saveMentions(s.repos, post)
+		}
+		log.Printf("📅 %d scheduled posts have been published\n", len(posts))
+	}
+	if count := s.repos.BoardEdit.ExpirePosts(now); count > 0 {
+		log.Printf("📅 %d posts have been expired\n", count)
+	}
+}
+
+// 작업 하나를 처리하고 결과에 따라 완료/재시도/실패 처리
+func (s *TsboardJobService) process(job models.JobItem, maxAttempts int) {
+	var err error
+	switch job.Type {
+	case models.JOB_THUMBNAIL:
+		err = s.makeThumbnail(job)
+	case models.JOB_EXIF:
+		err = s.extractExif(job)
+	case models.JOB_IMAGE_DESCRIPTION:
+		err = s.describeImage(job)
+	case models.JOB_VARIANTS:
+		err = s.makeVariants(job)
+	case models.JOB_EXPORT:
+		err = s.makeExport(job)
+	default:
+		err = fmt.Errorf("unknown job type: %d", job.Type)
+	}
+
+	if err == nil {
+		s.repos.Job.UpdateJobDone(job.Uid)
+		return
+	}
+
+	attempts := job.Attempts + 1
+	if int(attempts) >= maxAttempts {
+		s.repos.Job.UpdateJobFailed(job.Uid, attempts, err.Error())
+		return
+	}
+	backoff := time.Duration(attempts*attempts) * time.Minute
+	s.repos.Job.UpdateJobRetry(job.Uid, attempts, err.Error(), time.Now().Add(backoff).UnixMilli())
+}
+
+// 첨부된 이미지의 썸네일 생성하기
+func (s *TsboardJobService) makeThumbnail(job models.JobItem) error {
+	thumb, err := utils.SaveThumbnailImage("." + job.Path)
+	if err != nil {
+		return err
+	}
+	s.repos.BoardEdit.InsertFileThumbnail(models.EditorSaveThumbnailParameter{
+		BoardThumbnail: models.BoardThumbnail{
+			Large: thumb.Large[1:],
+			Small: thumb.Small[1:],
+		},
+		FileUid: job.FileUid,
+		PostUid: job.PostUid,
+	})
+	changePostStorageUsage(s.repos, job.PostUid, []string{thumb.Small[1:], thumb.Large[1:]}, true)
+
+	if _, isNeeded := s.getVariantOption(job.BoardUid); isNeeded {
+		s.repos.Job.InsertJob(models.JobInsertParameter{
+			Type:     models.JOB_VARIANTS,
+			BoardUid: job.BoardUid,
+			PostUid:  job.PostUid,
+			FileUid:  job.FileUid,
+			Path:     job.Path,
+		})
+	}
+
+	if describer := utils.NewImageDescriber(); describer != nil {
+		s.repos.Job.InsertJob(models.JobInsertParameter{
+			Type:     models.JOB_IMAGE_DESCRIPTION,
+			BoardUid: job.BoardUid,
+			PostUid:  job.PostUid,
+			FileUid:  job.FileUid,
+			Path:     thumb.Small[1:],
+		})
+	}
+	return nil
+}
+
+// 첨부된 이미지의 EXIF 정보 추출하기 (이전에 등록된 작업용, 위치 정보는 저장하지 않음)
+func (s *TsboardJobService) extractExif(job models.JobItem) error {
+	exif := utils.ExtractExif("." + job.Path)
+	exif.Latitude, exif.Longitude = 0, 0
+	utils.StripImageMetadata("." + job.Path)
+	s.repos.BoardEdit.InsertExif(job.FileUid, job.PostUid, exif)
+	return nil
+}
+
+// 첨부된 이미지의 썸네일로 설명글 생성하기 (기존 설명글이 있다면 교체)
+func (s *TsboardJobService) describeImage(job models.JobItem) error {
+	describer := utils.NewImageDescriber()
+	if describer == nil {
+		return fmt.Errorf("image describer is not configured")
+	}
+	opt := utils.MergeDescribeOption(s.repos.Board.GetDescribeOption(job.BoardUid))
+	description, err := describer.Describe("."+job.Path, opt)
+	if err != nil {
+		return err
+	}
+	s.repos.BoardView.RemoveImageDescription(job.FileUid)
+	s.repos.BoardEdit.InsertImageDescription(job.FileUid, job.PostUid, utils.CutString(description, 500))
+	return nil
+}
+
+// 갤러리/블로그 게시판이면서 생성할 너비가 지정된 경우에만 변형 이미지 옵션 반환
+func (s *TsboardJobService) getVariantOption(boardUid uint) (models.BoardVariantOption, bool) {
+	config := s.repos.BoardView.GetBasicBoardConfig(boardUid)
+	if config.Type != models.BOARD_GALLERY && config.Type != models.BOARD_BLOG {
+		return models.BoardVariantOption{}, false
+	}
+	opt := utils.MergeVariantOption(s.repos.Board.GetVariantOption(boardUid))
+	return opt, len(opt.Widths) > 0
+}
+
+// 첨부된 이미지의 반응형 변형 이미지들 생성하기 (기존 변형 이미지가 있다면 교체)
+func (s *TsboardJobService) makeVariants(job models.JobItem) error {
+	opt, isNeeded := s.getVariantOption(job.BoardUid)
+	if !isNeeded {
+		return nil
+	}
+	variants, err := utils.SaveImageVariants("."+job.Path, opt.Widths, opt.Avif)
+	if err != nil {
+		for _, variant := range variants {
+			os.Remove("." + variant.Path)
+		}
+		return err
+	}
+	removes := s.repos.BoardView.RemoveImageVariants(job.FileUid)
+	changePostStorageUsage(s.repos, job.PostUid, removes, false)
+	for _, path := range removes {
+		os.Remove("." + path)
+	}
+	if len(variants) < 1 {
+		s.repos.BoardEdit.InsertImageVariants(job.FileUid, job.PostUid, []models.BoardImageVariant{
+			{Width: slices.Min(opt.Widths), Format: models.VARIANT_FORMAT_NONE},
+		})
+		return nil
+	}
+	s.repos.BoardEdit.InsertImageVariants(job.FileUid, job.PostUid, variants)
+
+	paths := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		paths = append(paths, variant.Path)
+	}
+	changePostStorageUsage(s.repos, job.PostUid, paths, true)
+	return nil
+}
+
+// 요청받은 게시글 혹은 게시판 전체를 압축 파일로 내보내기 (FileUid 는 내보내기 고유번호)
+func (s *TsboardJobService) makeExport(job models.JobItem) error {
+	return runExport(s.repos, s.repos.Export.GetExport(job.FileUid))
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type MentionService interface {
+	GetSuggestionNames(input string, actionUserUid uint, bunch uint) []models.UserBasicInfo
+	SaveMentions(param models.MentionParameter)
+}
+
+type TsboardMentionService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardMentionService(repos *repositories.Repository) *TsboardMentionService {
+	return &TsboardMentionService{repos: repos}
+}
+
+// 언급할 회원 이름 추천하기
+func (s *TsboardMentionService) GetSuggestionNames(input string, actionUserUid uint, bunch uint) []models.UserBasicInfo {
+	return s.repos.Mention.GetSuggestionNames(input, actionUserUid, bunch)
+}
+
+// 본문 속 언급들을 기록하고 새로 언급된 회원에게 알림 보내기 (승인 대기 중이거나 예약된 게시글은 공개될 때 처리)
+func (s *TsboardMentionService) SaveMentions(param models.MentionParameter) {
+	if isWaiting := s.repos.Moderation.IsWaiting(param.PostUid, param.CommentUid); isWaiting {
+		return
+	}
+	if status := s.repos.Comment.GetPostStatus(param.PostUid); status == models.CONTENT_PENDING {
+		return
+	}
+	saveMentions(s.repos, param)
+}
+
+// 언급들을 기록하고 새로 언급된 회원에게 알림 보내기 (나를 차단한 회원, 글을 볼 수 없는 회원은 알림 제외)
+func saveMentions(repos *repositories.Repository, param models.MentionParameter) {
+	targets := repos.Mention.FindUserUidsByNames(utils.ExtractMentions(param.Content))
+	mentioned := repos.Mention.GetMentionedUids(param.PostUid, param.CommentUid)
+	for _, targetUid := range mentioned {
+		if !slices.Contains(targets, targetUid) {
+			repos.Mention.RemoveMention(param.PostUid, param.CommentUid, targetUid)
+		}
+	}
+
+	status := repos.Comment.GetPostStatus(param.PostUid)
+	isPublic := status == models.CONTENT_NORMAL || status == models.CONTENT_NOTICE
+	needLv, _ := repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_VIEW)
+
+	for _, targetUid := range targets {
+		if targetUid == param.UserUid || slices.Contains(mentioned, targetUid) {
+			continue
+		}
+		if isBanned := repos.User.IsBannedByTarget(param.UserUid, targetUid); isBanned {
+			continue
+		}
+		repos.Mention.InsertMention(param, targetUid)
+
+		if targetLv, _ := repos.User.GetUserLevelPoint(targetUid); !isPublic || targetLv < needLv {
+			continue
+		}
+		repos.Noti.InsertNotification(models.InsertNotificationParameter{
+			ActionUserUid: param.UserUid,
+			TargetUserUid: targetUid,
+			NotiType:      models.NOTI_MENTION,
+			PostUid:       param.PostUid,
+			CommentUid:    param.CommentUid,
+		})
+	}
+}
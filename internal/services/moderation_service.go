@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type ModerationService interface {
+	Approve(moderationUid uint, moderatorUid uint) (models.MentionParameter, error)
+	ChangeModerationOption(boardUid uint, opt models.BoardModerationOption) error
+	GetModerationList(param models.ModerationListParameter) models.ModerationListResult
+	GetModerationOption(boardUid uint) models.BoardModerationOption
+	Reject(moderationUid uint, moderatorUid uint, reason string) error
+}
+
+type TsboardModerationService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardModerationService(repos *repositories.Repository) *TsboardModerationService {
+	return &TsboardModerationService{repos: repos}
+}
+
+// 승인 대기중인 게시글 혹은 댓글을 공개하고 작성자에게 알리기 (본문 속 언급 처리에 필요한 파라미터 반환)
+func (s *TsboardModerationService) Approve(moderationUid uint, moderatorUid uint) (models.MentionParameter, error) {
+	record, err := s.findWaitingRecord(moderationUid)
+	if err != nil {
+		return models.MentionParameter{}, err
+	}
+
+	if record.CommentUid > 0 {
+		s.repos.Moderation.PublishComment(record.CommentUid)
+	} else {
+		s.repos.Moderation.PublishPost(record.PostUid)
+	}
+	s.repos.Moderation.UpdateDecision(record.Uid, models.MODERATION_APPROVED, "", moderatorUid)
+	s.notify(record, moderatorUid, models.NOTI_MODERATION_APPROVED)
+
+	if record.CommentUid > 0 {
+		targetUserUid := s.repos.Comment.GetPostWriterUid(record.PostUid)
+		if targetUserUid != record.UserUid {
+			s.repos.Noti.InsertNotification(models.InsertNotificationParameter{
+				ActionUserUid: record.UserUid,
+				TargetUserUid: targetUserUid,
+				NotiType:      models.NOTI_LEAVE_COMMENT,
+				PostUid:       record.PostUid,
+				CommentUid:    record.CommentUid,
+			})
+		}
+	}
+
+	_, content := s.repos.Moderation.GetSummary(record)
+	return models.MentionParameter{
+		BoardUid:   record.BoardUid,
+		PostUid:    record.PostUid,
+		CommentUid: record.CommentUid,
+		UserUid:    record.UserUid,
+		Content:    content,
+	}, nil
+}
+
+// 게시판별 승인 대기 옵션 변경하기
+func (s *TsboardModerationService) ChangeModerationOption(boardUid uint, opt models.BoardModerationOption) error {
+	if !opt.Mode.IsValid() {
+		return fmt.Errorf("invalid moderation mode")
+	}
+	if opt.Mode == models.MODERATION_FIRST_POSTS && opt.Threshold < 1 {
+		return fmt.Errorf("number of posts to approve should be at least 1")
+	}
+	return s.repos.Moderation.UpdateModerationOption(boardUid, opt)
+}
+
+// (관리화면) 승인 대기 목록 가져오기
+func (s *TsboardModerationService) GetModerationList(param models.ModerationListParameter) models.ModerationListResult {
+	param.MaxUid = s.repos.Board.GetMaxUid(models.TABLE_MODERATION)
+	items := make([]models.ModerationItem, 0)
+
+	for _, record := range s.repos.Moderation.GetModerationList(param) {
+		title, content := s.repos.Moderation.GetSummary(record)
+		items = append(items, models.ModerationItem{
+			Uid:        record.Uid,
+			Board:      s.repos.BoardView.GetBasicBoardConfig(record.BoardUid),
+			PostUid:    record.PostUid,
+			CommentUid: record.CommentUid,
+			Title:      title,
+			Content:    content,
+			Writer:     s.repos.Board.GetWriterInfo(record.UserUid),
+			Submitted:  record.Submitted,
+		})
+	}
+	return models.ModerationListResult{
+		Items:  items,
+		MaxUid: param.MaxUid,
+	}
+}
+
+// 게시판별 승인 대기 옵션 가져오기
+func (s *TsboardModerationService) GetModerationOption(boardUid uint) models.BoardModerationOption {
+	return s.repos.Moderation.GetModerationOption(boardUid)
+}
+
+// 승인 대기중인 게시글 혹은 댓글을 공개하지 않은 채 휴지통으로 옮기고 작성자에게 사유와 함께 알리기 (관리자가 복원하면 공개 상태로)
+func (s *TsboardModerationService) Reject(moderationUid uint, moderatorUid uint, reason string) error {
+	record, err := s.findWaitingRecord(moderationUid)
+	if err != nil {
+		return err
+	}
+
+	if record.CommentUid > 0 {
+		if trashUid := s.repos.Trash.MoveCommentToTrash(record.CommentUid, moderatorUid, false); trashUid != models.FAILED {
+			s.repos.Trash.UpdatePrevStatus(trashUid, models.CONTENT_NORMAL)
+		}
+	} else {
+		status := s.repos.Moderation.GetPublishStatus(record.PostUid)
+		if trashUid := s.repos.Trash.MovePostToTrash(record.PostUid, moderatorUid); trashUid != models.FAILED {
+			s.repos.Trash.UpdatePrevStatus(trashUid, status)
+		}
+	}
+	s.repos.Moderation.UpdateDecision(record.Uid, models.MODERATION_REJECTED, reason, moderatorUid)
+	s.notify(record, moderatorUid, models.NOTI_MODERATION_REJECTED)
+	return nil
+}
+
+// 아직 검토하지 않은 승인 대기 레코드 가져오기
+func (s *TsboardModerationService) findWaitingRecord(moderationUid uint) (models.ModerationRecord, error) {
+	record, err := s.repos.Moderation.GetModerationRecord(moderationUid)
+	if err != nil {
+		return record, fmt.Errorf("unable to find the item in moderation queue")
+	}
+	if record.Decision != models.MODERATION_WAITING {
+		return record, fmt.Errorf("already decided item")
+	}
+	return record, nil
+}
+
+// 검토 결과를 작성자에게 알리기
+func (s *TsboardModerationService) notify(record models.ModerationRecord, moderatorUid uint, notiType models.Noti) {
+	if record.UserUid == moderatorUid {
+		return
+	}
+	s.repos.Noti.InsertNotification(models.InsertNotificationParameter{
+		ActionUserUid: moderatorUid,
+		TargetUserUid: record.UserUid,
+		NotiType:      notiType,
+		PostUid:       record.PostUid,
+		CommentUid:    record.CommentUid,
+	})
+}
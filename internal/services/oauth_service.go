@@ -37,6 +37,7 @@ func (s *TsboardOAuthService) SaveProfileImage(userUid uint, profile string) {
 	newSavePath := fmt.Sprintf("%s/%s.webp", dirPath, uuid.New().String())
 	utils.DownloadImage(profile, newSavePath, configs.SIZE_PROFILE.Number())
 	s.repos.User.UpdateUserProfile(userUid, newSavePath[1:])
+	changeStorageUsage(s.repos, userUid, models.STORAGE_PROFILE, []string{newSavePath[1:]}, true)
 }
 
 // OAuth 로그인 시 미가입 상태이면 바로 등록해주기 (프로필도 있으면 함께)
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type PollService interface {
+	GetPollResult(boardUid uint, postUid uint, userUid uint) (models.PollResult, error)
+	Vote(param models.PollVoteParameter) error
+}
+
+type TsboardPollService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardPollService(repos *repositories.Repository) *TsboardPollService {
+	return &TsboardPollService{repos: repos}
+}
+
+// 게시글에 등록된 투표와 현재까지의 결과 가져오기
+func (s *TsboardPollService) GetPollResult(boardUid uint, postUid uint, userUid uint) (models.PollResult, error) {
+	poll, err := s.getVisiblePoll(boardUid, postUid, userUid)
+	if err != nil {
+		return poll, err
+	}
+
+	now := uint64(time.Now().UnixMilli())
+	poll.IsOpen = poll.OpenAt <= now && (poll.CloseAt == 0 || now < poll.CloseAt)
+	poll.TotalVoters = s.repos.Poll.GetVoterCount(poll.Uid)
+	poll.Options = s.repos.Poll.GetPollOptions(poll.Uid, !poll.Anonymous)
+	poll.Voted = s.repos.Poll.GetVotedOptions(poll.Uid, userUid)
+	return poll, nil
+}
+
+// 투표하기 (단일 선택 투표는 하나만, 회원당 한 번만 가능)
+func (s *TsboardPollService) Vote(param models.PollVoteParameter) error {
+	if param.UserUid < 1 {
+		return fmt.Errorf("please log in to vote")
+	}
+	poll, err := s.getVisiblePoll(param.BoardUid, param.PostUid, param.UserUid)
+	if err != nil {
+		return err
+	}
+
+	now := uint64(time.Now().UnixMilli())
+	if poll.OpenAt > now {
+		return fmt.Errorf("this poll is not open yet")
+	}
+	if poll.CloseAt > 0 && poll.CloseAt <= now {
+		return fmt.Errorf("this poll has been closed")
+	}
+	if len(param.OptionUids) < 1 || (!poll.Multiple && len(param.OptionUids) > 1) {
+		return fmt.Errorf("invalid number of options")
+	}
+
+	optionUids := make([]uint, 0)
+	for _, option := range s.repos.Poll.GetPollOptions(poll.Uid, false) {
+		optionUids = append(optionUids, option.Uid)
+	}
+	for _, optionUid := range param.OptionUids {
+		if !slices.Contains(optionUids, optionUid) {
+			return fmt.Errorf("invalid option uid")
+		}
+	}
+	slices.Sort(param.OptionUids)
+	return s.repos.Poll.InsertVote(poll.Uid, param.UserUid, slices.Compact(param.OptionUids))
+}
+
+// 게시글을 볼 수 있는 사용자에게만 투표 정보 반환하기
+func (s *TsboardPollService) getVisiblePoll(boardUid uint, postUid uint, userUid uint) (models.PollResult, error) {
+	poll, err := s.repos.Poll.GetPoll(postUid)
+	if err != nil || poll.BoardUid != boardUid {
+		return poll, fmt.Errorf("unable to find a poll in this post")
+	}
+
+	userLv, _ := s.repos.User.GetUserLevelPoint(userUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(boardUid, models.BOARD_ACTION_VIEW)
+	if userLv < needLv {
+		return poll, fmt.Errorf("level restriction")
+	}
+
+	status := s.repos.Comment.GetPostStatus(postUid)
+	if status == models.CONTENT_REMOVED {
+		return poll, fmt.Errorf("post has been removed")
+	}
+	if status == models.CONTENT_SECRET || status == models.CONTENT_PENDING {
+		isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, boardUid)
+		if !isAdmin && poll.UserUid != userUid {
+			return poll, fmt.Errorf("you have no permission to see this poll")
+		}
+	}
+	return poll, nil
+}
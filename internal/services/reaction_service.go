@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type ReactionService interface {
+	GetReactionUsers(param models.ReactionUserParameter) (models.ReactionUserResult, error)
+	React(param models.ReactionParameter) ([]models.ReactionCount, error)
+}
+
+type TsboardReactionService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardReactionService(repos *repositories.Repository) *TsboardReactionService {
+	return &TsboardReactionService{repos: repos}
+}
+
+// 게시글 혹은 댓글에 반응을 남긴 회원 목록 가져오기
+func (s *TsboardReactionService) GetReactionUsers(param models.ReactionUserParameter) (models.ReactionUserResult, error) {
+	result := models.ReactionUserResult{Items: make([]models.ReactionUserItem, 0)}
+	if err := s.checkTarget(param.ReactionParameter); err != nil {
+		return result, err
+	}
+	result.Total = s.repos.Reaction.GetReactionUserCount(param)
+	result.Items = s.repos.Reaction.GetReactionUsers(param)
+	return result, nil
+}
+
+// 게시글 혹은 댓글에 반응 남기기 (이미 남긴 이모지면 취소, 하나만 허용하는 게시판이면 기존 반응을 교체)
+func (s *TsboardReactionService) React(param models.ReactionParameter) ([]models.ReactionCount, error) {
+	if param.UserUid < 1 {
+		return nil, fmt.Errorf("please log in to react")
+	}
+	if err := s.checkTarget(param); err != nil {
+		return nil, err
+	}
+	opt := utils.MergeReactionOption(s.repos.Board.GetReactionOption(param.BoardUid))
+	reacted := s.repos.Reaction.GetUserReactions(param.Table, param.TargetUid, param.UserUid)
+	if slices.Contains(reacted, param.Emoji) {
+		s.repos.Reaction.RemoveReaction(param)
+		if param.Emoji == models.REACTION_LIKE {
+			s.updateLegacyLike(param, false)
+		}
+	} else {
+		if !slices.Contains(opt.Emojis, param.Emoji) {
+			return nil, fmt.Errorf("this emoji is not allowed in this board")
+		}
+		if !opt.Multiple && len(reacted) > 0 {
+			s.repos.Reaction.RemoveUserReactions(param.Table, param.TargetUid, param.UserUid)
+			if slices.Contains(reacted, models.REACTION_LIKE) {
+				s.updateLegacyLike(param, false)
+			}
+		}
+		if err := s.repos.Reaction.InsertReaction(param); err != nil {
+			return nil, err
+		}
+		if param.Emoji == models.REACTION_LIKE {
+			s.updateLegacyLike(param, true)
+		}
+	}
+	return s.repos.Board.GetReactions(param.Table, param.TargetUid, param.UserUid), nil
+}
+
+// 반응 대상을 볼 수 있는 사용자인지 확인하기
+func (s *TsboardReactionService) checkTarget(param models.ReactionParameter) error {
+	if param.Table != models.TABLE_POST_REACTION && param.Table != models.TABLE_COMMENT_REACTION {
+		return fmt.Errorf("invalid reaction target")
+	}
+	boardUid, postUid, status := s.repos.Reaction.FindTarget(param.Table, param.TargetUid)
+	if boardUid < 1 || boardUid != param.BoardUid || status == models.CONTENT_REMOVED {
+		return fmt.Errorf("unable to find the target to react")
+	}
+
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_VIEW)
+	if userLv < needLv {
+		return fmt.Errorf("level restriction")
+	}
+
+	postStatus := s.repos.Comment.GetPostStatus(postUid)
+	if postStatus == models.CONTENT_REMOVED {
+		return fmt.Errorf("post has been removed")
+	}
+	if postStatus == models.CONTENT_SECRET || postStatus == models.CONTENT_PENDING {
+		isAdmin := s.repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid)
+		isAuthor := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, param.UserUid)
+		if !isAdmin && !isAuthor {
+			return fmt.Errorf("you have no permission to see this post")
+		}
+	}
+	return nil
+}
+
+// 기본 반응(👍)을 남기거나 취소하면 기존 좋아요에도 반영하기
+func (s *TsboardReactionService) updateLegacyLike(param models.ReactionParameter, liked bool) {
+	if param.Table == models.TABLE_COMMENT_REACTION {
+		like := models.CommentLikeParameter{
+			BoardUid:   param.BoardUid,
+			CommentUid: param.TargetUid,
+			UserUid:    param.UserUid,
+			Liked:      liked,
+		}
+		if isLiked := s.repos.Comment.IsLikedComment(param.TargetUid, param.UserUid); isLiked {
+			s.repos.Comment.UpdateLikeComment(like)
+		} else {
+			s.repos.Comment.InsertLikeComment(like)
+		}
+		return
+	}
+
+	like := models.BoardViewLikeParameter{
+		BoardViewCommonParameter: models.BoardViewCommonParameter{
+			BoardUid: param.BoardUid,
+			PostUid:  param.TargetUid,
+			UserUid:  param.UserUid,
+		},
+		Liked: liked,
+	}
+	if isLiked := s.repos.BoardView.IsLikedPost(param.TargetUid, param.UserUid); isLiked {
+		s.repos.BoardView.UpdateLikePost(like)
+	} else {
+		s.repos.BoardView.InsertLikePost(like)
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type SeriesService interface {
+	CreateSeries(param models.SeriesParameter) (uint, error)
+	GetSeriesList(boardUid uint, userUid uint) ([]models.SeriesItem, error)
+	GetSeriesView(seriesUid uint, userUid uint) (models.SeriesViewResult, error)
+	ModifySeries(param models.SeriesParameter) error
+	RemoveSeries(seriesUid uint, userUid uint) error
+	UpdateSeriesPosts(param models.SeriesPostParameter) error
+}
+
+type TsboardSeriesService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardSeriesService(repos *repositories.Repository) *TsboardSeriesService {
+	return &TsboardSeriesService{repos: repos}
+}
+
+// 게시판에 새 시리즈 만들기 (글쓰기 권한이 있는 회원만 가능)
+func (s *TsboardSeriesService) CreateSeries(param models.SeriesParameter) (uint, error) {
+	if param.UserUid < 1 {
+		return models.FAILED, fmt.Errorf("please log in to create a series")
+	}
+	if len(param.Name) < 1 {
+		return models.FAILED, fmt.Errorf("name of series is empty")
+	}
+	if hasPerm := s.repos.Auth.CheckPermissionForAction(param.UserUid, models.USER_ACTION_WRITE_POST); !hasPerm {
+		return models.FAILED, fmt.Errorf("you have no permission to write post")
+	}
+
+	userLv, _ := s.repos.User.GetUserLevelPoint(param.UserUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(param.BoardUid, models.BOARD_ACTION_WRITE)
+	if userLv < needLv {
+		return models.FAILED, fmt.Errorf("level restriction")
+	}
+
+	seriesUid := s.repos.Series.InsertSeries(param)
+	if seriesUid == models.FAILED {
+		return models.FAILED, fmt.Errorf("unable to create a series")
+	}
+	return seriesUid, nil
+}
+
+// 게시판에 등록된 시리즈 목록 가져오기
+func (s *TsboardSeriesService) GetSeriesList(boardUid uint, userUid uint) ([]models.SeriesItem, error) {
+	if err := s.checkViewLevel(boardUid, userUid); err != nil {
+		return nil, err
+	}
+	return s.repos.Series.GetSeriesList(boardUid), nil
+}
+
+// 시리즈 정보와 속한 게시글들 순서대로 가져오기
+func (s *TsboardSeriesService) GetSeriesView(seriesUid uint, userUid uint) (models.SeriesViewResult, error) {
+	result := models.SeriesViewResult{}
+	series, err := s.repos.Series.GetSeries(seriesUid)
+	if err != nil {
+		return result, fmt.Errorf("unable to find the series")
+	}
+	if err := s.checkViewLevel(series.BoardUid, userUid); err != nil {
+		return result, err
+	}
+
+	result.Series = series
+	result.Posts = s.repos.Series.GetSeriesPosts(seriesUid)
+	return result, nil
+}
+
+// 시리즈 이름, 설명 수정하기
+func (s *TsboardSeriesService) ModifySeries(param models.SeriesParameter) error {
+	if len(param.Name) < 1 {
+		return fmt.Errorf("name of series is empty")
+	}
+	if _, err := s.getManageableSeries(param.SeriesUid, param.UserUid); err != nil {
+		return err
+	}
+	s.repos.Series.UpdateSeries(param)
+	return nil
+}
+
+// 시리즈 삭제하기 (속한 게시글들은 삭제하지 않음)
+func (s *TsboardSeriesService) RemoveSeries(seriesUid uint, userUid uint) error {
+	if _, err := s.getManageableSeries(seriesUid, userUid); err != nil {
+		return err
+	}
+	s.repos.Series.RemoveSeries(seriesUid)
+	return nil
+}
+
+// 시리즈에 속할 게시글들과 순서 지정하기 (같은 게시판의 시리즈 작성자 글만 가능)
+func (s *TsboardSeriesService) UpdateSeriesPosts(param models.SeriesPostParameter) error {
+	series, err := s.getManageableSeries(param.SeriesUid, param.UserUid)
+	if err != nil {
+		return err
+	}
+
+	postUids := make([]uint, 0)
+	for _, postUid := range param.PostUids {
+		if !slices.Contains(postUids, postUid) {
+			postUids = append(postUids, postUid)
+		}
+	}
+	if len(postUids) > models.SERIES_MAX_POSTS {
+		return fmt.Errorf("too many posts in a series, up to %d", models.SERIES_MAX_POSTS)
+	}
+
+	for _, postUid := range postUids {
+		if boardUid := s.repos.Admin.FindBoardUidByPostUid(postUid); boardUid != series.BoardUid {
+			return fmt.Errorf("post %d is not in this board", postUid)
+		}
+		if status := s.repos.Comment.GetPostStatus(postUid); status == models.CONTENT_REMOVED {
+			return fmt.Errorf("post %d has been removed", postUid)
+		}
+		if isWriter := s.repos.BoardView.IsWriter(models.TABLE_POST, postUid, series.Writer.UserUid); !isWriter {
+			return fmt.Errorf("post %d is not written by the owner of series", postUid)
+		}
+	}
+	return s.repos.Series.UpdateSeriesPosts(series.Uid, postUids)
+}
+
+// 게시판을 볼 수 있는 레벨인지 확인하기
+func (s *TsboardSeriesService) checkViewLevel(boardUid uint, userUid uint) error {
+	userLv, _ := s.repos.User.GetUserLevelPoint(userUid)
+	needLv, _ := s.repos.BoardView.GetNeededLevelPoint(boardUid, models.BOARD_ACTION_VIEW)
+	if userLv < needLv {
+		return fmt.Errorf("level restriction")
+	}
+	return nil
+}
+
+// 시리즈 작성자 혹은 게시판 관리자만 관리할 수 있는 시리즈 가져오기
+func (s *TsboardSeriesService) getManageableSeries(seriesUid uint, userUid uint) (models.SeriesItem, error) {
+	series, err := s.repos.Series.GetSeries(seriesUid)
+	if err != nil {
+		return series, fmt.Errorf("unable to find the series")
+	}
+	isAdmin := s.repos.Auth.CheckPermissionByUid(userUid, series.BoardUid)
+	if !isAdmin && series.Writer.UserUid != userUid {
+		return series, fmt.Errorf("only the owner can manage this series")
+	}
+	return series, nil
+}
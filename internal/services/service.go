@@ -4,34 +4,64 @@ import "github.com/sirini/goapi/internal/repositories"
 
 // 모든 서비스들을 관리
 type Service struct {
-	Admin   AdminService
-	Auth    AuthService
-	Board   BoardService
-	Blog    BlogService
-	Chat    ChatService
-	Comment CommentService
-	Home    HomeService
-	Noti    NotiService
-	OAuth   OAuthService
-	Sync    SyncService
-	Trade   TradeService
-	User    UserService
+	Admin      AdminService
+	Auth       AuthService
+	Board      BoardService
+	Blog       BlogService
+	Bookmark   BookmarkService
+	Chat       ChatService
+	Comment    CommentService
+	Draft      DraftService
+	Export     ExportService
+	Hashtag    HashtagService
+	Home       HomeService
+	Import     ImportService
+	Job        JobService
+	Mention    MentionService
+	Moderation ModerationService
+	Noti       NotiService
+	OAuth      OAuthService
+	Poll       PollService
+	Reaction   ReactionService
+	Series     SeriesService
+	Spam       SpamService
+	Sync       SyncService
+	Trade      TradeService
+	Trash      TrashService
+	Unfurl     UnfurlService
+	User       UserService
+	View       ViewService
 }
 
 // 모든 서비스들을 생성
 func NewService(repos *repositories.Repository) *Service {
 	return &Service{
-		Admin:   NewTsboardAdminService(repos),
-		Auth:    NewTsboardAuthService(repos),
-		Board:   NewTsboardBoardService(repos),
-		Blog:    NewTsboardBlogService(repos),
-		Chat:    NewTsboardChatService(repos),
-		Comment: NewTsboardCommentService(repos),
-		Home:    NewTsboardHomeService(repos),
-		Noti:    NewTsboardNotiService(repos),
-		OAuth:   NewTsboardOAuthService(repos),
-		Sync:    NewTsboardSyncService(repos),
-		Trade:   NewTsboardTradeService(repos),
-		User:    NewTsboardUserService(repos),
+		Admin:      NewTsboardAdminService(repos),
+		Auth:       NewTsboardAuthService(repos),
+		Board:      NewTsboardBoardService(repos),
+		Blog:       NewTsboardBlogService(repos),
+		Bookmark:   NewTsboardBookmarkService(repos),
+		Chat:       NewTsboardChatService(repos),
+		Comment:    NewTsboardCommentService(repos),
+		Draft:      NewTsboardDraftService(repos),
+		Export:     NewTsboardExportService(repos),
+		Hashtag:    NewTsboardHashtagService(repos),
+		Home:       NewTsboardHomeService(repos),
+		Import:     NewTsboardImportService(repos),
+		Job:        NewTsboardJobService(repos),
+		Mention:    NewTsboardMentionService(repos),
+		Moderation: NewTsboardModerationService(repos),
+		Noti:       NewTsboardNotiService(repos),
+		OAuth:      NewTsboardOAuthService(repos),
+		Poll:       NewTsboardPollService(repos),
+		Reaction:   NewTsboardReactionService(repos),
+		Series:     NewTsboardSeriesService(repos),
+		Spam:       NewTsboardSpamService(repos),
+		Sync:       NewTsboardSyncService(repos),
+		Trade:      NewTsboardTradeService(repos),
+		Trash:      NewTsboardTrashService(repos),
+		Unfurl:     NewTsboardUnfurlService(repos),
+		User:       NewTsboardUserService(repos),
+		View:       NewTsboardViewService(repos),
 	}
 }
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type SpamService interface {
+	AddSpamRule(rule models.SpamRule) (uint, error)
+	GetSpamLogList(param models.SpamLogListParameter) models.SpamLogListResult
+	GetSpamRules() models.AdminSpamRuleResult
+	RemoveSpamRule(ruleUid uint) error
+}
+
+type TsboardSpamService struct {
+	repos *repositories.Repository
+}
+
+// 컴파일해둔 금지어 규칙 (규칙이 바뀌거나 주기가 지나면 다시 불러옴)
+type compiledSpamRule struct {
+	rule    models.SpamRule
+	pattern *regexp.Regexp
+}
+
+var spamRuleCache struct {
+	mu     sync.Mutex
+	rules  []compiledSpamRule
+	loaded time.Time
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardSpamService(repos *repositories.Repository) *TsboardSpamService {
+	return &TsboardSpamService{repos: repos}
+}
+
+// 새 금지어 규칙 추가하기 (정규식은 미리 검사)
+func (s *TsboardSpamService) AddSpamRule(rule models.SpamRule) (uint, error) {
+	if !rule.Type.IsValid() || !rule.Action.IsValid() {
+		return models.FAILED, fmt.Errorf("invalid rule type or action")
+	}
+	if _, err := utils.CompileSpamRule(rule); err != nil {
+		return models.FAILED, fmt.Errorf("invalid pattern, %s", err.Error())
+	}
+	ruleUid := s.repos.Spam.InsertSpamRule(rule)
+	if ruleUid == models.FAILED {
+		return models.FAILED, fmt.Errorf("failed to add a new rule")
+	}
+	resetSpamRules()
+	return ruleUid, nil
+}
+
+// (관리화면) 스팸 검사 기록 가져오기
+func (s *TsboardSpamService) GetSpamLogList(param models.SpamLogListParameter) models.SpamLogListResult {
+	param.MaxUid = s.repos.Board.GetMaxUid(models.TABLE_SPAM_LOG)
+	items := make([]models.SpamLogItem, 0)
+
+	for _, record := range s.repos.Spam.GetSpamLogList(param) {
+		item := models.SpamLogItem{
+			Uid:       record.Uid,
+			Target:    record.Target,
+			TargetUid: record.TargetUid,
+			Writer:    s.repos.Board.GetWriterInfo(record.UserUid),
+			Check:     record.Check,
+			RuleUid:   record.RuleUid,
+			Action:    record.Action,
+			Detail:    record.Detail,
+			Excerpt:   record.Excerpt,
+			Timestamp: record.Timestamp,
+		}
+		if record.BoardUid > 0 {
+			item.Board = s.repos.BoardView.GetBasicBoardConfig(record.BoardUid)
+		}
+		items = append(items, item)
+	}
+	return models.SpamLogListResult{
+		Items:  items,
+		MaxUid: param.MaxUid,
+	}
+}
+
+// 등록된 금지어 규칙 목록 가져오기
+func (s *TsboardSpamService) GetSpamRules() models.AdminSpamRuleResult {
+	return models.AdminSpamRuleResult{
+		Rules: s.repos.Spam.GetSpamRules(),
+	}
+}
+
+// 금지어 규칙 삭제하기
+func (s *TsboardSpamService) RemoveSpamRule(ruleUid uint) error {
+	err := s.repos.Spam.RemoveSpamRule(ruleUid)
+	resetSpamRules()
+	return err
+}
+
+// 게시글, 댓글, 쪽지를 스팸 필터에 차례로 통과시켜 처리 방법 정하기 (관리자는 검사하지 않고, 쪽지는 짧은 말이 반복되기 쉬워 중복 검사 제외)
+func filterSpam(repos *repositories.Repository, param models.SpamCheckParameter) models.SpamVerdict {
+	verdict := models.SpamVerdict{
+		Action:  models.SPAM_ACTION_PASS,
+		Title:   param.Title,
+		Content: param.Content,
+		Source:  param.Source,
+		Hits:    make([]models.SpamHit, 0),
+	}
+	if isAdmin := repos.Auth.CheckPermissionByUid(param.UserUid, param.BoardUid); isAdmin {
+		return verdict
+	}
+
+	text := strings.Join([]string{param.Title, param.Content, param.Source}, "\n")
+	for _, compiled := range getSpamRules(repos) {
+		rule, pattern := compiled.rule, compiled.pattern
+		match := pattern.FindString(text)
+		if len(match) < 1 {
+			continue
+		}
+		addSpamHit(&verdict, models.SpamHit{Check: models.SPAM_CHECK_RULE, RuleUid: rule.Uid, Action: rule.Action, Detail: match})
+		if rule.Action == models.SPAM_ACTION_MASK {
+			verdict.Title = utils.MaskMatches(pattern, verdict.Title)
+			verdict.Content = utils.MaskMatches(pattern, verdict.Content)
+			verdict.Source = utils.MaskMatches(pattern, verdict.Source)
+		}
+	}
+
+	userLv, _ := repos.User.GetUserLevelPoint(param.UserUid)
+	linkLevel, linkLimit := configs.GetSpamLinkLimit()
+	if count := utils.CountLinks(param.Content); userLv < linkLevel && count > linkLimit {
+		addSpamHit(&verdict, models.SpamHit{
+			Check:  models.SPAM_CHECK_LINK,
+			Action: models.SPAM_ACTION_MODERATE,
+			Detail: fmt.Sprintf("%d links (limit %d)", count, linkLimit),
+		})
+	}
+
+	now := time.Now()
+	isChat := param.Target == models.SPAM_TARGET_CHAT
+	if !isChat && len(param.Content) > 0 && repos.Spam.HasDuplicate(param, now.Add(-configs.GetSpamDuplicateWindow()).UnixMilli()) {
+		addSpamHit(&verdict, models.SpamHit{
+			Check:  models.SPAM_CHECK_DUPLICATE,
+			Action: models.SPAM_ACTION_REJECT,
+			Detail: "same content has already been posted",
+		})
+	}
+
+	if param.TargetUid < 1 {
+		limit, window := configs.GetSpamRateLimit()
+		if isChat {
+			limit, window = configs.GetSpamChatRateLimit()
+		}
+		if count := repos.Spam.GetRecentWriteCount(param.Target, param.UserUid, now.Add(-window).UnixMilli()); count >= uint(limit) {
+			addSpamHit(&verdict, models.SpamHit{
+				Check:  models.SPAM_CHECK_RATE,
+				Action: models.SPAM_ACTION_REJECT,
+				Detail: fmt.Sprintf("%d writes in %s (limit %d)", count, window.String(), limit),
+			})
+		}
+	}
+
+	if verdict.Action < models.SPAM_ACTION_REJECT {
+		if classifier := utils.NewSpamClassifier(); classifier != nil {
+			isSpam, reason, err := classifier.Classify(utils.CutString(param.Title+"\n"+utils.StripTags(param.Content), models.SPAM_MAX_CLASSIFY))
+			if err == nil && isSpam {
+				addSpamHit(&verdict, models.SpamHit{Check: models.SPAM_CHECK_CLASSIFIER, Action: models.SPAM_ACTION_MODERATE, Detail: reason})
+			}
+		}
+	}
+
+	if param.Target == models.SPAM_TARGET_CHAT && verdict.Action == models.SPAM_ACTION_MODERATE {
+		verdict.Action = models.SPAM_ACTION_REJECT
+	}
+	return verdict
+}
+
+// 컴파일해둔 금지어 규칙들 가져오기 (처음이거나 주기가 지났으면 다시 불러와서 컴파일)
+func getSpamRules(repos *repositories.Repository) []compiledSpamRule {
+	spamRuleCache.mu.Lock()
+	defer spamRuleCache.mu.Unlock()
+
+	if spamRuleCache.rules != nil && time.Since(spamRuleCache.loaded) < models.SPAM_RULE_CACHE_TTL {
+		return spamRuleCache.rules
+	}
+	rules := make([]compiledSpamRule, 0)
+	for _, rule := range repos.Spam.GetSpamRules() {
+		pattern, err := utils.CompileSpamRule(rule)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, compiledSpamRule{rule: rule, pattern: pattern})
+	}
+	spamRuleCache.rules = rules
+	spamRuleCache.loaded = time.Now()
+	return rules
+}
+
+// 금지어 규칙이 바뀌었으니 다음 검사 때 다시 불러오도록 비우기
+func resetSpamRules() {
+	spamRuleCache.mu.Lock()
+	defer spamRuleCache.mu.Unlock()
+	spamRuleCache.rules = nil
+}
+
+// 스팸 검사에 걸린 항목 추가하고 가장 강한 처리 방법 남기기
+func addSpamHit(verdict *models.SpamVerdict, hit models.SpamHit) {
+	verdict.Hits = append(verdict.Hits, hit)
+	if hit.Action > verdict.Action {
+		verdict.Action = hit.Action
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+type TrashService interface {
+	GetTrashList(param models.TrashListParameter) models.TrashListResult
+	PurgeExpiredTrash() uint
+	PurgeTrash(trashUid uint) error
+	RestoreComment(boardUid uint, commentUid uint, userUid uint) error
+	RestorePost(boardUid uint, postUid uint, userUid uint) error
+	RestoreTrash(trashUid uint) error
+	Start()
+}
+
+type TsboardTrashService struct {
+	repos *repositories.Repository
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardTrashService(repos *repositories.Repository) *TsboardTrashService {
+	return &TsboardTrashService{repos: repos}
+}
+
+// (관리화면) 휴지통 목록 가져오기
+func (s *TsboardTrashService) GetTrashList(param models.TrashListParameter) models.TrashListResult {
+	param.MaxUid = s.repos.Board.GetMaxUid(models.TABLE_TRASH)
+	retention := uint64(configs.GetTrashRetention().Milliseconds())
+	items := make([]models.TrashItem, 0)
+
+	for _, record := range s.repos.Trash.GetTrashList(param) {
+		items = append(items, models.TrashItem{
+			Uid:       record.Uid,
+			Type:      record.Type,
+			TargetUid: record.TargetUid,
+			Board:     s.repos.BoardView.GetBasicBoardConfig(record.BoardUid),
+			PostUid:   record.PostUid,
+			Content:   s.repos.Trash.GetTrashSummary(record),
+			Writer:    s.repos.Board.GetWriterInfo(record.UserUid),
+			RemovedBy: s.repos.Board.GetWriterInfo(record.RemovedBy),
+			Removed:   record.Removed,
+			PurgeAt:   record.Removed + retention,
+		})
+	}
+	return models.TrashListResult{
+		Items:  items,
+		MaxUid: param.MaxUid,
+	}
+}
+
+// 보관 기간이 지난 휴지통 항목들을 완전히 삭제하고 처리한 개수 반환
+func (s *TsboardTrashService) PurgeExpiredTrash() uint {
+	var count uint
+	removedBefore := time.Now().Add(-configs.GetTrashRetention()).UnixMilli()
+	for {
+		records := s.repos.Trash.FindExpiredTrash(removedBefore, models.TRASH_PURGE_BUNCH)
+		for _, record := range records {
+			s.purge(record)
+		}
+		count += uint(len(records))
+		if len(records) < models.TRASH_PURGE_BUNCH {
+			return count
+		}
+	}
+}
+
+// (관리화면) 휴지통 항목을 보관 기간과 상관없이 바로 완전히 삭제하기
+func (s *TsboardTrashService) PurgeTrash(trashUid uint) error {
+	record, err := s.repos.Trash.GetTrash(trashUid)
+	if err != nil || record.ParentUid > 0 {
+		return fmt.Errorf("unable to find the item in trash")
+	}
+	s.purge(record)
+	return nil
+}
+
+// 휴지통에서 댓글 되살리기 (직접 삭제한 작성자 혹은 관리자만 가능)
+func (s *TsboardTrashService) RestoreComment(boardUid uint, commentUid uint, userUid uint) error {
+	record, err := s.repos.Trash.GetTrashByTarget(models.TRASH_COMMENT, commentUid)
+	if err != nil || record.BoardUid != boardUid {
+		return fmt.Errorf("unable to find the comment in trash")
+	}
+	if !s.canRestore(record, userUid) {
+		return fmt.Errorf("you have no permission to restore this comment")
+	}
+	return s.restore(record)
+}
+
+// 휴지통에서 게시글과 함께 삭제된 댓글들 되살리기 (직접 삭제한 작성자 혹은 관리자만 가능)
+func (s *TsboardTrashService) RestorePost(boardUid uint, postUid uint, userUid uint) error {
+	record, err := s.repos.Trash.GetTrashByTarget(models.TRASH_POST, postUid)
+	if err != nil || record.BoardUid != boardUid {
+		return fmt.Errorf("unable to find the post in trash")
+	}
+	if !s.canRestore(record, userUid) {
+		return fmt.Errorf("you have no permission to restore this post")
+	}
+	return s.restore(record)
+}
+
+// (관리화면) 휴지통 항목 되살리기
+func (s *TsboardTrashService) RestoreTrash(trashUid uint) error {
+	record, err := s.repos.Trash.GetTrash(trashUid)
+	if err != nil {
+		return fmt.Errorf("unable to find the item in trash")
+	}
+	return s.restore(record)
+}
+
+// 보관 기간이 지난 휴지통 항목들을 주기적으로 완전히 삭제하기
+func (s *TsboardTrashService) Start() {
+	go func() {
+		ticker := time.NewTicker(models.TRASH_PURGE_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if count := s.PurgeExpiredTrash(); count > 0 {
+				log.Printf("🗑️  %d items in trash have been purged\n", count)
+			}
+		}
+	}()
+}
+
+// 휴지통 항목을 되살릴 수 있는지 확인하기 (관리자나 승인 검토자가 삭제했다면 관리자만 가능)
+func (s *TsboardTrashService) canRestore(record models.TrashRecord, userUid uint) bool {
+	if s.repos.Auth.CheckPermissionByUid(userUid, record.BoardUid) {
+		return true
+	}
+	return record.UserUid == userUid && record.RemovedBy == userUid
+}
+
+// 휴지통 항목의 게시글 혹은 댓글을 완전히 삭제하기 (게시글은 첨부파일, 태그, 수정 이력도 삭제)
+func (s *TsboardTrashService) purge(record models.TrashRecord) {
+	if record.Type == models.TRASH_POST {
+		s.repos.BoardView.RemovePostTags(record.TargetUid)
+		removes := s.repos.BoardView.RemoveAttachments(record.TargetUid)
+		changePostStorageUsage(s.repos, record.TargetUid, removes, false)
+		for _, path := range removes {
+			os.Remove("." + path)
+		}
+		s.repos.Revision.RemoveRevisions(record.TargetUid)
+		for _, child := range s.repos.Trash.GetTrashChildren(record.Uid) {
+			s.repos.Trash.PurgeTarget(child)
+		}
+	}
+	s.repos.Trash.PurgeTarget(record)
+	s.repos.Trash.RemoveTrash(record.Uid)
+}
+
+// 휴지통 항목을 삭제 전 상태로 되돌리기 (게시글과 함께 삭제된 댓글은 게시글을 통해서만 가능)
+func (s *TsboardTrashService) restore(record models.TrashRecord) error {
+	if record.Type == models.TRASH_COMMENT {
+		if status := s.repos.Comment.GetPostStatus(record.PostUid); status == models.CONTENT_REMOVED {
+			return fmt.Errorf("the post of this comment has been removed, restore the post first")
+		}
+	}
+
+	s.repos.Trash.RestoreTarget(record)
+	if record.Type == models.TRASH_POST {
+		for _, child := range s.repos.Trash.GetTrashChildren(record.Uid) {
+			s.repos.Trash.RestoreTarget(child)
+		}
+	}
+	s.repos.Trash.RemoveTrash(record.Uid)
+	return nil
+}
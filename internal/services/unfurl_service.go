@@ -0,0 +1,107 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type UnfurlService interface {
+	Unfurl(url string) (models.LinkPreview, bool)
+	UnfurlContent(content string)
+}
+
+type TsboardUnfurlService struct {
+	repos    *repositories.Repository
+	mu       sync.Mutex
+	inflight map[string]bool
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardUnfurlService(repos *repositories.Repository) *TsboardUnfurlService {
+	return &TsboardUnfurlService{
+		repos:    repos,
+		inflight: make(map[string]bool),
+	}
+}
+
+// 링크 미리보기 가져오기 (캐시가 유효하면 캐시 사용, 아니면 새로 가져와서 저장)
+func (s *TsboardUnfurlService) Unfurl(url string) (models.LinkPreview, bool) {
+	now := time.Now()
+	record, err := s.repos.Unfurl.FindPreview(url)
+	hasRecord := err == nil
+	if hasRecord {
+		ttl := models.UNFURL_CACHE_TTL
+		if record.Failed {
+			ttl = models.UNFURL_FAILED_TTL
+		}
+		if now.Sub(time.UnixMilli(int64(record.Fetched))) < ttl {
+			return record.LinkPreview, !record.Failed
+		}
+	}
+
+	s.mu.Lock()
+	if s.inflight[url] {
+		s.mu.Unlock()
+		return record.LinkPreview, hasRecord && !record.Failed
+	}
+	s.inflight[url] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, url)
+		s.mu.Unlock()
+	}()
+
+	preview, err := utils.FetchLinkPreview(url)
+	preview.Url = url
+	preview.Fetched = uint64(now.UnixMilli())
+	if err != nil {
+		s.repos.Unfurl.UpsertPreview(models.LinkPreviewRecord{LinkPreview: preview, Failed: true})
+		if hasRecord {
+			removePreviewImage(record.Image, preview.Image)
+		}
+		return preview, false
+	}
+
+	if len(preview.Image) > 0 {
+		savedPath, err := utils.SavePreviewImage(preview.Image)
+		if err != nil {
+			preview.Image = ""
+		} else {
+			preview.Image = savedPath[1:]
+		}
+	}
+	s.repos.Unfurl.UpsertPreview(models.LinkPreviewRecord{LinkPreview: preview})
+	if hasRecord {
+		removePreviewImage(record.Image, preview.Image)
+	}
+	return preview, true
+}
+
+// 갱신되면서 더 이상 쓰이지 않는 이전 미리보기 이미지 파일 삭제하기
+func removePreviewImage(oldPath string, newPath string) {
+	if oldPath == newPath || !strings.HasPrefix(oldPath, "/upload/") {
+		return
+	}
+	os.Remove("." + oldPath)
+}
+
+// 본문 속 링크들의 미리보기를 백그라운드에서 미리 가져오기
+func (s *TsboardUnfurlService) UnfurlContent(content string) {
+	urls := utils.ExtractUrls(content)
+	if len(urls) < 1 {
+		return
+	}
+	go func() {
+		for _, url := range urls {
+			s.Unfurl(url)
+		}
+	}()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/sirini/goapi/internal/configs"
 	"github.com/sirini/goapi/internal/repositories"
 	"github.com/sirini/goapi/pkg/models"
 	"github.com/sirini/goapi/pkg/utils"
@@ -16,6 +17,8 @@ type UserService interface {
 	GetUserInfo(userUid uint) (models.UserInfoResult, error)
 	GetUserLevelPoint(userUid uint) (int, int)
 	GetUserPermission(actionUserUid uint, targetUserUid uint) models.UserPermissionReportResult
+	GetUserStorage(userUid uint) models.UserStorageResult
+	GetUserStorageLeft(userUid uint) int64
 	ReportTargetUser(actionUserUid uint, targetUserUid uint, wantBlock bool, report string) bool
 }
 
@@ -70,7 +73,9 @@ func (s *TsboardUserService) ChangeUserInfo(param models.UpdateUserInfoParameter
 				return err
 			}
 
+			changeStorageUsage(s.repos, param.UserUid, models.STORAGE_PROFILE, []string{s.repos.User.GetProfilePath(param.UserUid)}, false)
 			s.repos.User.UpdateUserProfile(param.UserUid, profilePath[1:])
+			changeStorageUsage(s.repos, param.UserUid, models.STORAGE_PROFILE, []string{profilePath[1:]}, true)
 			err = os.Remove("." + param.OldProfile)
 			if err != nil {
 				return err
@@ -160,6 +165,72 @@ func (s *TsboardUserService) GetUserPermission(actionUserUid uint, targetUserUid
 	return result
 }
 
+// 사용자의 저장 공간 사용량 및 한도 가져오기 (사용량은 처음 한 번만 파일들을 확인해서 집계)
+func (s *TsboardUserService) GetUserStorage(userUid uint) models.UserStorageResult {
+	result, isCounted := s.repos.User.GetStorageUsage(userUid)
+	if !isCounted {
+		result = s.countStorageUsage(userUid)
+		s.repos.User.InsertStorageUsage(userUid, result)
+	}
+	result.Used = result.Attachment + result.Image + result.Profile
+
+	quota, isCustom := s.repos.User.GetStorageQuota(userUid)
+	if !isCustom {
+		level, _ := s.repos.User.GetUserLevelPoint(userUid)
+		quota = configs.GetStorageQuota(level)
+	}
+	result.Quota = quota
+	result.IsCustom = isCustom
+
+	if quota < 1 || userUid == 1 {
+		result.Remain = models.STORAGE_UNLIMITED
+	} else if result.Used >= quota {
+		result.Remain = 0
+	} else {
+		result.Remain = int64(quota - result.Used)
+	}
+	return result
+}
+
+// 사용자가 더 업로드 할 수 있는 용량 가져오기
+func (s *TsboardUserService) GetUserStorageLeft(userUid uint) int64 {
+	return s.GetUserStorage(userUid).Remain
+}
+
+// 사용자가 올린 파일들의 크기를 모두 확인해서 저장 공간 사용량 집계하기
+func (s *TsboardUserService) countStorageUsage(userUid uint) models.UserStorageResult {
+	result := models.UserStorageResult{}
+	for _, path := range s.repos.User.GetAttachedPaths(userUid) {
+		result.Attachment += uint64(utils.GetFileSize(path))
+	}
+	for _, path := range s.repos.User.GetInsertedImagePaths(userUid) {
+		result.Image += uint64(utils.GetFileSize(path))
+	}
+	if profile := s.repos.User.GetProfilePath(userUid); len(profile) > 0 {
+		result.Profile = uint64(utils.GetFileSize(profile))
+	}
+	return result
+}
+
+// 파일들의 크기만큼 사용자의 저장 공간 사용량 바꾸기 (삭제할 때는 파일을 지우기 전에 호출)
+func changeStorageUsage(repos *repositories.Repository, userUid uint, usage models.StorageUsage, paths []string, isAdded bool) {
+	var size int64
+	for _, path := range paths {
+		if len(path) > 0 {
+			size += int64(utils.GetFileSize(path))
+		}
+	}
+	if !isAdded {
+		size = -size
+	}
+	repos.User.AddStorageUsage(userUid, usage, size)
+}
+
+// 게시글에 딸린 파일들의 크기만큼 게시글 작성자의 첨부파일 사용량 바꾸기
+func changePostStorageUsage(repos *repositories.Repository, postUid uint, paths []string, isAdded bool) {
+	changeStorageUsage(repos, repos.Comment.GetPostWriterUid(postUid), models.STORAGE_ATTACHMENT, paths, isAdded)
+}
+
 // 사용자가 특정 유저를 신고하기
 func (s *TsboardUserService) ReportTargetUser(actionUserUid uint, targetUserUid uint, wantBlock bool, report string) bool {
 	isAllowedAction := s.repos.Auth.CheckPermissionForAction(actionUserUid, models.USER_ACTION_SEND_REPORT)
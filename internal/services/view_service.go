@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/internal/repositories"
+	"github.com/sirini/goapi/pkg/models"
+	"github.com/sirini/goapi/pkg/utils"
+)
+
+type ViewService interface {
+	CountView(param models.ViewCountParameter) bool
+	FlushViews() uint
+	GetViewStatistic(postUid uint, days int) models.AdminDashboardStatistic
+	Start()
+}
+
+type TsboardViewService struct {
+	repos   *repositories.Repository
+	mu      sync.Mutex
+	seen    map[string]int64
+	pending map[uint]models.ViewPending
+}
+
+// 리포지토리 묶음 주입받기
+func NewTsboardViewService(repos *repositories.Repository) *TsboardViewService {
+	return &TsboardViewService{
+		repos:   repos,
+		seen:    make(map[string]int64),
+		pending: make(map[uint]models.ViewPending),
+	}
+}
+
+// 봇과 중복 조회를 제외하고 조회수 올리기 (게시글에는 주기적으로 모아서 반영)
+func (s *TsboardViewService) CountView(param models.ViewCountParameter) bool {
+	if utils.IsBotAgent(param.UserAgent) {
+		return false
+	}
+	viewer := fmt.Sprintf("u%d", param.UserUid)
+	if param.UserUid < 1 {
+		viewer = "a" + utils.GetHashedString(param.Ip+param.UserAgent)
+	}
+	key := fmt.Sprintf("%d:%s", param.PostUid, viewer)
+	now := time.Now().UnixMilli()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, isSeen := s.seen[key]; isSeen && now-last < configs.GetViewDedupWindow().Milliseconds() {
+		return false
+	}
+	s.seen[key] = now
+
+	pending := s.pending[param.PostUid]
+	pending.BoardUid = param.BoardUid
+	pending.Count++
+	s.pending[param.PostUid] = pending
+	return true
+}
+
+// 모아둔 조회수를 게시글과 일별 통계에 반영하고, 중복 확인 기간이 지난 기록은 정리하기
+func (s *TsboardViewService) FlushViews() uint {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[uint]models.ViewPending)
+	expired := time.Now().Add(-configs.GetViewDedupWindow()).UnixMilli()
+	for key, last := range s.seen {
+		if last < expired {
+			delete(s.seen, key)
+		}
+	}
+	s.mu.Unlock()
+
+	var count uint
+	for postUid, item := range pending {
+		s.repos.View.UpdatePostHit(postUid, item.Count)
+		s.repos.View.InsertDailyView(item.BoardUid, postUid, item.Count)
+		count += item.Count
+	}
+	return count
+}
+
+// (관리화면) 게시글의 최근 일자별 조회수 가져오기 (postUid 가 0이면 모든 게시글)
+func (s *TsboardViewService) GetViewStatistic(postUid uint, days int) models.AdminDashboardStatistic {
+	return s.repos.View.GetViewStatistic(postUid, days)
+}
+
+// 모아둔 조회수를 주기적으로 게시글에 반영하기
+func (s *TsboardViewService) Start() {
+	go func() {
+		ticker := time.NewTicker(models.VIEW_FLUSH_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.FlushViews()
+		}
+	}()
+}
@@ -108,6 +108,7 @@ type AdminDashboardStatisticResult struct {
 	Reply  AdminDashboardStatistic `json:"reply"`
 	File   AdminDashboardStatistic `json:"file"`
 	Image  AdminDashboardStatistic `json:"image"`
+	View   AdminDashboardStatistic `json:"view"`
 }
 
 // 대시보드 최근 통계 반환값 정의
@@ -250,3 +251,31 @@ type AdminUserInfo struct {
 	Level uint   `json:"level"`
 	Point uint   `json:"point"`
 }
+
+// 이미지 설명글을 다시 생성할 대상 정의
+type AdminDescribeTarget struct {
+	PostUid uint
+	FileUid uint
+	Path    string
+}
+
+// 게시판별 반응 이모지 옵션 반환값 정의
+type AdminBoardReactionResult struct {
+	BoardReactionOption
+	IsCustom bool `json:"isCustom"`
+}
+
+// 게시판별 변형 이미지 생성 옵션 반환값 정의
+type AdminBoardVariantResult struct {
+	BoardVariantOption
+	IsCustom bool `json:"isCustom"`
+}
+
+// 변형 이미지를 새로 만들 첨부 이미지 정의
+type AdminVariantTarget struct {
+	BoardUid     uint
+	PostUid      uint
+	FileUid      uint
+	Path         string
+	SkippedWidth uint
+}
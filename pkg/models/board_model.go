@@ -1,12 +1,21 @@
 package models
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+)
 
 // 기본값들 정의
 const FAILED = 0
 const EXIF_APERTURE_FACTOR = 100
 const EXIF_EXPOSURE_FACTOR = 1000000
 
+// 관련 글 점수 계산용 가중치 및 최대 개수 정의
+const RELATED_TAG_WEIGHT = 3
+const RELATED_CATEGORY_WEIGHT = 1
+const RELATED_MAX_LIMIT = 20
+const RELATED_CANDIDATE_LIMIT = 300
+
 // 게시판 타입 정의
 type Board uint8
 
@@ -43,8 +52,33 @@ const (
 	CONTENT_NORMAL
 	CONTENT_NOTICE
 	CONTENT_SECRET
+	CONTENT_PENDING
 )
 
+// 게시글 본문 형식 정의
+type ContentFormat uint8
+
+// 게시글 본문 형식들
+const (
+	FORMAT_HTML ContentFormat = iota
+	FORMAT_MARKDOWN
+)
+
+// 마크다운 원문 및 변환된 본문의 최대 길이 (바이트)
+const CONTENT_MAX_LENGTH = 4 << 20
+
+func (f ContentFormat) String() string {
+	switch f {
+	case FORMAT_MARKDOWN:
+		return "markdown"
+	default:
+		return "html"
+	}
+}
+
+// 예약 발행 및 게시 종료 처리 주기
+const POST_SCHEDULE_INTERVAL = 30 * time.Second
+
 // 검색 옵션 정의
 type Search uint8
 
@@ -116,12 +150,14 @@ type BoardCommonPostItem struct {
 
 // 게시글 목록보기에 추가로 필요한 리턴 타입 정의
 type BoardCommonListItem struct {
-	Category Pair        `json:"category"`
-	Cover    string      `json:"cover"`
-	Comment  uint        `json:"comment"`
-	Like     uint        `json:"like"`
-	Liked    bool        `json:"liked"`
-	Writer   BoardWriter `json:"writer"`
+	Category  Pair              `json:"category"`
+	Cover     string            `json:"cover"`
+	Comment   uint              `json:"comment"`
+	Like      uint              `json:"like"`
+	Liked     bool              `json:"liked"`
+	Reactions []ReactionCount   `json:"reactions"`
+	Writer    BoardWriter       `json:"writer"`
+	Fields    []BoardFieldValue `json:"fields"`
 }
 
 // 게시글 목록보기용 리턴 타입 정의
@@ -177,7 +213,7 @@ type BoardConfig struct {
 	RowCount    uint             `json:"rowCount"`
 	Width       uint             `json:"width"`
 	UseCategory bool             `json:"useCategory"`
-	Category    []Pair           `json:"category"`
+	Category    []BoardCategory  `json:"category"`
 	Level       BoardActionLevel `json:"level"`
 	Point       BoardActionPoint `json:"point"`
 }
@@ -188,6 +224,7 @@ type BoardListParameter struct {
 	Page        uint
 	Direction   Paging
 	NoticeCount uint
+	Filters     []BoardFieldFilter
 }
 
 // 게시글 목록보기 리턴 값 정의
@@ -198,6 +235,7 @@ type BoardListResult struct {
 	Posts          []BoardListItem `json:"posts"`
 	BlackList      []uint          `json:"blackList"`
 	IsAdmin        bool            `json:"isAdmin"`
+	Fields         []BoardField    `json:"fields"`
 }
 
 // 사용자의 포인트 변경하기에 필요한 파라미터 정의
@@ -243,10 +281,11 @@ type BoardAttachment struct {
 
 // 게시판 첨부 이미지 구조체 정의
 type BoardAttachedImage struct {
-	File        BoardFile      `json:"file"`
-	Thumbnail   BoardThumbnail `json:"thumbnail"`
-	Exif        BoardExif      `json:"exif"`
-	Description string         `json:"description"`
+	File        BoardFile           `json:"file"`
+	Thumbnail   BoardThumbnail      `json:"thumbnail"`
+	Variants    []BoardImageVariant `json:"variants"`
+	Exif        BoardExif           `json:"exif"`
+	Description string              `json:"description"`
 }
 
 // 게시글 작성자의 최근 글/댓글에 전달할 게시판 기본 설정값 정의
@@ -258,15 +297,58 @@ type BoardBasicConfig struct {
 
 // EXIF 구조체 정의
 type BoardExif struct {
-	Make        string `json:"make"`
-	Model       string `json:"model"`
-	Aperture    uint   `json:"aperture"`
-	ISO         uint   `json:"iso"`
-	FocalLength uint   `json:"focalLength"`
-	Exposure    uint   `json:"exposure"`
-	Width       uint   `json:"width"`
-	Height      uint   `json:"height"`
-	Date        uint64 `json:"date"`
+	Make        string  `json:"make"`
+	Model       string  `json:"model"`
+	Lens        string  `json:"lens"`
+	Aperture    uint    `json:"aperture"`
+	ISO         uint    `json:"iso"`
+	FocalLength uint    `json:"focalLength"`
+	Exposure    uint    `json:"exposure"`
+	Width       uint    `json:"width"`
+	Height      uint    `json:"height"`
+	Orientation uint    `json:"orientation"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Date        uint64  `json:"date"`
+}
+
+// 이미지 설명글 제공자 이름들
+const (
+	DESCRIBER_OPENAI = "openai"
+	DESCRIBER_LOCAL  = "local"
+)
+
+// 제공자별 기본 이미지 설명글 모델 (모델을 따로 지정하지 않았을 때 사용)
+const (
+	DESCRIBER_OPENAI_MODEL = "gpt-4o"
+	DESCRIBER_LOCAL_MODEL  = "llava"
+)
+
+// 이미지 설명글 응답을 기다리는 최대 시간 (작업 처리기가 멈춰있지 않도록 제한)
+const DESCRIBER_TIMEOUT = 3 * time.Minute
+
+// 이미지 설명글 생성 옵션 정의 (게시판별로 지정 가능)
+type ImageDescribeOption struct {
+	Model    string `json:"model"`
+	Prompt   string `json:"prompt"`
+	Language string `json:"language"`
+}
+
+// 반응형 이미지(srcset)용 변형 이미지 정의
+type BoardImageVariant struct {
+	Path   string `json:"path"`
+	Width  uint   `json:"width"`
+	Height uint   `json:"height"`
+	Format string `json:"format"`
+}
+
+// 지정된 너비가 모두 원본 이상이라 만들 변형 이미지가 없다는 표시용 형식 (경로는 비우고 너비에 요청했던 가장 작은 너비 기록)
+const VARIANT_FORMAT_NONE = "none"
+
+// 게시판별 변형 이미지 생성 옵션 정의
+type BoardVariantOption struct {
+	Widths []uint `json:"widths"`
+	Avif   bool   `json:"avif"`
 }
 
 // 파일 기본 구조 정의
@@ -303,8 +385,7 @@ type BoardViewDownloadResult struct {
 // 게시글 보기에 필요한 파라미터 정의
 type BoardViewParameter struct {
 	BoardViewCommonParameter
-	UpdateHit bool
-	Limit     uint
+	Limit uint
 }
 
 // 게시글 보기에 반환 타입 정의
@@ -314,10 +395,14 @@ type BoardViewResult struct {
 	Images         []BoardAttachedImage       `json:"images"`
 	Files          []BoardAttachment          `json:"files"`
 	Tags           []Pair                     `json:"tags"`
+	Bookmarked     bool                       `json:"bookmarked"`
 	PrevPostUid    uint                       `json:"prevPostUid"`
 	NextPostUid    uint                       `json:"nextPostUid"`
+	Series         SeriesNavigation           `json:"series"`
 	WriterPosts    []BoardWriterLatestPost    `json:"writerPosts"`
 	WriterComments []BoardWriterLatestComment `json:"writerComments"`
+	RelatedPosts   []BoardRelatedPost         `json:"relatedPosts"`
+	Previews       []LinkPreview              `json:"previews"`
 }
 
 // 게시글 좋아하기에 필요한 파라미터 정의
@@ -352,6 +437,26 @@ type BoardWriterLatestComment struct {
 	Content string `json:"content"`
 }
 
+// 관련 글 가져오기에 필요한 파라미터 정의
+type BoardRelatedParameter struct {
+	PostUid     uint
+	BoardUid    uint
+	CategoryUid uint
+	TagUids     []uint
+	Title       string
+	UserLevel   int
+	UseFulltext bool
+	Limit       uint
+}
+
+// 관련 글 정의 (점수가 높을수록 관련성이 높음)
+type BoardRelatedPost struct {
+	BoardWriterLatestPost
+	Cover string  `json:"cover"`
+	Hit   uint    `json:"hit"`
+	Score float64 `json:"score"`
+}
+
 // 게시글 작성자의 최근 글 정의
 type BoardWriterLatestPost struct {
 	BoardWriterLatestCommon
@@ -361,9 +466,9 @@ type BoardWriterLatestPost struct {
 
 // 에디터에서 게시판 설정 및 카테고리 불러오기 결과 타입 정의
 type EditorConfigResult struct {
-	Config     BoardConfig `json:"config"`
-	IsAdmin    bool        `json:"isAdmin"`
-	Categories []Pair      `json:"categories"`
+	Config     BoardConfig     `json:"config"`
+	IsAdmin    bool            `json:"isAdmin"`
+	Categories []BoardCategory `json:"categories"`
 }
 
 // EXIF 저장할 때 필요한 파라미터 정의
@@ -405,9 +510,21 @@ type EditorInsertImageResult struct {
 
 // 게시글 수정 시 가져오는 정보들 반환 타입 정의
 type EditorLoadPostResult struct {
-	Post  BoardListItem     `json:"post"`
-	Files []BoardAttachment `json:"files"`
-	Tags  []Pair            `json:"tags"`
+	Post     BoardListItem     `json:"post"`
+	Format   string            `json:"format"`
+	Source   string            `json:"source"`
+	Files    []BoardAttachment `json:"files"`
+	Tags     []Pair            `json:"tags"`
+	Schedule PostSchedule      `json:"schedule"`
+	Fields   []BoardFieldValue `json:"fields"`
+}
+
+// 게시글 예약 발행 및 게시 종료 일정 정의
+type PostSchedule struct {
+	PublishAt    uint64 `json:"publishAt"`
+	ExpireAt     uint64 `json:"expireAt"`
+	HideOnExpire bool   `json:"hideOnExpire"`
+	Expired      bool   `json:"expired"`
 }
 
 // 게시글 수정 시 필요한 파라미터 정의
@@ -432,15 +549,25 @@ type EditorTagItem struct {
 
 // 게시글 작성 시 필요한 파라미터 정의
 type EditorWriteParameter struct {
-	BoardUid    uint
-	UserUid     uint
-	CategoryUid uint
-	Title       string
-	Content     string
-	Files       []*multipart.FileHeader
-	Tags        []string
-	IsNotice    bool
-	IsSecret    bool
+	BoardUid     uint
+	UserUid      uint
+	CategoryUid  uint
+	Title        string
+	Content      string
+	Format       ContentFormat
+	Source       string
+	Files        []*multipart.FileHeader
+	Tags         []string
+	IsNotice     bool
+	IsSecret     bool
+	KeepLocation bool
+	DraftUid     uint
+	PublishAt    uint64
+	ExpireAt     uint64
+	HideOnExpire bool
+	IsPending    bool
+	Poll         PollWriteParameter
+	Fields       []BoardFieldValue
 }
 
 // 갤러리 그리드형 반환타입 정의
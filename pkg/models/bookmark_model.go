@@ -0,0 +1,57 @@
+package models
+
+// 북마크 관련 상수 정의
+const (
+	BOOKMARK_MAX_FOLDER = 50
+	BOOKMARK_MAX_NOTE   = 300
+)
+
+// 북마크 추가/수정에 필요한 파라미터 정의
+type BookmarkParameter struct {
+	BoardUid uint
+	PostUid  uint
+	UserUid  uint
+	Folder   string
+	Note     string
+}
+
+// 북마크 목록 가져오기에 필요한 파라미터 정의 (Folder 가 비어 있으면 전체)
+type BookmarkListParameter struct {
+	UserUid   uint
+	UserLevel int
+	Folder    string
+	Page      uint
+	Bunch     uint
+}
+
+// 북마크 레코드 정의
+type BookmarkRecord struct {
+	Uid       uint
+	BoardUid  uint
+	PostUid   uint
+	Folder    string
+	Note      string
+	Timestamp uint64
+}
+
+// 북마크 목록의 항목 정의
+type BookmarkItem struct {
+	Uid       uint             `json:"uid"`
+	Board     BoardBasicConfig `json:"board"`
+	Post      BoardListItem    `json:"post"`
+	Folder    string           `json:"folder"`
+	Note      string           `json:"note"`
+	Timestamp uint64           `json:"timestamp"`
+}
+
+// 북마크 목록 반환값 정의
+type BookmarkListResult struct {
+	Items []BookmarkItem `json:"items"`
+	Total uint           `json:"total"`
+}
+
+// 북마크 폴더와 담긴 북마크 수 정의
+type BookmarkFolder struct {
+	Name  string `json:"name"`
+	Count uint   `json:"count"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// 게시판 분류 제한값들
+const CATEGORY_MAX_DEPTH = 3
+const CATEGORY_MAX_NAME = 30
+const CATEGORY_MAX_DESCRIPTION = 300
+
+// 게시판 분류 정의 (ParentUid 가 0 이면 최상위 분류, LevelWrite 미만 회원은 이 분류에 글 작성 불가)
+type BoardCategory struct {
+	Pair
+	ParentUid   uint   `json:"parentUid"`
+	Description string `json:"description"`
+	SortOrder   uint   `json:"sortOrder"`
+	LevelWrite  int    `json:"levelWrite"`
+}
+
+// 게시판 분류 수정 파라미터 정의
+type CategoryModifyParameter struct {
+	BoardUid    uint
+	CategoryUid uint
+	Name        string
+	Description string
+	LevelWrite  int
+}
+
+// 게시판 분류 순서 변경 파라미터 정의 (Order 에 같은 상위 분류의 하위 분류들을 원하는 순서대로 지정)
+type CategoryReorderParameter struct {
+	BoardUid  uint
+	ParentUid uint
+	Order     []uint
+}
@@ -13,16 +13,18 @@ type CommentListParameter struct {
 
 // 댓글 내용 항목 정의
 type CommentItem struct {
-	Uid       uint        `json:"uid"`
-	ReplyUid  uint        `json:"replyUid"`
-	PostUid   uint        `json:"postUid"`
-	Writer    BoardWriter `json:"writer"`
-	Like      uint        `json:"like"`
-	Liked     bool        `json:"liked"`
-	Submitted uint64      `json:"submitted"`
-	Modified  uint64      `json:"modified"`
-	Status    Status      `json:"status"`
-	Content   string      `json:"content"`
+	Uid       uint            `json:"uid"`
+	ReplyUid  uint            `json:"replyUid"`
+	PostUid   uint            `json:"postUid"`
+	Writer    BoardWriter     `json:"writer"`
+	Like      uint            `json:"like"`
+	Liked     bool            `json:"liked"`
+	Reactions []ReactionCount `json:"reactions"`
+	Submitted uint64          `json:"submitted"`
+	Modified  uint64          `json:"modified"`
+	Status    Status          `json:"status"`
+	Content   string          `json:"content"`
+	Previews  []LinkPreview   `json:"previews"`
 }
 
 // 댓글 목록 가져오기 결과 정의
@@ -55,8 +57,9 @@ type CommentReplyParameter struct {
 
 // 새 댓글 작성하기에 필요한 파라미터 정의
 type CommentWriteParameter struct {
-	BoardUid uint
-	PostUid  uint
-	UserUid  uint
-	Content  string
+	BoardUid  uint
+	PostUid   uint
+	UserUid   uint
+	Content   string
+	IsPending bool
 }
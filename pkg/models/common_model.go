@@ -13,31 +13,62 @@ type Table string
 
 // 게시판 테이블 이름들 정리
 const (
-	TABLE_BOARD         Table = "board"
-	TABLE_BOARD_CAT     Table = "board_category"
-	TABLE_CHAT          Table = "chat"
-	TABLE_COMMENT       Table = "comment"
-	TABLE_COMMENT_LIKE  Table = "comment_like"
-	TABLE_EXIF          Table = "exif"
-	TABLE_FILE          Table = "file"
-	TABLE_FILE_THUMB    Table = "file_thumbnail"
-	TABLE_GROUP         Table = "group"
-	TABLE_HASHTAG       Table = "hashtag"
-	TABLE_IMAGE         Table = "image"
-	TABLE_IMAGE_DESC    Table = "image_description"
-	TABLE_NOTI          Table = "notification"
-	TABLE_POINT_HISTORY Table = "point_history"
-	TABLE_POST          Table = "post"
-	TABLE_POST_HASHTAG  Table = "post_hashtag"
-	TABLE_POST_LIKE     Table = "post_like"
-	TABLE_REPORT        Table = "report"
-	TABLE_TRADE         Table = "trade"
-	TABLE_USER          Table = "user"
-	TABLE_USER_ACCESS   Table = "user_access_log"
-	TABLE_USER_BLOCK    Table = "user_black_list"
-	TABLE_USER_PERM     Table = "user_permission"
-	TABLE_USER_TOKEN    Table = "user_token"
-	TABLE_USER_VERIFY   Table = "user_verification"
+	TABLE_BOARD            Table = "board"
+	TABLE_BOARD_CAT        Table = "board_category"
+	TABLE_BOARD_DESC       Table = "board_describer"
+	TABLE_BOARD_FIELD      Table = "board_field"
+	TABLE_BOARD_MODERATION Table = "board_moderation"
+	TABLE_BOARD_REACTION   Table = "board_reaction"
+	TABLE_BOARD_VARIANT    Table = "board_variant"
+	TABLE_BOOKMARK         Table = "bookmark"
+	TABLE_CHAT             Table = "chat"
+	TABLE_COMMENT          Table = "comment"
+	TABLE_COMMENT_LIKE     Table = "comment_like"
+	TABLE_COMMENT_REACTION Table = "comment_reaction"
+	TABLE_DRAFT            Table = "draft"
+	TABLE_DRAFT_FILE       Table = "draft_file"
+	TABLE_EXIF             Table = "exif"
+	TABLE_EXPORT           Table = "export"
+	TABLE_FILE             Table = "file"
+	TABLE_FILE_THUMB       Table = "file_thumbnail"
+	TABLE_FILE_VARIANT     Table = "file_variant"
+	TABLE_GROUP            Table = "group"
+	TABLE_HASHTAG          Table = "hashtag"
+	TABLE_IMAGE            Table = "image"
+	TABLE_IMAGE_DESC       Table = "image_description"
+	TABLE_IMPORT_MAP       Table = "import_map"
+	TABLE_JOB              Table = "job"
+	TABLE_LINK_PREVIEW     Table = "link_preview"
+	TABLE_MENTION          Table = "mention"
+	TABLE_MODERATION       Table = "moderation"
+	TABLE_NOTI             Table = "notification"
+	TABLE_POINT_HISTORY    Table = "point_history"
+	TABLE_POLL             Table = "poll"
+	TABLE_POLL_OPTION      Table = "poll_option"
+	TABLE_POLL_VOTE        Table = "poll_vote"
+	TABLE_POLL_VOTER       Table = "poll_voter"
+	TABLE_POST             Table = "post"
+	TABLE_POST_FIELD       Table = "post_field"
+	TABLE_POST_HASHTAG     Table = "post_hashtag"
+	TABLE_POST_LIKE        Table = "post_like"
+	TABLE_POST_REACTION    Table = "post_reaction"
+	TABLE_POST_REVISION    Table = "post_revision"
+	TABLE_POST_VIEW        Table = "post_view_daily"
+	TABLE_REPORT           Table = "report"
+	TABLE_SERIES           Table = "series"
+	TABLE_SERIES_POST      Table = "series_post"
+	TABLE_SPAM_LOG         Table = "spam_log"
+	TABLE_SPAM_RULE        Table = "spam_rule"
+	TABLE_TRADE            Table = "trade"
+	TABLE_TRASH            Table = "trash"
+	TABLE_USER             Table = "user"
+	TABLE_USER_ACCESS      Table = "user_access_log"
+	TABLE_USER_BLOCK       Table = "user_black_list"
+	TABLE_USER_PERM        Table = "user_permission"
+	TABLE_USER_STORAGE     Table = "user_storage"
+	TABLE_USER_TOKEN       Table = "user_token"
+	TABLE_USER_USAGE       Table = "user_usage"
+	TABLE_USER_VERIFY      Table = "user_verification"
 )
 
 // 고유값과 이름 구조체 정의
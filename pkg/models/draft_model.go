@@ -0,0 +1,62 @@
+package models
+
+import "mime/multipart"
+
+// 게시판별로 한 사용자가 보관할 수 있는 최대 임시저장 글 수
+const DRAFT_MAX_COUNT = 20
+
+// 임시저장 파라미터 정의
+type DraftSaveParameter struct {
+	DraftUid    uint
+	BoardUid    uint
+	UserUid     uint
+	CategoryUid uint
+	Version     uint
+	Title       string
+	Content     string
+	Format      ContentFormat
+	Tags        string
+	Files       []*multipart.FileHeader
+	Force       bool
+}
+
+// 임시저장 결과 정의 (다른 곳에서 먼저 저장했다면 Conflict 가 true, Server 는 서버에 저장된 글)
+type DraftSaveResult struct {
+	Uid      uint       `json:"uid"`
+	Version  uint       `json:"version"`
+	Updated  uint64     `json:"updated"`
+	Conflict bool       `json:"conflict"`
+	Server   *DraftItem `json:"server,omitempty"`
+}
+
+// 임시저장 글에 미리 올려둔 첨부파일 정의
+type DraftFile struct {
+	Uid  uint   `json:"uid"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Path string `json:"-"`
+}
+
+// 임시저장 글 정의
+type DraftItem struct {
+	Uid         uint        `json:"uid"`
+	BoardUid    uint        `json:"boardUid"`
+	CategoryUid uint        `json:"categoryUid"`
+	Version     uint        `json:"version"`
+	Title       string      `json:"title"`
+	Content     string      `json:"content"`
+	Format      string      `json:"format"`
+	Tags        string      `json:"tags"`
+	Files       []DraftFile `json:"files"`
+	Images      []string    `json:"images"`
+	Created     uint64      `json:"created"`
+	Updated     uint64      `json:"updated"`
+}
+
+// 임시저장 글 목록 항목 정의
+type DraftListItem struct {
+	Uid     uint   `json:"uid"`
+	Version uint   `json:"version"`
+	Title   string `json:"title"`
+	Updated uint64 `json:"updated"`
+}
@@ -0,0 +1,130 @@
+package models
+
+import "time"
+
+// 내보내기 형식 재정의
+type ExportFormat uint8
+
+// 내보내기 형식 목록 (마크다운은 front-matter 포함)
+const (
+	EXPORT_MARKDOWN ExportFormat = iota
+	EXPORT_JSON
+)
+
+// 내보내기 형식 이름 반환
+func (f ExportFormat) String() string {
+	switch f {
+	case EXPORT_JSON:
+		return "json"
+	default:
+		return "markdown"
+	}
+}
+
+// 내보내기 형식이 올바른지 확인
+func (f ExportFormat) IsValid() bool {
+	return f <= EXPORT_JSON
+}
+
+// 내보내기 진행 상태 재정의
+type ExportStatus uint8
+
+// 내보내기 진행 상태 목록
+const (
+	EXPORT_PENDING ExportStatus = iota
+	EXPORT_DONE
+	EXPORT_FAILED
+	EXPORT_EXPIRED
+)
+
+// 게시판 내보내기 시 한 번에 가져오는 게시글 수
+const EXPORT_BUNCH = 100
+
+// 압축 파일 안에서 첨부파일을 모아두는 폴더명
+const EXPORT_FILE_DIR = "files"
+
+// 내보낸 압축 파일을 저장하는 폴더 (공개된 upload 폴더 밖에 두고 다운로드 핸들러로만 제공)
+const EXPORT_SAVE_ROOT = "./export"
+
+// 내보낸 압축 파일 보관 기간 (지나면 파일 삭제)
+const EXPORT_KEEP_DURATION = 24 * time.Hour
+
+// 한 번에 정리하는 만료된 내보내기 요청 수
+const EXPORT_CLEANUP_BUNCH = 50
+
+// 내보내기 요청 추가 파라미터 정의 (PostUid 가 0이면 게시판 전체)
+type ExportInsertParameter struct {
+	BoardUid uint
+	PostUid  uint
+	UserUid  uint
+	Format   ExportFormat
+}
+
+// 내보내기 요청 항목 정의
+type ExportItem struct {
+	Uid       uint         `json:"uid"`
+	BoardUid  uint         `json:"boardUid"`
+	PostUid   uint         `json:"postUid"`
+	UserUid   uint         `json:"userUid"`
+	Format    ExportFormat `json:"format"`
+	Status    ExportStatus `json:"status"`
+	Path      string       `json:"-"`
+	Size      uint         `json:"size"`
+	Message   string       `json:"message"`
+	Created   uint64       `json:"created"`
+	Completed uint64       `json:"completed"`
+}
+
+// 내보낸 압축 파일 다운로드 정보 정의 (Path 는 서버 안의 실제 경로)
+type ExportDownloadResult struct {
+	Path string
+	Name string
+}
+
+// 내보낼 첨부파일 정의 (Path 는 압축 파일 안의 상대 경로)
+type ExportFile struct {
+	Uid    uint   `json:"uid"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Origin string `json:"-"`
+}
+
+// 내보낼 댓글 정의
+type ExportComment struct {
+	Uid       uint   `json:"uid"`
+	ReplyUid  uint   `json:"replyUid"`
+	WriterUid uint   `json:"writerUid"`
+	Writer    string `json:"writer"`
+	Content   string `json:"content"`
+	Submitted uint64 `json:"submitted"`
+	Modified  uint64 `json:"modified"`
+}
+
+// 내보낼 게시글 정의 (본문의 업로드 경로는 압축 파일 안의 상대 경로로 변경됨)
+type ExportPost struct {
+	Uid       uint            `json:"uid"`
+	Title     string          `json:"title"`
+	Category  string          `json:"category"`
+	WriterUid uint            `json:"writerUid"`
+	Writer    string          `json:"writer"`
+	Tags      []string        `json:"tags"`
+	Format    string          `json:"format"`
+	Content   string          `json:"content"`
+	Source    string          `json:"source"`
+	Notice    bool            `json:"notice"`
+	Secret    bool            `json:"secret"`
+	Hit       uint            `json:"hit"`
+	Submitted uint64          `json:"submitted"`
+	Modified  uint64          `json:"modified"`
+	Files     []ExportFile    `json:"files"`
+	Comments  []ExportComment `json:"comments"`
+}
+
+// 압축 파일에 함께 들어가는 목차 정의
+type ExportManifest struct {
+	Version string           `json:"version"`
+	Board   BoardBasicConfig `json:"board"`
+	Format  string           `json:"format"`
+	Posts   []uint           `json:"posts"`
+	Created uint64           `json:"created"`
+}
@@ -0,0 +1,56 @@
+package models
+
+// 게시판별 사용자 정의 필드 타입 정의
+type FieldType uint8
+
+// 사용자 정의 필드 타입 목록
+const (
+	FIELD_TEXT FieldType = iota
+	FIELD_NUMBER
+	FIELD_SELECT
+	FIELD_DATE
+	FIELD_URL
+)
+
+// 사용자 정의 필드 제한값들
+const FIELD_MAX_COUNT = 20
+const FIELD_MAX_NAME = 50
+const FIELD_MAX_VALUE = 300
+const FIELD_MAX_OPTIONS = 30
+
+// 날짜 필드 값의 형식
+const FIELD_DATE_LAYOUT = "2006-01-02"
+
+// 사용자 정의 필드 타입이 올바른지 확인
+func (t FieldType) IsValid() bool {
+	return t <= FIELD_URL
+}
+
+// 게시판별 사용자 정의 필드 정의 (Options 는 선택형 필드에서만 사용)
+type BoardField struct {
+	Uid      uint      `json:"uid"`
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Required bool      `json:"required"`
+	Options  []string  `json:"options"`
+}
+
+// 게시글에 입력된 사용자 정의 필드 값 정의
+type BoardFieldValue struct {
+	FieldUid uint      `json:"fieldUid"`
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Value    string    `json:"value"`
+}
+
+// 게시글 목록을 사용자 정의 필드 값으로 거를 때 필요한 조건 정의
+type BoardFieldFilter struct {
+	FieldUid uint
+	Type     FieldType
+	Value    string
+}
+
+// 게시판별 사용자 정의 필드 목록 반환값 정의
+type AdminBoardFieldResult struct {
+	Fields []BoardField `json:"fields"`
+}
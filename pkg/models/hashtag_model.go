@@ -0,0 +1,53 @@
+package models
+
+// 해시태그 제한값들
+const HASHTAG_MAX_NAME = 30
+const HASHTAG_MAX_TREND_DAYS = 365
+const HASHTAG_MAX_CLOUD = 100
+
+// 해시태그 정의
+type Hashtag struct {
+	Uid       uint   `json:"uid"`
+	Name      string `json:"name"`
+	Used      uint   `json:"used"`
+	Blocked   bool   `json:"blocked"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// (관리화면) 해시태그 목록 조회 파라미터 정의
+type HashtagListParameter struct {
+	Page   uint
+	Bunch  uint
+	MaxUid uint
+}
+
+// (관리화면) 해시태그 목록 및 max uid 반환값 정의
+type HashtagListResult struct {
+	Items  []Hashtag `json:"items"`
+	MaxUid uint      `json:"maxUid"`
+}
+
+// 해시태그 병합 결과 정의
+type HashtagMergeResult struct {
+	Moved uint `json:"moved"`
+	Used  uint `json:"used"`
+}
+
+// 해시태그 사용 횟수 재계산 결과 정의
+type HashtagRecountResult struct {
+	Updated uint `json:"updated"`
+	Removed uint `json:"removed"`
+}
+
+// 인기 해시태그 항목 정의 (Count 는 지정한 기간 동안 사용된 횟수)
+type HashtagCloudItem struct {
+	Uid   uint   `json:"uid"`
+	Name  string `json:"name"`
+	Count uint   `json:"count"`
+}
+
+// 해시태그 상세 페이지 반환값 정의
+type HashtagDetailResult struct {
+	Tag   Hashtag             `json:"tag"`
+	Posts []BoardHomePostItem `json:"posts"`
+}
@@ -0,0 +1,186 @@
+package models
+
+// 가져오기 원본 형식 재정의
+type ImportFormat uint8
+
+// 가져오기 원본 형식 목록 (그누보드5, XE 는 SQL 덤프 파일, 워드프레스는 WXR 파일)
+const (
+	IMPORT_GNUBOARD ImportFormat = iota
+	IMPORT_XE
+	IMPORT_WXR
+)
+
+// 가져오기 원본 형식 이름 반환
+func (f ImportFormat) String() string {
+	switch f {
+	case IMPORT_XE:
+		return "xe"
+	case IMPORT_WXR:
+		return "wxr"
+	default:
+		return "gnuboard"
+	}
+}
+
+// 원본 형식별 기본 테이블 접두사 반환 (WXR 은 사용하지 않음)
+func (f ImportFormat) DefaultPrefix() string {
+	switch f {
+	case IMPORT_GNUBOARD:
+		return "g5_"
+	case IMPORT_XE:
+		return "xe_"
+	default:
+		return ""
+	}
+}
+
+// 고유번호를 옮겨 기록하는 대상 재정의
+type ImportKind uint8
+
+// 고유번호를 옮겨 기록하는 대상 목록
+const (
+	IMPORT_USER ImportKind = iota
+	IMPORT_BOARD
+	IMPORT_CATEGORY
+	IMPORT_POST
+	IMPORT_COMMENT
+	IMPORT_FILE
+)
+
+// 가져오기 제한값들
+const IMPORT_MAX_SOURCE_ID = 100
+const IMPORT_MAX_WARNINGS = 200
+const IMPORT_MAX_NAME_SUFFIX = 100
+
+// 워드프레스 글들을 담을 게시판의 기본 아이디
+const IMPORT_WXR_BOARD_ID = "wordpress"
+
+// 가져오기 실행 파라미터 정의 (FileRoot 는 첨부파일을 찾을 원본 사이트의 최상위 경로)
+type ImportParameter struct {
+	Format   ImportFormat
+	Path     string
+	FileRoot string
+	Prefix   string
+	DryRun   bool
+}
+
+// 원본 회원 정의 (Password 는 원본 사이트의 해시값, 옮긴 뒤 재설정 필요)
+type ImportUser struct {
+	SourceId  string
+	Email     string
+	Name      string
+	Password  string
+	Level     int
+	Point     uint
+	Signature string
+	Signup    uint64
+}
+
+// 원본 게시판 정의
+type ImportBoard struct {
+	SourceId string
+	Id       string
+	Name     string
+	Info     string
+}
+
+// 원본 카테고리 정의 (ParentSourceId 가 비어있으면 최상위)
+type ImportCategory struct {
+	SourceId       string
+	BoardSourceId  string
+	ParentSourceId string
+	Name           string
+}
+
+// 원본 게시글 정의
+type ImportPost struct {
+	SourceId         string
+	BoardSourceId    string
+	CategorySourceId string
+	WriterSourceId   string
+	Title            string
+	Content          string
+	IsNotice         bool
+	IsSecret         bool
+	Hit              uint
+	Submitted        uint64
+	Modified         uint64
+	Tags             []string
+}
+
+// 원본 댓글 정의 (ReplySourceId 가 비어있으면 게시글에 바로 단 댓글)
+type ImportComment struct {
+	SourceId       string
+	PostSourceId   string
+	ReplySourceId  string
+	WriterSourceId string
+	Content        string
+	Submitted      uint64
+	Modified       uint64
+}
+
+// 원본 첨부파일 정의 (Path 는 FileRoot 기준 상대 경로, Url 은 본문에서 바꿔줄 원래 주소)
+type ImportFile struct {
+	SourceId     string
+	PostSourceId string
+	Name         string
+	Path         string
+	Url          string
+}
+
+// 원본 형식별 파서가 채워주는 전체 데이터 정의
+type ImportData struct {
+	Users      []ImportUser
+	Boards     []ImportBoard
+	Categories []ImportCategory
+	Posts      []ImportPost
+	Comments   []ImportComment
+	Files      []ImportFile
+	Warnings   []string
+}
+
+// 새로 추가할 게시글 파라미터 정의
+type ImportPostParameter struct {
+	BoardUid    uint
+	UserUid     uint
+	CategoryUid uint
+	Title       string
+	Content     string
+	Status      Status
+	Hit         uint
+	Submitted   uint64
+	Modified    uint64
+}
+
+// 새로 추가할 댓글 파라미터 정의
+type ImportCommentParameter struct {
+	BoardUid  uint
+	PostUid   uint
+	UserUid   uint
+	Content   string
+	Submitted uint64
+	Modified  uint64
+}
+
+// 대상별 가져오기 결과 개수 정의 (Mapped 는 이미 옮겨졌거나 기존 항목에 연결된 개수)
+type ImportCount struct {
+	Found   uint `json:"found"`
+	Created uint `json:"created"`
+	Mapped  uint `json:"mapped"`
+	Skipped uint `json:"skipped"`
+}
+
+// 가져오기 결과 보고서 정의 (DryRun 이면 Created 는 새로 만들어질 개수)
+type ImportReport struct {
+	Format     string      `json:"format"`
+	DryRun     bool        `json:"dryRun"`
+	Users      ImportCount `json:"users"`
+	Boards     ImportCount `json:"boards"`
+	Categories ImportCount `json:"categories"`
+	Posts      ImportCount `json:"posts"`
+	Comments   ImportCount `json:"comments"`
+	Files      ImportCount `json:"files"`
+	Tags       ImportCount `json:"tags"`
+	ResetUsers uint        `json:"resetUsers"`
+	Warnings   []string    `json:"warnings"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// 백그라운드 작업 타입 재정의
+type JobType uint8
+
+// 백그라운드 작업 타입 고유값들
+const (
+	JOB_THUMBNAIL JobType = iota
+	JOB_EXIF
+	JOB_IMAGE_DESCRIPTION
+	JOB_VARIANTS
+	JOB_EXPORT
+)
+
+// 작업 타입 이름 반환
+func (j JobType) String() string {
+	switch j {
+	case JOB_EXIF:
+		return "exif"
+	case JOB_EXPORT:
+		return "export"
+	case JOB_IMAGE_DESCRIPTION:
+		return "image_description"
+	case JOB_VARIANTS:
+		return "variants"
+	default:
+		return "thumbnail"
+	}
+}
+
+// 백그라운드 작업 상태 재정의
+type JobStatus uint8
+
+// 백그라운드 작업 상태 고유값들
+const (
+	JOB_PENDING JobStatus = iota
+	JOB_RUNNING
+	JOB_DONE
+	JOB_FAILED
+)
+
+// 대기 중인 작업을 확인하는 주기
+const JOB_POLL_INTERVAL = 2 * time.Second
+
+// 새 작업 추가 파라미터 정의 (내보내기 작업은 FileUid 에 내보내기 고유번호를 담음)
+type JobInsertParameter struct {
+	Type     JobType
+	BoardUid uint
+	PostUid  uint
+	FileUid  uint
+	Path     string
+}
+
+// 작업 목록 조회 파라미터 정의
+type JobListParameter struct {
+	Page     uint
+	Bunch    uint
+	MaxUid   uint
+	Status   JobStatus
+	AnyState bool
+}
+
+// 작업 항목 정의
+type JobItem struct {
+	Uid      uint      `json:"uid"`
+	Type     JobType   `json:"type"`
+	Status   JobStatus `json:"status"`
+	BoardUid uint      `json:"boardUid"`
+	PostUid  uint      `json:"postUid"`
+	FileUid  uint      `json:"fileUid"`
+	Path     string    `json:"path"`
+	Attempts uint      `json:"attempts"`
+	Message  string    `json:"message"`
+	Created  uint64    `json:"created"`
+	Updated  uint64    `json:"updated"`
+}
+
+// 작업 목록 및 max uid 반환값 정의
+type JobListResult struct {
+	Jobs   []JobItem `json:"jobs"`
+	MaxUid uint      `json:"maxUid"`
+}
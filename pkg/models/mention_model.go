@@ -0,0 +1,13 @@
+package models
+
+// 글 하나에서 처리하는 최대 언급 수
+const MENTION_MAX_COUNT = 10
+
+// 언급 저장 파라미터 정의 (게시글 본문에서 언급한 경우 CommentUid 는 0)
+type MentionParameter struct {
+	BoardUid   uint
+	PostUid    uint
+	CommentUid uint
+	UserUid    uint
+	Content    string
+}
@@ -0,0 +1,75 @@
+package models
+
+// 게시판별 승인 대기(검토) 모드 정의
+type ModerationMode uint8
+
+// 승인 대기 모드 목록 (사용 안함, 처음 N개의 글만, 모든 글, 지정한 레벨 미만 회원의 글)
+const (
+	MODERATION_OFF ModerationMode = iota
+	MODERATION_FIRST_POSTS
+	MODERATION_ALL
+	MODERATION_LEVEL
+)
+
+// 승인 대기 모드가 올바른지 확인
+func (m ModerationMode) IsValid() bool {
+	return m <= MODERATION_LEVEL
+}
+
+// 검토 결과 정의
+type ModerationDecision uint8
+
+// 검토 결과 목록
+const (
+	MODERATION_WAITING ModerationDecision = iota
+	MODERATION_APPROVED
+	MODERATION_REJECTED
+)
+
+// 반려 사유 최대 길이
+const MODERATION_MAX_REASON = 300
+
+// 게시판별 승인 대기 옵션 정의 (Threshold 는 모드에 따라 글 개수 혹은 레벨)
+type BoardModerationOption struct {
+	Mode      ModerationMode `json:"mode"`
+	Threshold uint           `json:"threshold"`
+}
+
+// 승인 대기 레코드 정의
+type ModerationRecord struct {
+	Uid          uint
+	BoardUid     uint
+	PostUid      uint
+	CommentUid   uint
+	UserUid      uint
+	Decision     ModerationDecision
+	Reason       string
+	ModeratorUid uint
+	Submitted    uint64
+	Decided      uint64
+}
+
+// 승인 대기 목록 조회 파라미터 정의
+type ModerationListParameter struct {
+	Page   uint
+	Bunch  uint
+	MaxUid uint
+}
+
+// (관리화면) 승인 대기 항목 정의
+type ModerationItem struct {
+	Uid        uint             `json:"uid"`
+	Board      BoardBasicConfig `json:"board"`
+	PostUid    uint             `json:"postUid"`
+	CommentUid uint             `json:"commentUid"`
+	Title      string           `json:"title"`
+	Content    string           `json:"content"`
+	Writer     BoardWriter      `json:"writer"`
+	Submitted  uint64           `json:"submitted"`
+}
+
+// 승인 대기 목록 및 max uid 반환값 정의
+type ModerationListResult struct {
+	Items  []ModerationItem `json:"items"`
+	MaxUid uint             `json:"maxUid"`
+}
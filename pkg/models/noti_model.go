@@ -17,6 +17,7 @@ type NotificationItem struct {
 	Id        string        `json:"id"`
 	BoardType Board         `json:"boardType"`
 	PostUid   uint          `json:"postUid"`
+	Reason    string        `json:"reason"`
 	Checked   bool          `json:"checked"`
 	Timestamp uint64        `json:"timestamp"`
 }
@@ -31,4 +32,8 @@ const (
 	NOTI_LEAVE_COMMENT
 	NOTI_REPLY_COMMENT
 	NOTI_CHAT_MESSAGE
+	NOTI_POLL_CLOSED
+	NOTI_MENTION
+	NOTI_MODERATION_APPROVED
+	NOTI_MODERATION_REJECTED
 )
@@ -0,0 +1,55 @@
+package models
+
+// 투표 선택지 개수 제한
+const POLL_MIN_OPTIONS = 2
+const POLL_MAX_OPTIONS = 20
+
+// 게시글 작성 시 함께 등록하는 투표 파라미터 정의
+type PollWriteParameter struct {
+	Question  string
+	Options   []string
+	Multiple  bool
+	Anonymous bool
+	OpenAt    uint64
+	CloseAt   uint64
+}
+
+// 투표하기 파라미터 정의
+type PollVoteParameter struct {
+	BoardUid   uint
+	PostUid    uint
+	UserUid    uint
+	OptionUids []uint
+}
+
+// 투표 선택지 및 득표 현황 정의 (공개 투표일 때만 투표자 목록 포함)
+type PollOption struct {
+	Uid     uint            `json:"uid"`
+	Content string          `json:"content"`
+	Count   uint            `json:"count"`
+	Voters  []UserBasicInfo `json:"voters"`
+}
+
+// 투표 정보 및 결과 반환값 정의
+type PollResult struct {
+	Uid         uint         `json:"uid"`
+	BoardUid    uint         `json:"boardUid"`
+	PostUid     uint         `json:"postUid"`
+	UserUid     uint         `json:"-"`
+	Question    string       `json:"question"`
+	Multiple    bool         `json:"multiple"`
+	Anonymous   bool         `json:"anonymous"`
+	OpenAt      uint64       `json:"openAt"`
+	CloseAt     uint64       `json:"closeAt"`
+	IsOpen      bool         `json:"isOpen"`
+	TotalVoters uint         `json:"totalVoters"`
+	Options     []PollOption `json:"options"`
+	Voted       []uint       `json:"voted"`
+}
+
+// 마감되어 작성자에게 알려야 할 투표 정의
+type PollClosedItem struct {
+	Uid     uint
+	PostUid uint
+	UserUid uint
+}
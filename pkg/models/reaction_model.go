@@ -0,0 +1,52 @@
+package models
+
+// 반응 관련 상수 정의
+const (
+	REACTION_LIKE           = "👍"
+	REACTION_DEFAULT_EMOJIS = "👍,❤️,😂,😮,😢,😡"
+	REACTION_MAX_EMOJIS     = 12
+	REACTION_MAX_LENGTH     = 10
+)
+
+// 게시판별 반응 이모지 옵션 정의
+type BoardReactionOption struct {
+	Emojis   []string `json:"emojis"`
+	Multiple bool     `json:"multiple"`
+}
+
+// 이모지별 반응 수 정의
+type ReactionCount struct {
+	Emoji   string `json:"emoji"`
+	Count   uint   `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+// 반응 남기기/취소하기에 필요한 파라미터 정의 (Table 은 게시글 혹은 댓글 반응 테이블)
+type ReactionParameter struct {
+	BoardUid  uint
+	TargetUid uint
+	UserUid   uint
+	Table     Table
+	Emoji     string
+}
+
+// 반응을 남긴 회원 목록 가져오기에 필요한 파라미터 정의
+type ReactionUserParameter struct {
+	ReactionParameter
+	Page  uint
+	Bunch uint
+}
+
+// 반응을 남긴 회원 정의
+type ReactionUserItem struct {
+	Uid       uint          `json:"uid"`
+	Emoji     string        `json:"emoji"`
+	User      UserBasicInfo `json:"user"`
+	Timestamp uint64        `json:"timestamp"`
+}
+
+// 반응을 남긴 회원 목록 반환값 정의
+type ReactionUserResult struct {
+	Items []ReactionUserItem `json:"items"`
+	Total uint               `json:"total"`
+}
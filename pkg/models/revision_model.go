@@ -0,0 +1,64 @@
+package models
+
+// 변경 내역 비교 결과의 줄 단위 타입
+type DiffType string
+
+// 변경 내역 비교 결과의 줄 단위 타입들
+const (
+	DIFF_EQUAL  DiffType = "equal"
+	DIFF_INSERT DiffType = "insert"
+	DIFF_DELETE DiffType = "delete"
+)
+
+// 비교 가능한 최대 크기 (양쪽 줄 수의 합), 넘어가면 전체를 교체한 것으로 처리
+const DIFF_MAX_LINES = 10000
+
+// 새 수정 이력 추가 파라미터 정의
+type RevisionInsertParameter struct {
+	PostUid      uint
+	BoardUid     uint
+	EditorUid    uint
+	Title        string
+	Content      string
+	Format       ContentFormat
+	Tags         string
+	Created      int64
+	RestoredFrom uint
+}
+
+// 게시글 수정 이력 정의
+type PostRevision struct {
+	Uid          uint        `json:"uid"`
+	PostUid      uint        `json:"postUid"`
+	Editor       BoardWriter `json:"editor"`
+	Title        string      `json:"title"`
+	Content      string      `json:"content"`
+	Format       string      `json:"format"`
+	Tags         string      `json:"tags"`
+	Created      uint64      `json:"created"`
+	RestoredFrom uint        `json:"restoredFrom"`
+}
+
+// 게시글 수정 이력 목록 항목 정의
+type RevisionListItem struct {
+	Uid          uint        `json:"uid"`
+	Editor       BoardWriter `json:"editor"`
+	Title        string      `json:"title"`
+	Created      uint64      `json:"created"`
+	RestoredFrom uint        `json:"restoredFrom"`
+}
+
+// 비교 결과의 한 줄 정의
+type DiffLine struct {
+	Type DiffType `json:"type"`
+	Text string   `json:"text"`
+}
+
+// 두 수정 이력 비교 결과 정의
+type RevisionDiffResult struct {
+	From    RevisionListItem `json:"from"`
+	To      RevisionListItem `json:"to"`
+	Title   []DiffLine       `json:"title"`
+	Content []DiffLine       `json:"content"`
+	Tags    []DiffLine       `json:"tags"`
+}
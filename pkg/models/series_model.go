@@ -0,0 +1,58 @@
+package models
+
+// 시리즈 이름, 설명 길이 및 시리즈당 게시글 수 제한
+const SERIES_MAX_NAME = 100
+const SERIES_MAX_INFO = 300
+const SERIES_MAX_POSTS = 200
+
+// 시리즈 생성/수정 파라미터 정의
+type SeriesParameter struct {
+	SeriesUid uint
+	BoardUid  uint
+	UserUid   uint
+	Name      string
+	Info      string
+}
+
+// 시리즈에 게시글들을 순서대로 지정하는 파라미터 정의
+type SeriesPostParameter struct {
+	SeriesUid uint
+	UserUid   uint
+	PostUids  []uint
+}
+
+// 시리즈 정보 정의
+type SeriesItem struct {
+	Uid      uint          `json:"uid"`
+	BoardUid uint          `json:"boardUid"`
+	Writer   UserBasicInfo `json:"writer"`
+	Name     string        `json:"name"`
+	Info     string        `json:"info"`
+	Count    uint          `json:"count"`
+	Created  uint64        `json:"created"`
+	Modified uint64        `json:"modified"`
+}
+
+// 시리즈에 속한 게시글 정의
+type SeriesPostItem struct {
+	PostUid   uint   `json:"postUid"`
+	Position  uint   `json:"position"`
+	Title     string `json:"title"`
+	Submitted uint64 `json:"submitted"`
+}
+
+// 시리즈 상세 보기 반환값 정의
+type SeriesViewResult struct {
+	Series SeriesItem       `json:"series"`
+	Posts  []SeriesPostItem `json:"posts"`
+}
+
+// 게시글 보기 화면에서 시리즈 내 이전/다음 글 정보 정의
+type SeriesNavigation struct {
+	Uid         uint   `json:"uid"`
+	Name        string `json:"name"`
+	Position    uint   `json:"position"`
+	Total       uint   `json:"total"`
+	PrevPostUid uint   `json:"prevPostUid"`
+	NextPostUid uint   `json:"nextPostUid"`
+}
@@ -0,0 +1,156 @@
+package models
+
+import "time"
+
+// 스팸 필터 검사 대상 정의
+type SpamTarget uint8
+
+// 스팸 필터 검사 대상 목록
+const (
+	SPAM_TARGET_POST SpamTarget = iota
+	SPAM_TARGET_COMMENT
+	SPAM_TARGET_CHAT
+)
+
+// 금지어 규칙 타입 정의
+type SpamRuleType uint8
+
+// 금지어 규칙 타입 목록 (단어는 대소문자 구분 없이 포함 여부, 정규식은 RE2 문법)
+const (
+	SPAM_RULE_WORD SpamRuleType = iota
+	SPAM_RULE_REGEX
+)
+
+// 스팸으로 판단되었을 때의 처리 방법 정의 (값이 클수록 강한 처리)
+type SpamAction uint8
+
+// 스팸 처리 방법 목록 (통과, 가리기, 승인 대기, 거부)
+const (
+	SPAM_ACTION_PASS SpamAction = iota
+	SPAM_ACTION_MASK
+	SPAM_ACTION_MODERATE
+	SPAM_ACTION_REJECT
+)
+
+// 스팸으로 판단한 검사 항목 정의
+type SpamCheck uint8
+
+// 스팸 검사 항목 목록 (금지어 규칙, 링크 개수, 중복 내용, 작성 빈도, 분류기)
+const (
+	SPAM_CHECK_RULE SpamCheck = iota
+	SPAM_CHECK_LINK
+	SPAM_CHECK_DUPLICATE
+	SPAM_CHECK_RATE
+	SPAM_CHECK_CLASSIFIER
+)
+
+// 스팸 분류기 제공자 목록
+const (
+	SPAM_CLASSIFIER_OPENAI = "openai"
+	SPAM_CLASSIFIER_HTTP   = "http"
+)
+
+// 스팸 필터 제한값들
+const SPAM_MAX_PATTERN = 200
+const SPAM_MAX_DETAIL = 300
+const SPAM_MAX_CLASSIFY = 10000
+const SPAM_MASK_CHAR = "*"
+
+// 분류기 응답을 기다리는 최대 시간 (글쓰기 요청이 함께 기다리므로 짧게 유지)
+const SPAM_CLASSIFY_TIMEOUT = 10 * time.Second
+
+// 컴파일해둔 금지어 규칙을 다시 불러오는 주기
+const SPAM_RULE_CACHE_TTL = time.Minute
+
+// 금지어 규칙이 올바른지 확인
+func (t SpamRuleType) IsValid() bool {
+	return t <= SPAM_RULE_REGEX
+}
+
+// 처리 방법이 올바른지 확인 (통과는 규칙으로 지정할 수 없음)
+func (a SpamAction) IsValid() bool {
+	return a > SPAM_ACTION_PASS && a <= SPAM_ACTION_REJECT
+}
+
+// 관리자가 등록한 금지어 규칙 정의
+type SpamRule struct {
+	Uid       uint         `json:"uid"`
+	Type      SpamRuleType `json:"type"`
+	Pattern   string       `json:"pattern"`
+	Action    SpamAction   `json:"action"`
+	Timestamp uint64       `json:"timestamp"`
+}
+
+// 스팸 검사 파라미터 정의 (TargetUid 는 수정할 때만, PostUid 는 댓글일 때만 지정)
+type SpamCheckParameter struct {
+	Target    SpamTarget
+	TargetUid uint
+	BoardUid  uint
+	PostUid   uint
+	UserUid   uint
+	Title     string
+	Content   string
+	Source    string
+}
+
+// 스팸 검사에 걸린 항목 정의
+type SpamHit struct {
+	Check   SpamCheck
+	RuleUid uint
+	Action  SpamAction
+	Detail  string
+}
+
+// 스팸 검사 결과 정의 (Title, Content, Source 는 가리기 처리된 내용)
+type SpamVerdict struct {
+	Action  SpamAction
+	Title   string
+	Content string
+	Source  string
+	Hits    []SpamHit
+}
+
+// 스팸 검사 기록 정의
+type SpamLogRecord struct {
+	SpamHit
+	Uid       uint
+	Target    SpamTarget
+	TargetUid uint
+	BoardUid  uint
+	UserUid   uint
+	Excerpt   string
+	Timestamp uint64
+}
+
+// 스팸 검사 기록 조회 파라미터 정의
+type SpamLogListParameter struct {
+	Page   uint
+	Bunch  uint
+	MaxUid uint
+}
+
+// (관리화면) 스팸 검사 기록 항목 정의
+type SpamLogItem struct {
+	Uid       uint             `json:"uid"`
+	Target    SpamTarget       `json:"target"`
+	TargetUid uint             `json:"targetUid"`
+	Board     BoardBasicConfig `json:"board"`
+	Writer    BoardWriter      `json:"writer"`
+	Check     SpamCheck        `json:"check"`
+	RuleUid   uint             `json:"ruleUid"`
+	Action    SpamAction       `json:"action"`
+	Detail    string           `json:"detail"`
+	Excerpt   string           `json:"excerpt"`
+	Timestamp uint64           `json:"timestamp"`
+}
+
+// 스팸 검사 기록 목록 및 max uid 반환값 정의
+type SpamLogListResult struct {
+	Items  []SpamLogItem `json:"items"`
+	MaxUid uint          `json:"maxUid"`
+}
+
+// 금지어 규칙 목록 반환값 정의
+type AdminSpamRuleResult struct {
+	Rules []SpamRule `json:"rules"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// 휴지통 항목 타입 재정의
+type TrashType uint8
+
+// 휴지통 항목 타입 고유값들
+const (
+	TRASH_POST TrashType = iota
+	TRASH_COMMENT
+)
+
+// 보관 기간이 지난 휴지통 항목들을 확인하는 주기와 한 번에 처리할 개수
+const TRASH_PURGE_INTERVAL = time.Hour
+const TRASH_PURGE_BUNCH = 100
+
+// 휴지통 항목 추가 파라미터 정의
+type TrashInsertParameter struct {
+	ParentUid  uint
+	Type       TrashType
+	TargetUid  uint
+	BoardUid   uint
+	PostUid    uint
+	UserUid    uint
+	RemovedBy  uint
+	PrevStatus Status
+	Backup     string
+}
+
+// 휴지통 레코드 정의
+type TrashRecord struct {
+	TrashInsertParameter
+	Uid     uint
+	Removed uint64
+}
+
+// 휴지통 목록 조회 파라미터 정의
+type TrashListParameter struct {
+	Page   uint
+	Bunch  uint
+	MaxUid uint
+}
+
+// (관리화면) 휴지통 항목 정의
+type TrashItem struct {
+	Uid       uint             `json:"uid"`
+	Type      TrashType        `json:"type"`
+	TargetUid uint             `json:"targetUid"`
+	Board     BoardBasicConfig `json:"board"`
+	PostUid   uint             `json:"postUid"`
+	Content   string           `json:"content"`
+	Writer    BoardWriter      `json:"writer"`
+	RemovedBy BoardWriter      `json:"removedBy"`
+	Removed   uint64           `json:"removed"`
+	PurgeAt   uint64           `json:"purgeAt"`
+}
+
+// 휴지통 목록 및 max uid 반환값 정의
+type TrashListResult struct {
+	Items  []TrashItem `json:"items"`
+	MaxUid uint        `json:"maxUid"`
+}
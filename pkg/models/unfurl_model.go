@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// 링크 미리보기 가져오기 제한값들
+const UNFURL_TIMEOUT = 5 * time.Second
+const UNFURL_MAX_BYTES = 1 << 20
+const UNFURL_IMAGE_MAX_BYTES = 5 << 20
+const UNFURL_MAX_REDIRECTS = 3
+const UNFURL_MAX_LINKS = 5
+
+// 링크 미리보기 캐시 유지 기간 (실패한 링크는 짧게 유지 후 다시 시도)
+const UNFURL_CACHE_TTL = 7 * 24 * time.Hour
+const UNFURL_FAILED_TTL = 1 * time.Hour
+
+// 링크 미리보기를 가져올 때 사용하는 User-Agent
+const UNFURL_USER_AGENT = "Mozilla/5.0 (compatible; TSBOARD-Unfurl/1.0; +https://tsboard.dev)"
+
+// 링크 미리보기 정의 (Image 는 서버에 저장된 경로)
+type LinkPreview struct {
+	Url         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	SiteName    string `json:"siteName"`
+	Image       string `json:"image"`
+	Type        string `json:"type"`
+	Fetched     uint64 `json:"fetched"`
+}
+
+// 캐시에 저장된 링크 미리보기 레코드 정의 (가져오기에 실패한 링크도 기록)
+type LinkPreviewRecord struct {
+	LinkPreview
+	Failed bool
+}
@@ -117,3 +117,39 @@ type UserBasicInfo struct {
 	Name    string `json:"name"`
 	Profile string `json:"profile"`
 }
+
+// 저장 공간 한도가 없을 때 남은 용량 표시값
+const STORAGE_UNLIMITED int64 = -1
+
+// 저장 공간 사용량 구분 정의
+type StorageUsage uint8
+
+// 저장 공간 사용량 구분들
+const (
+	STORAGE_ATTACHMENT StorageUsage = iota
+	STORAGE_IMAGE
+	STORAGE_PROFILE
+)
+
+// 저장 공간 사용량 구분의 컬럼 이름 반환
+func (u StorageUsage) String() string {
+	switch u {
+	case STORAGE_IMAGE:
+		return "image"
+	case STORAGE_PROFILE:
+		return "profile"
+	default:
+		return "attachment"
+	}
+}
+
+// 사용자의 저장 공간 사용량 반환값 정의
+type UserStorageResult struct {
+	Attachment uint64 `json:"attachment"`
+	Image      uint64 `json:"image"`
+	Profile    uint64 `json:"profile"`
+	Used       uint64 `json:"used"`
+	Quota      uint64 `json:"quota"`
+	Remain     int64  `json:"remain"`
+	IsCustom   bool   `json:"isCustom"`
+}
@@ -32,4 +32,5 @@ const (
 	CODE_NO_PERMISSION
 	CODE_EXCEED_SIZE
 	CODE_EXPIRED_TOKEN
+	CODE_CONFLICT
 )
\ No newline at end of file
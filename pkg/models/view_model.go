@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// 모아둔 조회수를 게시글에 반영하는 주기
+const VIEW_FLUSH_INTERVAL = 10 * time.Second
+
+// 조회수에서 제외할 봇(크롤러, 스크립트)들의 User-Agent 키워드 (소문자)
+var VIEW_BOT_KEYWORDS = []string{
+	"bot", "crawl", "spider", "slurp", "facebookexternalhit", "embedly", "headless",
+	"curl", "wget", "python-requests", "go-http-client", "okhttp", "libwww", "httpclient", "scrapy",
+}
+
+// 게시글 조회수 올리기에 필요한 파라미터 정의
+type ViewCountParameter struct {
+	BoardUid  uint
+	PostUid   uint
+	UserUid   uint
+	Ip        string
+	UserAgent string
+}
+
+// 게시글에 반영하기 전까지 모아둔 조회수 정의
+type ViewPending struct {
+	BoardUid uint
+	Count    uint
+}
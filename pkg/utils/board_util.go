@@ -1,21 +1,29 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
+	"mime/multipart"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/sirini/goapi/internal/configs"
 	"github.com/sirini/goapi/pkg/models"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
 )
 
 var (
 	once           sync.Once
 	sanitizePolicy *bluemonday.Policy
+	markdown       = goldmark.New(goldmark.WithExtensions(extension.GFM, extension.Footnote))
 )
 
 // int 절대값 구하기
@@ -26,8 +34,8 @@ func Abs(n int) int {
 	return n
 }
 
-// 글 작성/수정 시 파라미터 검사 및 타입 변환
-func CheckWriteParameters(c fiber.Ctx) (models.EditorWriteParameter, error) {
+// 글 작성/수정 시 파라미터 검사 및 타입 변환 (storageLeft 는 남은 저장 공간, fields 는 게시판의 사용자 정의 필드들)
+func CheckWriteParameters(c fiber.Ctx, storageLeft int64, fields []models.BoardField) (models.EditorWriteParameter, error) {
 	result := models.EditorWriteParameter{}
 	actionUserUid := ExtractUserUid(c.Get(models.AUTH_KEY))
 	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
@@ -46,6 +54,21 @@ func CheckWriteParameters(c fiber.Ctx) (models.EditorWriteParameter, error) {
 	if err != nil {
 		return result, err
 	}
+	keepLocation, _ := strconv.ParseBool(c.FormValue("keepLocation"))
+	draftUid, _ := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	hideOnExpire, _ := strconv.ParseBool(c.FormValue("hideOnExpire"))
+	publishAt, expireAt, err := checkPostSchedule(c.FormValue("publishAt"), c.FormValue("expireAt"))
+	if err != nil {
+		return result, err
+	}
+	poll, err := checkPollParameters(c)
+	if err != nil {
+		return result, err
+	}
+	fieldValues, err := checkFieldParameters(c, fields)
+	if err != nil {
+		return result, err
+	}
 
 	title := Escape(c.FormValue("title"))
 	if len(title) < 2 {
@@ -53,10 +76,22 @@ func CheckWriteParameters(c fiber.Ctx) (models.EditorWriteParameter, error) {
 	}
 	title = CutString(title, 299)
 
+	format := ParseContentFormat(c.FormValue("format"))
+	source := ""
 	content := Sanitize(c.FormValue("content"))
+	if format == models.FORMAT_MARKDOWN {
+		source = c.FormValue("content")
+		if len(source) > models.CONTENT_MAX_LENGTH {
+			return result, fmt.Errorf("invalid content, too long")
+		}
+		content = RenderMarkdown(source)
+	}
 	if len(content) < 2 {
 		return result, fmt.Errorf("invalid content, too short")
 	}
+	if len(content) > models.CONTENT_MAX_LENGTH {
+		return result, fmt.Errorf("invalid content, too long")
+	}
 
 	tags := c.FormValue("tags")
 	tagArr := strings.Split(tags, ",")
@@ -76,18 +111,144 @@ func CheckWriteParameters(c fiber.Ctx) (models.EditorWriteParameter, error) {
 		if totalFileSize > fileSizeLimit {
 			return result, fmt.Errorf("uploaded files exceed size limitation")
 		}
+		if storageLeft != models.STORAGE_UNLIMITED && totalFileSize > storageLeft {
+			return result, fmt.Errorf("uploaded files exceed your storage quota")
+		}
 	}
 
 	result = models.EditorWriteParameter{
+		BoardUid:     uint(boardUid),
+		UserUid:      uint(actionUserUid),
+		CategoryUid:  uint(categoryUid),
+		Title:        title,
+		Content:      content,
+		Format:       format,
+		Source:       source,
+		Files:        attachments,
+		Tags:         tagArr,
+		IsNotice:     isNotice,
+		IsSecret:     isSecret,
+		KeepLocation: keepLocation,
+		DraftUid:     uint(draftUid),
+		PublishAt:    publishAt,
+		ExpireAt:     expireAt,
+		HideOnExpire: hideOnExpire,
+		Poll:         poll,
+		Fields:       fieldValues,
+	}
+	return result, nil
+}
+
+// 글 작성 시 함께 등록할 투표 파라미터 검사 (선택지가 없으면 투표 없음)
+func checkPollParameters(c fiber.Ctx) (models.PollWriteParameter, error) {
+	poll := models.PollWriteParameter{Options: make([]string, 0)}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return poll, nil
+	}
+	for _, option := range form.Value["pollOptions[]"] {
+		if option = CutString(Escape(strings.TrimSpace(option)), 100); len(option) > 0 {
+			poll.Options = append(poll.Options, option)
+		}
+	}
+	if len(poll.Options) < 1 {
+		return poll, nil
+	}
+	if len(poll.Options) < models.POLL_MIN_OPTIONS || len(poll.Options) > models.POLL_MAX_OPTIONS {
+		return poll, fmt.Errorf("invalid number of poll options")
+	}
+
+	poll.Question = CutString(Escape(c.FormValue("pollQuestion")), 299)
+	if len(poll.Question) < 1 {
+		return poll, fmt.Errorf("invalid poll question, too short")
+	}
+	poll.Multiple, _ = strconv.ParseBool(c.FormValue("pollMultiple"))
+	poll.Anonymous, _ = strconv.ParseBool(c.FormValue("pollAnonymous"))
+	poll.OpenAt, _ = strconv.ParseUint(c.FormValue("pollOpenAt"), 10, 64)
+	poll.CloseAt, _ = strconv.ParseUint(c.FormValue("pollCloseAt"), 10, 64)
+
+	now := uint64(time.Now().UnixMilli())
+	if poll.CloseAt > 0 && poll.CloseAt <= max(now, poll.OpenAt) {
+		return poll, fmt.Errorf("invalid pollCloseAt, it should be later than opening time")
+	}
+	return poll, nil
+}
+
+// 예약 발행, 게시 종료 시각 검사 (이미 지난 발행 시각은 즉시 발행으로 처리)
+func checkPostSchedule(publishAtStr string, expireAtStr string) (uint64, uint64, error) {
+	var publishAt, expireAt uint64
+	var err error
+	if len(publishAtStr) > 0 {
+		if publishAt, err = strconv.ParseUint(publishAtStr, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid publishAt, not a valid timestamp")
+		}
+	}
+	if len(expireAtStr) > 0 {
+		if expireAt, err = strconv.ParseUint(expireAtStr, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid expireAt, not a valid timestamp")
+		}
+	}
+
+	now := uint64(time.Now().UnixMilli())
+	if publishAt <= now {
+		publishAt = 0
+	}
+	if expireAt > 0 && expireAt <= max(now, publishAt) {
+		return 0, 0, fmt.Errorf("invalid expireAt, it should be later than publishing time")
+	}
+	return publishAt, expireAt, nil
+}
+
+// 임시저장에 필요한 파라미터 체크 (자동 저장도 고려해서 제목, 내용이 비어있어도 허용)
+func CheckDraftParameters(c fiber.Ctx, storageLeft int64) (models.DraftSaveParameter, error) {
+	result := models.DraftSaveParameter{}
+	boardUid, err := strconv.ParseUint(c.FormValue("boardUid"), 10, 32)
+	if err != nil {
+		return result, fmt.Errorf("invalid board uid, not a valid number")
+	}
+	draftUid, _ := strconv.ParseUint(c.FormValue("draftUid"), 10, 32)
+	version, _ := strconv.ParseUint(c.FormValue("version"), 10, 32)
+	categoryUid, _ := strconv.ParseUint(c.FormValue("categoryUid"), 10, 32)
+	force, _ := strconv.ParseBool(c.FormValue("force"))
+	format := ParseContentFormat(c.FormValue("format"))
+	content := Sanitize(c.FormValue("content"))
+	if format == models.FORMAT_MARKDOWN {
+		content = c.FormValue("content")
+	}
+	if len(content) > models.CONTENT_MAX_LENGTH {
+		return result, fmt.Errorf("invalid content, too long")
+	}
+
+	var attachments []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		attachments = form.File["attachments[]"]
+	}
+	if len(attachments) > 0 {
+		fileSizeLimit, _ := strconv.ParseInt(configs.Env.FileSizeLimit, 10, 32)
+		var totalFileSize int64
+		for _, fileHeader := range attachments {
+			totalFileSize += fileHeader.Size
+		}
+		if totalFileSize > fileSizeLimit {
+			return result, fmt.Errorf("uploaded files exceed size limitation")
+		}
+		if storageLeft != models.STORAGE_UNLIMITED && totalFileSize > storageLeft {
+			return result, fmt.Errorf("uploaded files exceed your storage quota")
+		}
+	}
+
+	result = models.DraftSaveParameter{
+		DraftUid:    uint(draftUid),
 		BoardUid:    uint(boardUid),
-		UserUid:     uint(actionUserUid),
+		UserUid:     uint(ExtractUserUid(c.Get(models.AUTH_KEY))),
 		CategoryUid: uint(categoryUid),
-		Title:       title,
+		Version:     uint(version),
+		Title:       CutString(Escape(c.FormValue("title")), 299),
 		Content:     content,
+		Format:      format,
+		Tags:        CutString(c.FormValue("tags"), 300),
 		Files:       attachments,
-		Tags:        tagArr,
-		IsNotice:    isNotice,
-		IsSecret:    isSecret,
+		Force:       force,
 	}
 	return result, nil
 }
@@ -110,13 +271,14 @@ func initSanitizePolicy() {
 	allowedTags := []string{
 		"h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "p", "a", "s",
 		"ul", "ol", "nl", "li", "b", "i", "strong", "em", "mark", "span",
-		"strike", "code", "hr", "br", "div", "table", "iframe",
+		"strike", "del", "sup", "code", "hr", "br", "div", "table", "iframe",
 		"thead", "caption", "tbody", "tr", "th", "td", "pre", "img",
 	}
 	sanitizePolicy.AllowElements(allowedTags...)
 	sanitizePolicy.AllowAttrs("style", "class").OnElements(allowedTags...)
 	sanitizePolicy.AllowAttrs("href", "name", "target").OnElements("a")
 	sanitizePolicy.AllowAttrs("src", "alt").OnElements("img")
+	sanitizePolicy.AllowAttrs("id").Matching(regexp.MustCompile(`^fn(ref\d*)?:\d+$`)).OnElements("sup", "li")
 	sanitizePolicy.AllowAttrs(
 		"width", "height", "allowfullscreen", "autoplay", "disablekbcontrols",
 		"enableiframeapi", "endtime", "ivloadpolicy", "loop", "modestbranding",
@@ -135,12 +297,77 @@ func GetContentStatus(isNotice bool, isSecret bool) models.Status {
 	return status
 }
 
+// 게시판 승인 대기 옵션에 따라 새 글을 검토해야 하는지 확인 (publishedCount 는 이미 공개된 글 개수)
+func NeedModeration(opt models.BoardModerationOption, userLevel int, publishedCount uint) bool {
+	switch opt.Mode {
+	case models.MODERATION_ALL:
+		return true
+	case models.MODERATION_FIRST_POSTS:
+		return publishedCount < opt.Threshold
+	case models.MODERATION_LEVEL:
+		return userLevel < int(opt.Threshold)
+	default:
+		return false
+	}
+}
+
 // Sanitize 정책 가져오기
 func getSanitizePolicy() *bluemonday.Policy {
 	once.Do(initSanitizePolicy)
 	return sanitizePolicy
 }
 
+// 게시판별 설정이 있다면 그대로 쓰고, 없다면 기본 이모지 목록으로 반응 옵션 만들기
+func MergeReactionOption(board models.BoardReactionOption, isCustom bool) models.BoardReactionOption {
+	if isCustom && len(board.Emojis) > 0 {
+		return board
+	}
+	return models.BoardReactionOption{
+		Emojis:   ParseReactionEmojis(models.REACTION_DEFAULT_EMOJIS),
+		Multiple: board.Multiple,
+	}
+}
+
+// 쉼표로 구분된 이모지 목록을 중복 없이 변환하기 (문자, 공백 등 이모지가 아닌 값은 제외)
+func ParseReactionEmojis(emojis string) []string {
+	result := make([]string, 0)
+	added := make(map[string]bool)
+	for _, token := range strings.Split(emojis, ",") {
+		emoji := strings.TrimSpace(token)
+		if len(emoji) < 1 || utf8.RuneCountInString(emoji) > models.REACTION_MAX_LENGTH || added[emoji] {
+			continue
+		}
+		if strings.ContainsFunc(emoji, func(r rune) bool {
+			return r < utf8.RuneSelf && !strings.ContainsRune("#*0123456789", r)
+		}) {
+			continue
+		}
+		added[emoji] = true
+		result = append(result, emoji)
+		if len(result) >= models.REACTION_MAX_EMOJIS {
+			break
+		}
+	}
+	return result
+}
+
+// 본문 형식 문자열 변환하기 (알 수 없는 값이면 HTML 로 처리)
+func ParseContentFormat(format string) models.ContentFormat {
+	if strings.ToLower(strings.TrimSpace(format)) == models.FORMAT_MARKDOWN.String() {
+		return models.FORMAT_MARKDOWN
+	}
+	return models.FORMAT_HTML
+}
+
+// 마크다운 원문을 HTML 로 변환한 후 허용된 태그만 남겨두기 (GFM 표, 코드 블록, 각주 지원)
+func RenderMarkdown(source string) string {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return ""
+	}
+	return Sanitize(buf.String())
+}
+
 // 입력 문자열 중 HTML 태그들은 허용된 것만 남겨두기
 func Sanitize(input string) string {
 	policy := getSanitizePolicy()
@@ -153,3 +380,67 @@ func Purify(input string) string {
 	result := re.ReplaceAllString(input, "")
 	return strings.ToLower(result)
 }
+
+// 지정한 분류와 그 아래 모든 하위 분류들의 고유 번호 목록 반환 (없는 분류면 빈 목록)
+func GetCategoryFamily(cats []models.BoardCategory, rootUid uint) []uint {
+	if !hasCategory(cats, rootUid) {
+		return make([]uint, 0)
+	}
+	family := []uint{rootUid}
+	visited := map[uint]bool{rootUid: true}
+	for i := 0; i < len(family); i++ {
+		for _, cat := range cats {
+			if cat.ParentUid == family[i] && !visited[cat.Uid] {
+				visited[cat.Uid] = true
+				family = append(family, cat.Uid)
+			}
+		}
+	}
+	return family
+}
+
+// 분류의 깊이 반환 (최상위 분류는 1, 없는 분류는 0, 순환 참조면 분류 개수보다 큰 값)
+func GetCategoryDepth(cats []models.BoardCategory, categoryUid uint) int {
+	parents := make(map[uint]uint)
+	for _, cat := range cats {
+		parents[cat.Uid] = cat.ParentUid
+	}
+	depth := 0
+	for uid := categoryUid; uid > 0 && depth <= len(cats); depth++ {
+		parent, ok := parents[uid]
+		if !ok {
+			break
+		}
+		uid = parent
+	}
+	return depth
+}
+
+// 분류 아래로 이어지는 하위 분류 단계 수 반환 (하위 분류가 없으면 1, 없는 분류면 0)
+func GetCategoryHeight(cats []models.BoardCategory, categoryUid uint) int {
+	if !hasCategory(cats, categoryUid) {
+		return 0
+	}
+	height := 0
+	level := []uint{categoryUid}
+	visited := map[uint]bool{categoryUid: true}
+	for len(level) > 0 {
+		height++
+		next := make([]uint, 0)
+		for _, cat := range cats {
+			if !visited[cat.Uid] && slices.Contains(level, cat.ParentUid) {
+				visited[cat.Uid] = true
+				next = append(next, cat.Uid)
+			}
+		}
+		level = next
+	}
+	return height
+}
+
+// 분류 목록에 지정한 분류가 있는지 확인
+func hasCategory(cats []models.BoardCategory, categoryUid uint) bool {
+	return categoryUid > 0 && slices.ContainsFunc(cats, func(cat models.BoardCategory) bool {
+		return cat.Uid == categoryUid
+	})
+}
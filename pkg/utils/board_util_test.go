@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+func makeTestCategory(uid uint, parentUid uint) models.BoardCategory {
+	return models.BoardCategory{Pair: models.Pair{Uid: uid}, ParentUid: parentUid}
+}
+
+// 1 ─ 2 ─ 3, 4 (최상위)
+func makeTestCategoryTree() []models.BoardCategory {
+	return []models.BoardCategory{
+		makeTestCategory(1, 0),
+		makeTestCategory(2, 1),
+		makeTestCategory(3, 2),
+		makeTestCategory(4, 0),
+	}
+}
+
+func TestGetCategoryFamily(t *testing.T) {
+	cats := makeTestCategoryTree()
+	tests := []struct {
+		name string
+		root uint
+		want []uint
+	}{
+		{"root with descendants", 1, []uint{1, 2, 3}},
+		{"middle", 2, []uint{2, 3}},
+		{"leaf", 4, []uint{4}},
+		{"zero uid", 0, []uint{}},
+		{"missing root", 99, []uint{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetCategoryFamily(cats, tt.root)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetCategoryFamily(%d) = %v, want %v", tt.root, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCategoryFamilyCycle(t *testing.T) {
+	cats := []models.BoardCategory{makeTestCategory(1, 2), makeTestCategory(2, 1)}
+	got := GetCategoryFamily(cats, 1)
+	slices.Sort(got)
+	if !slices.Equal(got, []uint{1, 2}) {
+		t.Errorf("GetCategoryFamily on a cycle = %v, want [1 2]", got)
+	}
+}
+
+func TestGetCategoryDepth(t *testing.T) {
+	cats := makeTestCategoryTree()
+	tests := []struct {
+		uid  uint
+		want int
+	}{
+		{1, 1}, {2, 2}, {3, 3}, {4, 1}, {0, 0}, {99, 0},
+	}
+	for _, tt := range tests {
+		if got := GetCategoryDepth(cats, tt.uid); got != tt.want {
+			t.Errorf("GetCategoryDepth(%d) = %d, want %d", tt.uid, got, tt.want)
+		}
+	}
+}
+
+func TestGetCategoryDepthCycle(t *testing.T) {
+	cats := []models.BoardCategory{makeTestCategory(1, 2), makeTestCategory(2, 1)}
+	if got := GetCategoryDepth(cats, 1); got <= len(cats) {
+		t.Errorf("GetCategoryDepth on a cycle = %d, want more than %d", got, len(cats))
+	}
+}
+
+func TestGetCategoryHeight(t *testing.T) {
+	cats := makeTestCategoryTree()
+	tests := []struct {
+		uid  uint
+		want int
+	}{
+		{1, 3}, {2, 2}, {3, 1}, {4, 1}, {0, 0}, {99, 0},
+	}
+	for _, tt := range tests {
+		if got := GetCategoryHeight(cats, tt.uid); got != tt.want {
+			t.Errorf("GetCategoryHeight(%d) = %d, want %d", tt.uid, got, tt.want)
+		}
+	}
+}
+
+func TestGetCategoryHeightCycle(t *testing.T) {
+	cats := []models.BoardCategory{makeTestCategory(1, 2), makeTestCategory(2, 1)}
+	if got := GetCategoryHeight(cats, 1); got != 2 {
+		t.Errorf("GetCategoryHeight on a cycle = %d, want 2", got)
+	}
+}
@@ -2,12 +2,18 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/sirini/goapi/pkg/models"
 )
 
+// 본문 속 @이름 형태의 언급 (이메일 주소처럼 앞에 문자가 붙은 경우는 제외)
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@/])@([\p{L}\p{N}_.\-]{2,30})`)
+
 // 새 댓글 및 답글 작성 시 파라미터 체크
 func CheckCommentParameters(c fiber.Ctx) (models.CommentWriteParameter, error) {
 	result := models.CommentWriteParameter{}
@@ -35,3 +41,19 @@ func CheckCommentParameters(c fiber.Ctx) (models.CommentWriteParameter, error) {
 	}
 	return result, nil
 }
+
+// 본문에서 언급한 이름들을 중복 없이 추출하기 (최대 MENTION_MAX_COUNT 개)
+func ExtractMentions(content string) []string {
+	names := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		name := strings.TrimRight(match[1], ".-")
+		if len(name) < 2 || slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+		if len(names) >= models.MENTION_MAX_COUNT {
+			break
+		}
+	}
+	return names
+}
@@ -9,6 +9,20 @@ import (
 	"github.com/sirini/goapi/pkg/models"
 )
 
+// User-Agent 가 비어 있거나 봇(크롤러, 스크립트)으로 보이는지 확인
+func IsBotAgent(userAgent string) bool {
+	agent := strings.ToLower(strings.TrimSpace(userAgent))
+	if len(agent) < 1 {
+		return true
+	}
+	for _, keyword := range models.VIEW_BOT_KEYWORDS {
+		if strings.Contains(agent, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
 // HTML 문자열을 이스케이프
 func Escape(raw string) string {
 	safeStr := template.HTMLEscapeString(raw)
@@ -17,6 +31,11 @@ func Escape(raw string) string {
 	return safeStr
 }
 
+// LIKE 검색어의 와일드카드 문자(%, _)와 이스케이프 문자 처리
+func EscapeLike(raw string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(raw)
+}
+
 // HTML 문자열 이스케이프 해제
 func Unescape(escaped string) string {
 	originStr := html.UnescapeString(escaped)
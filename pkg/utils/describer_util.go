@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 이미지 설명글을 생성해주는 제공자 정의
+type ImageDescriber interface {
+	Describe(path string, opt models.ImageDescribeOption) (string, error)
+}
+
+// OpenAI API를 이용하는 제공자
+type OpenaiDescriber struct {
+	key string
+}
+
+// OpenAI 호환 API를 제공하는 로컬 모델(Ollama 등)을 이용하는 제공자
+type LocalDescriber struct {
+	endpoint string
+	key      string
+	client   *http.Client
+}
+
+// 설정에 맞는 이미지 설명글 제공자 가져오기 (사용하지 않는다면 nil 반환)
+func NewImageDescriber() ImageDescriber {
+	switch configs.Env.ImageDescProvider {
+	case models.DESCRIBER_OPENAI:
+		if len(configs.Env.OpenaiKey) < 1 {
+			return nil
+		}
+		return &OpenaiDescriber{key: configs.Env.OpenaiKey}
+	case models.DESCRIBER_LOCAL:
+		if len(configs.Env.ImageDescEndpoint) < 1 {
+			return nil
+		}
+		return &LocalDescriber{
+			endpoint: strings.TrimRight(configs.Env.ImageDescEndpoint, "/"),
+			key:      configs.Env.ImageDescKey,
+			client:   &http.Client{Timeout: models.DESCRIBER_TIMEOUT},
+		}
+	default:
+		return nil
+	}
+}
+
+// 사이트 기본 설정값에 게시판별 설정값을 덮어써서 최종 옵션 만들기
+func MergeDescribeOption(board models.ImageDescribeOption) models.ImageDescribeOption {
+	result := models.ImageDescribeOption{
+		Model:    configs.Env.ImageDescModel,
+		Prompt:   configs.Env.ImageDescPrompt,
+		Language: configs.Env.ImageDescLanguage,
+	}
+	if len(board.Model) > 0 {
+		result.Model = board.Model
+	}
+	if len(board.Prompt) > 0 {
+		result.Prompt = board.Prompt
+	}
+	if len(board.Language) > 0 {
+		result.Language = board.Language
+	}
+	if len(result.Model) < 1 {
+		result.Model = defaultDescribeModel(configs.Env.ImageDescProvider)
+	}
+	return result
+}
+
+// 제공자별 기본 모델 가져오기
+func defaultDescribeModel(provider string) string {
+	if provider == models.DESCRIBER_LOCAL {
+		return models.DESCRIBER_LOCAL_MODEL
+	}
+	return models.DESCRIBER_OPENAI_MODEL
+}
+
+// 프롬프트에 언어 지정해서 반환하기 (관리자가 입력한 프롬프트이므로 서식 문자열로 쓰지 않고 %s 만 치환)
+func makeDescribePrompt(opt models.ImageDescribeOption) string {
+	prompt := opt.Prompt
+	if len(prompt) < 1 {
+		prompt = "Describe the content of this image in %s."
+	}
+	return strings.ReplaceAll(prompt, "%s", opt.Language)
+}
+
+// 설명글 요청을 위해 이미지를 base64 형태의 jpeg 데이터로 변환하기
+func makeDescribeImage(path string) (string, error) {
+	jpgTempPath, err := MakeTempJpeg(path)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(jpgTempPath)
+	encoded, err := EncodeImage(jpgTempPath)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("data:image/jpeg;base64,%s", encoded), nil
+}
+
+// OpenAI의 API를 이용해서 사진에 대한 설명 가져오기
+func (d *OpenaiDescriber) Describe(path string, opt models.ImageDescribeOption) (string, error) {
+	imageUrl, err := makeDescribeImage(path)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), models.DESCRIBER_TIMEOUT)
+	defer cancel()
+
+	client := openai.NewClient(option.WithAPIKey(d.key))
+	result, err := client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model: openai.F(opt.Model),
+		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
+			openai.ChatCompletionUserMessageParam{
+				Role: openai.F(openai.ChatCompletionUserMessageParamRoleUser),
+				Content: openai.F([]openai.ChatCompletionContentPartUnionParam{
+					openai.ChatCompletionContentPartTextParam{
+						Type: openai.F(openai.ChatCompletionContentPartTextTypeText),
+						Text: openai.F(makeDescribePrompt(opt)),
+					},
+					openai.ChatCompletionContentPartImageParam{
+						Type: openai.F(openai.ChatCompletionContentPartImageTypeImageURL),
+						ImageURL: openai.F(openai.ChatCompletionContentPartImageImageURLParam{
+							URL:    openai.F(imageUrl),
+							Detail: openai.F(openai.ChatCompletionContentPartImageImageURLDetailLow),
+						}),
+					},
+				}),
+			},
+		}),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(result.Choices) < 1 {
+		return "", fmt.Errorf("empty response from openai")
+	}
+	return result.Choices[0].Message.Content, nil
+}
+
+// OpenAI 호환 엔드포인트(/chat/completions)로 사진에 대한 설명 가져오기
+func (d *LocalDescriber) Describe(path string, opt models.ImageDescribeOption) (string, error) {
+	imageUrl, err := makeDescribeImage(path)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model":  opt.Model,
+		"stream": false,
+		"messages": []map[string]interface{}{
+			{
+				"role": "user",
+				"content": []map[string]interface{}{
+					{"type": "text", "text": makeDescribePrompt(opt)},
+					{"type": "image_url", "image_url": map[string]string{"url": imageUrl}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.endpoint+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(d.key) > 0 {
+		req.Header.Set("Authorization", "Bearer "+d.key)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		return "", fmt.Errorf("local describer returned %d: %s", resp.StatusCode, string(message))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if len(result.Choices) < 1 {
+		return "", fmt.Errorf("empty response from local describer")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
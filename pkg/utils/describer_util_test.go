@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+func TestMakeDescribePrompt(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"", "Describe the content of this image in Korean."},
+		{"Describe in %s, then translate to %s.", "Describe in Korean, then translate to Korean."},
+		{"Keep it under 100% accurate, %d words", "Keep it under 100% accurate, %d words"},
+		{"No language here", "No language here"},
+	}
+	for _, tt := range tests {
+		opt := models.ImageDescribeOption{Prompt: tt.prompt, Language: "Korean"}
+		if got := makeDescribePrompt(opt); got != tt.want {
+			t.Errorf("makeDescribePrompt(%q) = %q, want %q", tt.prompt, got, tt.want)
+		}
+	}
+}
+
+func TestMergeDescribeOptionModel(t *testing.T) {
+	original := configs.Env
+	t.Cleanup(func() { configs.Env = original })
+
+	tests := []struct {
+		provider string
+		envModel string
+		board    string
+		want     string
+	}{
+		{models.DESCRIBER_OPENAI, "", "", models.DESCRIBER_OPENAI_MODEL},
+		{models.DESCRIBER_LOCAL, "", "", models.DESCRIBER_LOCAL_MODEL},
+		{models.DESCRIBER_LOCAL, "qwen2.5vl", "", "qwen2.5vl"},
+		{models.DESCRIBER_LOCAL, "qwen2.5vl", "gemma3", "gemma3"},
+	}
+	for _, tt := range tests {
+		configs.Env.ImageDescProvider = tt.provider
+		configs.Env.ImageDescModel = tt.envModel
+		got := MergeDescribeOption(models.ImageDescribeOption{Model: tt.board})
+		if got.Model != tt.want {
+			t.Errorf("%s provider with %q/%q model = %q, want %q", tt.provider, tt.envModel, tt.board, got.Model, tt.want)
+		}
+	}
+}
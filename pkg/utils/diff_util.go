@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 게시글 본문(HTML)을 비교하기 좋게 블록 단위의 줄들로 나누기
+func SplitContentLines(content string) []string {
+	replacer := strings.NewReplacer("</p>", "</p>\n", "<br>", "<br>\n", "<br/>", "<br/>\n",
+		"</li>", "</li>\n", "</h1>", "</h1>\n", "</h2>", "</h2>\n", "</h3>", "</h3>\n",
+		"</blockquote>", "</blockquote>\n", "</pre>", "</pre>\n")
+	lines := make([]string, 0)
+	for _, line := range strings.Split(replacer.Replace(content), "\n") {
+		if trimmed := strings.TrimSpace(line); len(trimmed) > 0 {
+			lines = append(lines, trimmed)
+		}
+	}
+	return lines
+}
+
+// 쉼표로 구분된 태그 문자열을 빈 값 없이 나누기
+func SplitTags(tags string) []string {
+	return strings.FieldsFunc(tags, func(r rune) bool { return r == ',' })
+}
+
+// 두 줄 목록을 Myers 알고리즘(선형 공간)으로 비교하기
+func DiffLines(before []string, after []string) []models.DiffLine {
+	if len(before)+len(after) > models.DIFF_MAX_LINES {
+		result := make([]models.DiffLine, 0, len(before)+len(after))
+		for _, line := range before {
+			result = append(result, models.DiffLine{Type: models.DIFF_DELETE, Text: line})
+		}
+		for _, line := range after {
+			result = append(result, models.DiffLine{Type: models.DIFF_INSERT, Text: line})
+		}
+		return result
+	}
+
+	size := (len(before)+len(after)+1)/2 + 1
+	differ := lineDiffer{
+		a:       before,
+		b:       after,
+		forward: make([]int, 2*size+1),
+		reverse: make([]int, 2*size+1),
+		result:  make([]models.DiffLine, 0, len(before)+len(after)),
+	}
+	differ.compare(0, len(before), 0, len(after))
+	return differ.result
+}
+
+// 줄 단위 비교에 쓰는 상태 (forward, reverse 는 대각선별로 가장 멀리 간 위치)
+type lineDiffer struct {
+	a       []string
+	b       []string
+	forward []int
+	reverse []int
+	result  []models.DiffLine
+}
+
+// 구간을 비교해서 결과에 추가하기 (공통 앞뒤를 떼어낸 뒤 가운데 스네이크를 기준으로 나눠서 재귀 처리)
+func (d *lineDiffer) compare(aLo int, aHi int, bLo int, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.add(models.DIFF_EQUAL, d.a[aLo])
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+
+	switch {
+	case aLo == aHi:
+		for ; bLo < bHi; bLo++ {
+			d.add(models.DIFF_INSERT, d.b[bLo])
+		}
+	case bLo == bHi:
+		for ; aLo < aHi; aLo++ {
+			d.add(models.DIFF_DELETE, d.a[aLo])
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for ; x < u; x++ {
+			d.add(models.DIFF_EQUAL, d.a[x])
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.add(models.DIFF_EQUAL, d.a[aHi+i])
+	}
+}
+
+// 최단 편집 경로의 가운데에 있는 스네이크(같은 줄이 이어지는 구간)의 시작과 끝 위치 찾기
+func (d *lineDiffer) middleSnake(aLo int, aHi int, bLo int, bHi int) (int, int, int, int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	isOdd := delta%2 != 0
+	offset := (n+m+1)/2 + 1
+	d.forward[offset+1] = 0
+	d.reverse[offset+1] = 0
+
+	for step := 0; step <= (n+m+1)/2; step++ {
+		for k := -step; k <= step; k += 2 {
+			x := d.forward[offset+k+1]
+			if k != -step && (k == step || d.forward[offset+k-1] >= d.forward[offset+k+1]) {
+				x = d.forward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			d.forward[offset+k] = x
+			if isOdd && delta-k >= -(step-1) && delta-k <= step-1 && x+d.reverse[offset+delta-k] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		for k := -step; k <= step; k += 2 {
+			x := d.reverse[offset+k+1]
+			if k != -step && (k == step || d.reverse[offset+k-1] >= d.reverse[offset+k+1]) {
+				x = d.reverse[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			d.reverse[offset+k] = x
+			if !isOdd && delta-k >= -step && delta-k <= step && x+d.forward[offset+delta-k] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+	return aLo, bLo, aLo, bLo
+}
+
+// 비교 결과에 한 줄 추가하기
+func (d *lineDiffer) add(diffType models.DiffType, text string) {
+	d.result = append(d.result, models.DiffLine{Type: diffType, Text: text})
+}
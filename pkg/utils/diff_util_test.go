@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 비교 결과로부터 원래의 이전/이후 줄 목록 되살리기
+func applyDiff(diff []models.DiffLine) ([]string, []string) {
+	before, after := make([]string, 0), make([]string, 0)
+	for _, line := range diff {
+		if line.Type != models.DIFF_INSERT {
+			before = append(before, line.Text)
+		}
+		if line.Type != models.DIFF_DELETE {
+			after = append(after, line.Text)
+		}
+	}
+	return before, after
+}
+
+// 기준값으로 쓸 최장 공통 부분열 길이 구하기
+func lcsLength(a []string, b []string) int {
+	prev, curr := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = max(prev[j], curr[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func countEqual(diff []models.DiffLine) int {
+	count := 0
+	for _, line := range diff {
+		if line.Type == models.DIFF_EQUAL {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		after  []string
+		want   []models.DiffType
+	}{
+		{"both empty", nil, nil, []models.DiffType{}},
+		{"all inserted", nil, []string{"a", "b"}, []models.DiffType{models.DIFF_INSERT, models.DIFF_INSERT}},
+		{"all deleted", []string{"a"}, nil, []models.DiffType{models.DIFF_DELETE}},
+		{"same", []string{"a", "b"}, []string{"a", "b"}, []models.DiffType{models.DIFF_EQUAL, models.DIFF_EQUAL}},
+		{"insert in middle", []string{"a", "c"}, []string{"a", "b", "c"},
+			[]models.DiffType{models.DIFF_EQUAL, models.DIFF_INSERT, models.DIFF_EQUAL}},
+		{"replace", []string{"a", "b", "c"}, []string{"a", "x", "c"},
+			[]models.DiffType{models.DIFF_EQUAL, models.DIFF_DELETE, models.DIFF_INSERT, models.DIFF_EQUAL}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffLines(tt.before, tt.after)
+			types := make([]models.DiffType, 0, len(diff))
+			for _, line := range diff {
+				types = append(types, line.Type)
+			}
+			if !slices.Equal(types, tt.want) {
+				t.Errorf("DiffLines(%v, %v) = %v, want %v", tt.before, tt.after, types, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesIsMinimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "c", "d"}
+	makeLines := func() []string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		before, after := makeLines(), makeLines()
+		diff := DiffLines(before, after)
+		gotBefore, gotAfter := applyDiff(diff)
+		if !slices.Equal(gotBefore, before) || !slices.Equal(gotAfter, after) {
+			t.Fatalf("DiffLines(%v, %v) does not rebuild the inputs: %v", before, after, diff)
+		}
+		if got, want := countEqual(diff), lcsLength(before, after); got != want {
+			t.Fatalf("DiffLines(%v, %v) kept %d equal lines, want %d", before, after, got, want)
+		}
+	}
+}
+
+func TestDiffLinesTooLarge(t *testing.T) {
+	before := make([]string, models.DIFF_MAX_LINES)
+	after := []string{"x"}
+	diff := DiffLines(before, after)
+	if len(diff) != len(before)+len(after) || countEqual(diff) != 0 {
+		t.Errorf("oversized input should be reported as a full replacement, got %d lines", len(diff))
+	}
+}
@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//                                                                    //
+// 원본 이미지에 남아있는 위치(GPS) 등 민감한 메타데이터를 제거하는 기능들       //
+// 재인코딩 없이 메타데이터 영역만 손보기 때문에 원본 화질은 그대로 유지됨        //
+//                                                                    //
+
+// 값을 지워야 하는 민감한 EXIF 태그들 (작가, 소유자, 기기 일련번호, 제조사 노트)
+var sensitiveExifTags = map[uint16]bool{
+	0x013B: true,
+	0x927C: true,
+	0xA430: true,
+	0xA431: true,
+	0xA435: true,
+}
+
+// EXIF 태그 값 타입별 크기 (바이트)
+var exifTypeSizes = map[uint16]uint32{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+const (
+	exifIfdPointer = 0x8769
+	gpsIfdPointer  = 0x8825
+)
+
+// 이미지 파일에서 위치 정보 등 민감한 메타데이터 제거하기 (JPEG, PNG, WebP 지원)
+func StripImageMetadata(path string) error {
+	buffer, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var stripped []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		stripped = stripJpegMetadata(buffer)
+	case ".png":
+		stripped = stripPngMetadata(buffer)
+	case ".webp":
+		stripped = stripWebpMetadata(buffer)
+	default:
+		return nil
+	}
+
+	if bytes.Equal(buffer, stripped) {
+		return nil
+	}
+	return os.WriteFile(path, stripped, 0644)
+}
+
+// 주어진 폴더 아래 모든 이미지의 민감한 메타데이터를 제거하고 처리한 개수 반환
+func StripMetadataInDir(dir string) uint {
+	var count uint
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !IsImage(path) {
+			return nil
+		}
+		if err := StripImageMetadata(path); err == nil {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// JPEG의 EXIF에서 GPS 및 민감한 태그들을 지우고, XMP 세그먼트는 통째로 제거하기
+func stripJpegMetadata(buffer []byte) []byte {
+	if len(buffer) < 4 || buffer[0] != 0xFF || buffer[1] != 0xD8 {
+		return buffer
+	}
+
+	result := make([]byte, 0, len(buffer))
+	result = append(result, buffer[:2]...)
+	pos := 2
+	for pos+4 <= len(buffer) {
+		if buffer[pos] != 0xFF {
+			break
+		}
+		marker := buffer[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+		length := int(binary.BigEndian.Uint16(buffer[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(buffer) {
+			break
+		}
+
+		segment := buffer[pos:end]
+		data := segment[4:]
+		if marker == 0xE1 && bytes.HasPrefix(data, []byte("http://ns.adobe.com/xap/1.0/")) {
+			pos = end
+			continue
+		}
+		if marker == 0xE1 && bytes.HasPrefix(data, []byte("Exif\x00\x00")) {
+			segment = append([]byte{}, segment...)
+			scrubTiff(segment[10:])
+		}
+		result = append(result, segment...)
+		pos = end
+	}
+	return append(result, buffer[pos:]...)
+}
+
+// TIFF 구조의 EXIF 데이터에서 GPS 영역을 비우고 민감한 태그 값들을 지우기
+func scrubTiff(tiff []byte) {
+	if len(tiff) < 8 {
+		return
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+
+	ifd0 := order.Uint32(tiff[4:8])
+	for _, entry := range readIfdEntries(tiff, order, ifd0) {
+		tag := order.Uint16(tiff[entry : entry+2])
+		switch {
+		case tag == gpsIfdPointer:
+			clearIfd(tiff, order, order.Uint32(tiff[entry+8:entry+12]))
+		case tag == exifIfdPointer:
+			exifIfd := order.Uint32(tiff[entry+8 : entry+12])
+			for _, sub := range readIfdEntries(tiff, order, exifIfd) {
+				if sensitiveExifTags[order.Uint16(tiff[sub:sub+2])] {
+					clearIfdValue(tiff, order, sub)
+				}
+			}
+		case sensitiveExifTags[tag]:
+			clearIfdValue(tiff, order, entry)
+		}
+	}
+}
+
+// IFD 항목들의 시작 위치 목록 반환하기
+func readIfdEntries(tiff []byte, order binary.ByteOrder, offset uint32) []uint32 {
+	entries := make([]uint32, 0)
+	if offset < 8 || uint64(offset)+2 > uint64(len(tiff)) {
+		return entries
+	}
+	count := uint32(order.Uint16(tiff[offset : offset+2]))
+	for i := uint32(0); i < count; i++ {
+		entry := offset + 2 + i*12
+		if uint64(entry)+12 > uint64(len(tiff)) {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// IFD 항목의 값(혹은 값이 저장된 영역)을 0으로 채우기
+func clearIfdValue(tiff []byte, order binary.ByteOrder, entry uint32) {
+	size, ok := exifTypeSizes[order.Uint16(tiff[entry+2:entry+4])]
+	if !ok {
+		size = 1
+	}
+	total := uint64(size) * uint64(order.Uint32(tiff[entry+4:entry+8]))
+	if total <= 4 {
+		clear(tiff[entry+8 : entry+12])
+		return
+	}
+	start := uint64(order.Uint32(tiff[entry+8 : entry+12]))
+	if start+total <= uint64(len(tiff)) {
+		clear(tiff[start : start+total])
+	}
+}
+
+// IFD의 모든 값을 지우고 항목이 없는 빈 IFD로 만들기
+func clearIfd(tiff []byte, order binary.ByteOrder, offset uint32) {
+	entries := readIfdEntries(tiff, order, offset)
+	if len(entries) < 1 {
+		return
+	}
+	for _, entry := range entries {
+		clearIfdValue(tiff, order, entry)
+	}
+	end := uint64(offset) + 2 + uint64(len(entries))*12 + 4
+	if end > uint64(len(tiff)) {
+		end = uint64(len(tiff))
+	}
+	clear(tiff[offset:end])
+}
+
+// PNG에서 eXIf 청크 및 XMP 텍스트 청크 제거하기
+func stripPngMetadata(buffer []byte) []byte {
+	signature := []byte("\x89PNG\r\n\x1a\n")
+	if !bytes.HasPrefix(buffer, signature) {
+		return buffer
+	}
+
+	result := make([]byte, 0, len(buffer))
+	result = append(result, signature...)
+	pos := len(signature)
+	for pos+12 <= len(buffer) {
+		length := int(binary.BigEndian.Uint32(buffer[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(buffer) {
+			break
+		}
+		chunkType := string(buffer[pos+4 : pos+8])
+		data := buffer[pos+8 : pos+8+length]
+		isXmp := chunkType == "iTXt" && bytes.HasPrefix(data, []byte("XML:com.adobe.xmp\x00"))
+		if chunkType != "eXIf" && !isXmp {
+			result = append(result, buffer[pos:end]...)
+		}
+		pos = end
+	}
+	return append(result, buffer[pos:]...)
+}
+
+// WebP에서 EXIF, XMP 청크를 제거하고 VP8X 플래그와 RIFF 크기 갱신하기
+func stripWebpMetadata(buffer []byte) []byte {
+	if len(buffer) < 12 || string(buffer[:4]) != "RIFF" || string(buffer[8:12]) != "WEBP" {
+		return buffer
+	}
+
+	result := make([]byte, 0, len(buffer))
+	result = append(result, buffer[:12]...)
+	pos := 12
+	for pos+8 <= len(buffer) {
+		fourcc := string(buffer[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(buffer[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if size < 0 || end > len(buffer) {
+			end = len(buffer)
+		}
+
+		if fourcc != "EXIF" && fourcc != "XMP " {
+			chunk := append([]byte{}, buffer[pos:end]...)
+			if fourcc == "VP8X" && len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04
+			}
+			result = append(result, chunk...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+	return result
+}
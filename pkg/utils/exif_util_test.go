@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+const (
+	testArtist = "Jane Photographer"
+	testXmp    = "<x:xmpmeta><exif:GPSLatitude>37,33.12N</exif:GPSLatitude></x:xmpmeta>"
+)
+
+// 1x1 무손실 WebP 이미지 (VP8L)
+const testWebpBase64 = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+type testIfdEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+// 방향(Orientation), 작가(Artist), GPS 위경도가 들어있는 리틀 엔디언 TIFF 만들기
+func makeTestTiff() []byte {
+	order := binary.LittleEndian
+	rational := func(values ...uint32) []byte {
+		raw := make([]byte, 0, len(values)*4)
+		for _, v := range values {
+			raw = order.AppendUint32(raw, v)
+		}
+		return raw
+	}
+	gps := []testIfdEntry{
+		{0x0001, 2, 2, []byte("N\x00")},
+		{0x0002, 5, 3, rational(37, 1, 33, 1, 1234, 100)},
+		{0x0003, 2, 2, []byte("E\x00")},
+		{0x0004, 5, 3, rational(126, 1, 58, 1, 4321, 100)},
+	}
+
+	const ifd0 = 8
+	gpsOffset := uint32(ifd0+2+3*12+4) + uint32(len(testArtist)+1)
+	tiff := []byte("II\x2a\x00\x08\x00\x00\x00")
+	tiff = appendTestIfd(tiff, []testIfdEntry{
+		{0x0112, 3, 1, order.AppendUint16(nil, 1)},
+		{0x013B, 2, uint32(len(testArtist) + 1), []byte(testArtist + "\x00")},
+		{gpsIfdPointer, 4, 1, order.AppendUint32(nil, gpsOffset)},
+	})
+	return appendTestIfd(tiff, gps)
+}
+
+// 리틀 엔디언 IFD를 덧붙이기 (4바이트를 넘는 값은 IFD 바로 뒤에 순서대로 저장)
+func appendTestIfd(tiff []byte, entries []testIfdEntry) []byte {
+	order := binary.LittleEndian
+	dataOffset := uint32(len(tiff)) + 2 + uint32(len(entries))*12 + 4
+	table := order.AppendUint16(nil, uint16(len(entries)))
+	data := make([]byte, 0)
+
+	for _, entry := range entries {
+		raw := make([]byte, 12)
+		order.PutUint16(raw[0:], entry.tag)
+		order.PutUint16(raw[2:], entry.kind)
+		order.PutUint32(raw[4:], entry.count)
+		if len(entry.value) <= 4 {
+			copy(raw[8:], entry.value)
+		} else {
+			order.PutUint32(raw[8:], dataOffset+uint32(len(data)))
+			data = append(data, entry.value...)
+		}
+		table = append(table, raw...)
+	}
+	table = append(table, 0, 0, 0, 0)
+	return append(append(tiff, table...), data...)
+}
+
+func makeTestImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 16), uint8(y * 16), 128, 255})
+		}
+	}
+	return img
+}
+
+// SOI 바로 뒤에 EXIF, XMP APP1 세그먼트를 끼워 넣은 JPEG 만들기
+func makeTestJpeg(t *testing.T) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, makeTestImage(), &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	app1 := func(payload []byte) []byte {
+		segment := []byte{0xFF, 0xE1, 0, 0}
+		binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+		return append(segment, payload...)
+	}
+	result := append([]byte{}, encoded.Bytes()[:2]...)
+	result = append(result, app1(append([]byte("Exif\x00\x00"), makeTestTiff()...))...)
+	result = append(result, app1([]byte("http://ns.adobe.com/xap/1.0/\x00"+testXmp))...)
+	return append(result, encoded.Bytes()[2:]...)
+}
+
+func makeTestPngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 4, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// IHDR 바로 뒤에 eXIf, XMP iTXt 청크를 끼워 넣은 PNG 만들기
+func makeTestPng(t *testing.T) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, makeTestImage()); err != nil {
+		t.Fatal(err)
+	}
+	const ihdrEnd = 8 + 12 + 13
+	result := append([]byte{}, encoded.Bytes()[:ihdrEnd]...)
+	result = append(result, makeTestPngChunk("eXIf", makeTestTiff())...)
+	result = append(result, makeTestPngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"+testXmp))...)
+	return append(result, encoded.Bytes()[ihdrEnd:]...)
+}
+
+func makeTestWebpChunk(fourcc string, data []byte) []byte {
+	chunk := []byte(fourcc)
+	chunk = binary.LittleEndian.AppendUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// VP8X 확장 형식에 EXIF, XMP 청크를 붙인 WebP 만들고 이미지 청크도 함께 반환
+func makeTestWebp(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	simple, err := base64.StdEncoding.DecodeString(testWebpBase64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bitstream := simple[12:]
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x08 | 0x04
+
+	body := []byte("WEBP")
+	body = append(body, makeTestWebpChunk("VP8X", vp8x)...)
+	body = append(body, bitstream...)
+	body = append(body, makeTestWebpChunk("EXIF", makeTestTiff())...)
+	body = append(body, makeTestWebpChunk("XMP ", []byte(testXmp))...)
+
+	result := []byte("RIFF")
+	result = binary.LittleEndian.AppendUint32(result, uint32(len(body)))
+	return append(result, body...), bitstream
+}
+
+func assertNoSensitiveData(t *testing.T, stripped []byte) {
+	t.Helper()
+	for _, secret := range []string{testArtist, testXmp} {
+		if bytes.Contains(stripped, []byte(secret)) {
+			t.Errorf("stripped image still contains %q", secret)
+		}
+	}
+}
+
+func assertSamePixels(t *testing.T, original []byte, stripped []byte) {
+	t.Helper()
+	before, _, err := image.Decode(bytes.NewReader(original))
+	if err != nil {
+		t.Fatalf("decode original: %v", err)
+	}
+	after, _, err := image.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatalf("decode stripped: %v", err)
+	}
+	if before.Bounds() != after.Bounds() {
+		t.Fatalf("bounds changed from %v to %v", before.Bounds(), after.Bounds())
+	}
+	bounds := before.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if before.At(x, y) != after.At(x, y) {
+				t.Fatalf("pixel (%d, %d) changed", x, y)
+			}
+		}
+	}
+}
+
+func TestStripJpegMetadata(t *testing.T) {
+	original := makeTestJpeg(t)
+	if x, err := exif.Decode(bytes.NewReader(original)); err != nil {
+		t.Fatalf("fixture has no readable EXIF: %v", err)
+	} else if _, _, err := x.LatLong(); err != nil {
+		t.Fatalf("fixture has no GPS: %v", err)
+	}
+
+	stripped := stripJpegMetadata(original)
+	assertNoSensitiveData(t, stripped)
+	assertSamePixels(t, original, stripped)
+
+	x, err := exif.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatalf("EXIF should stay readable after stripping: %v", err)
+	}
+	if lat, long, err := x.LatLong(); err == nil {
+		t.Errorf("GPS is still present: %f, %f", lat, long)
+	}
+	if tag, err := x.Get(exif.Orientation); err != nil {
+		t.Errorf("orientation should be kept: %v", err)
+	} else if value, _ := tag.Int(0); value != 1 {
+		t.Errorf("orientation = %d, want 1", value)
+	}
+	if len(stripped) >= len(original) {
+		t.Errorf("XMP segment was not removed (%d >= %d bytes)", len(stripped), len(original))
+	}
+}
+
+func TestStripPngMetadata(t *testing.T) {
+	original := makeTestPng(t)
+	stripped := stripPngMetadata(original)
+
+	if bytes.Contains(stripped, []byte("eXIf")) || bytes.Contains(stripped, []byte("iTXt")) {
+		t.Error("eXIf or XMP chunk is still present")
+	}
+	assertNoSensitiveData(t, stripped)
+	assertSamePixels(t, original, stripped)
+}
+
+func TestStripWebpMetadata(t *testing.T) {
+	original, bitstream := makeTestWebp(t)
+	stripped := stripWebpMetadata(original)
+
+	if bytes.Contains(stripped, []byte("EXIF")) || bytes.Contains(stripped, []byte("XMP ")) {
+		t.Error("EXIF or XMP chunk is still present")
+	}
+	assertNoSensitiveData(t, stripped)
+	if size := binary.LittleEndian.Uint32(stripped[4:8]); int(size) != len(stripped)-8 {
+		t.Errorf("RIFF size = %d, want %d", size, len(stripped)-8)
+	}
+	if flags := stripped[20]; flags&(0x08|0x04) != 0 {
+		t.Errorf("VP8X flags = %#x, EXIF and XMP bits should be cleared", flags)
+	}
+	if !bytes.HasSuffix(stripped, bitstream) {
+		t.Error("image bitstream changed")
+	}
+}
+
+func TestStripImageMetadataKeepsCleanFiles(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, makeTestImage()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stripPngMetadata(encoded.Bytes()), encoded.Bytes()) {
+		t.Error("PNG without metadata should not change")
+	}
+	for _, garbage := range [][]byte{nil, []byte("not an image"), {0xFF, 0xD8, 0xFF}} {
+		stripJpegMetadata(garbage)
+		stripPngMetadata(garbage)
+		stripWebpMetadata(garbage)
+	}
+}
+
+func TestStripImageMetadataFile(t *testing.T) {
+	dir := t.TempDir()
+	original := makeTestJpeg(t)
+	path := filepath.Join(dir, "photo.JPG")
+	if err := os.WriteFile(path, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if count := StripMetadataInDir(dir); count != 1 {
+		t.Fatalf("StripMetadataInDir = %d, want 1", count)
+	}
+	stripped, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoSensitiveData(t, stripped)
+	assertSamePixels(t, original, stripped)
+}
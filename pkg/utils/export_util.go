@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+var uploadLinkPattern = regexp.MustCompile(`(^|[\s"'(=])(/upload/[^\s"'<>()\[\]?#]+)`)
+
+// 압축 파일에 내용을 새 파일로 추가하기
+func AddZipContent(archive *zip.Writer, name string, content []byte) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
+
+// 업로드된 파일을 압축 파일에 복사해 넣기 (path 는 `/upload/...` 형태)
+func AddZipFile(archive *zip.Writer, name string, path string) error {
+	src, err := os.Open("." + path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, src)
+	return err
+}
+
+// 내보낼 때 사용할 첨부파일의 상대 경로 만들기
+func GetExportFilePath(path string) string {
+	return fmt.Sprintf("%s/%s", models.EXPORT_FILE_DIR, filepath.Base(path))
+}
+
+// 게시글을 front-matter 가 포함된 마크다운으로 변환하기
+func MakeExportMarkdown(post models.ExportPost) string {
+	var b strings.Builder
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, strconv.Quote(tag))
+	}
+
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "uid: %d\n", post.Uid)
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(post.Title))
+	fmt.Fprintf(&b, "category: %s\n", strconv.Quote(post.Category))
+	fmt.Fprintf(&b, "writer: %s\n", strconv.Quote(post.Writer))
+	fmt.Fprintf(&b, "writer_uid: %d\n", post.WriterUid)
+	fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(tags, ", "))
+	fmt.Fprintf(&b, "format: %s\n", post.Format)
+	fmt.Fprintf(&b, "notice: %t\n", post.Notice)
+	fmt.Fprintf(&b, "secret: %t\n", post.Secret)
+	fmt.Fprintf(&b, "hit: %d\n", post.Hit)
+	fmt.Fprintf(&b, "submitted: %s\n", formatExportTime(post.Submitted))
+	fmt.Fprintf(&b, "modified: %s\n", formatExportTime(post.Modified))
+	if len(post.Files) > 0 {
+		b.WriteString("files:\n")
+		for _, file := range post.Files {
+			fmt.Fprintf(&b, "  - name: %s\n    path: %s\n", strconv.Quote(file.Name), strconv.Quote(file.Path))
+		}
+	}
+	b.WriteString("---\n\n")
+
+	if post.Format == models.FORMAT_MARKDOWN.String() && len(post.Source) > 0 {
+		b.WriteString(post.Source)
+	} else {
+		b.WriteString(post.Content)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// 댓글들을 마크다운으로 변환하기 (답글은 원래 댓글 번호를 함께 표시)
+func MakeExportCommentsMarkdown(comments []models.ExportComment) string {
+	var b strings.Builder
+	b.WriteString("# Comments\n")
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "\n## #%d %s (%s)\n\n", comment.Uid, comment.Writer, formatExportTime(comment.Submitted))
+		if comment.ReplyUid > 0 && comment.ReplyUid != comment.Uid {
+			fmt.Fprintf(&b, "> reply to #%d\n\n", comment.ReplyUid)
+		}
+		b.WriteString(comment.Content)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// 내보낸 압축 파일을 저장할 경로 만들기 (공개되는 upload 폴더 밖)
+func MakeExportSavePath() (string, error) {
+	finalPath := fmt.Sprintf("%s/%s", models.EXPORT_SAVE_ROOT, time.Now().Format("2006/01/02"))
+	if err := os.MkdirAll(finalPath, 0700); err != nil {
+		return "", err
+	}
+	return finalPath, nil
+}
+
+// 이름으로 내보내기 형식 찾기
+func ParseExportFormat(name string) (models.ExportFormat, bool) {
+	switch strings.ToLower(name) {
+	case "", "md", models.EXPORT_MARKDOWN.String():
+		return models.EXPORT_MARKDOWN, true
+	case models.EXPORT_JSON.String():
+		return models.EXPORT_JSON, true
+	default:
+		return models.EXPORT_MARKDOWN, false
+	}
+}
+
+// 본문 속 업로드 경로들을 압축 파일 안의 상대 경로로 바꾸고 원래 경로들 반환하기
+func RewriteUploadLinks(text string) (string, []string) {
+	paths := make([]string, 0)
+	if len(configs.Env.URL) > 0 {
+		text = strings.ReplaceAll(text, strings.TrimSuffix(configs.Env.URL, "/")+"/upload/", "/upload/")
+	}
+
+	result := uploadLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := uploadLinkPattern.FindStringSubmatch(match)
+		if strings.Contains(groups[2], "..") {
+			return match
+		}
+		paths = append(paths, groups[2])
+		return groups[1] + GetExportFilePath(groups[2])
+	})
+	return result, paths
+}
+
+// 내보내기 파일에 기록할 시각 형식으로 변환하기
+func formatExportTime(timestamp uint64) string {
+	if timestamp < 1 {
+		return ""
+	}
+	return time.UnixMilli(int64(timestamp)).Format(time.RFC3339)
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 사용자 정의 필드 값 검사 후 저장할 형태로 변환하기 (빈 값은 그대로 빈 값 반환)
+func NormalizeFieldValue(field models.BoardField, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if len(input) < 1 {
+		return "", nil
+	}
+
+	switch field.Type {
+	case models.FIELD_NUMBER:
+		number, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s, not a valid number", field.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case models.FIELD_SELECT:
+		if !slices.Contains(field.Options, input) {
+			return "", fmt.Errorf("invalid %s, not one of the options", field.Name)
+		}
+		return input, nil
+	case models.FIELD_DATE:
+		date, err := time.Parse(models.FIELD_DATE_LAYOUT, input)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s, not a valid date", field.Name)
+		}
+		return date.Format(models.FIELD_DATE_LAYOUT), nil
+	case models.FIELD_URL:
+		link, err := url.ParseRequestURI(input)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || len(input) > models.FIELD_MAX_VALUE {
+			return "", fmt.Errorf("invalid %s, not a valid link", field.Name)
+		}
+		return Escape(input), nil
+	default:
+		return CutString(Escape(input), models.FIELD_MAX_VALUE), nil
+	}
+}
+
+// 쉼표로 구분된 선택형 필드의 선택지들을 중복 없이 정리하기
+func ParseFieldOptions(options string) []string {
+	result := make([]string, 0)
+	for _, token := range strings.Split(options, ",") {
+		option := CutString(Escape(strings.TrimSpace(token)), models.FIELD_MAX_NAME)
+		if len(option) < 1 || slices.Contains(result, option) {
+			continue
+		}
+		result = append(result, option)
+		if len(result) >= models.FIELD_MAX_OPTIONS {
+			break
+		}
+	}
+	return result
+}
+
+// 게시판에 정의된 필드들로 목록 필터 조건 만들기 (filters 는 {"필드 번호": "값"} 형태의 JSON)
+func ParseFieldFilters(filters string, fields []models.BoardField) []models.BoardFieldFilter {
+	result := make([]models.BoardFieldFilter, 0)
+	values := make(map[string]string)
+	if len(filters) < 1 || json.Unmarshal([]byte(filters), &values) != nil {
+		return result
+	}
+
+	for _, field := range fields {
+		value, err := NormalizeFieldValue(field, values[strconv.FormatUint(uint64(field.Uid), 10)])
+		if err != nil || len(value) < 1 {
+			continue
+		}
+		result = append(result, models.BoardFieldFilter{
+			FieldUid: field.Uid,
+			Type:     field.Type,
+			Value:    value,
+		})
+	}
+	return result
+}
+
+// 게시판 관리자가 추가, 수정하는 사용자 정의 필드 파라미터 검사 (fieldUid 는 수정 시에만 필요)
+func CheckFieldDefinition(c fiber.Ctx) (models.BoardField, error) {
+	field := models.BoardField{Options: make([]string, 0)}
+	fieldUid, _ := strconv.ParseUint(c.FormValue("fieldUid"), 10, 32)
+	fieldType, err := strconv.ParseUint(c.FormValue("type"), 10, 8)
+	if err != nil || !models.FieldType(fieldType).IsValid() {
+		return field, fmt.Errorf("invalid field type")
+	}
+	required, err := strconv.ParseBool(c.FormValue("required"))
+	if err != nil {
+		return field, fmt.Errorf("invalid required, it should be 0 or 1")
+	}
+
+	field.Uid = uint(fieldUid)
+	field.Type = models.FieldType(fieldType)
+	field.Required = required
+	field.Name = CutString(Escape(strings.TrimSpace(c.FormValue("name"))), models.FIELD_MAX_NAME)
+	if utf8.RuneCountInString(field.Name) < 1 {
+		return field, fmt.Errorf("invalid field name, too short")
+	}
+	if field.Type == models.FIELD_SELECT {
+		field.Options = ParseFieldOptions(CutString(c.FormValue("options"), 1000))
+		if len(field.Options) < 1 {
+			return field, fmt.Errorf("invalid options, select field needs at least one option")
+		}
+	}
+	return field, nil
+}
+
+// 글 작성 시 게시판에 정의된 사용자 정의 필드 값들 검사 (fields[필드 번호] 형태로 전달)
+func checkFieldParameters(c fiber.Ctx, fields []models.BoardField) ([]models.BoardFieldValue, error) {
+	result := make([]models.BoardFieldValue, 0)
+	for _, field := range fields {
+		value, err := NormalizeFieldValue(field, c.FormValue(fmt.Sprintf("fields[%d]", field.Uid)))
+		if err != nil {
+			return result, err
+		}
+		if field.Required && len(value) < 1 {
+			return result, fmt.Errorf("invalid %s, it is required", field.Name)
+		}
+		result = append(result, models.BoardFieldValue{
+			FieldUid: field.Uid,
+			Name:     field.Name,
+			Type:     field.Type,
+			Value:    value,
+		})
+	}
+	return result, nil
+}
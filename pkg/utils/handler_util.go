@@ -14,6 +14,16 @@ func Err(c fiber.Ctx, msg string, code models.Code) error {
 	})
 }
 
+// 에러 메시지와 함께 참고할 데이터 반환
+func ErrWithResult(c fiber.Ctx, msg string, code models.Code, result interface{}) error {
+	return c.JSON(models.ResponseCommon{
+		Success: false,
+		Result:  result,
+		Error:   msg,
+		Code:    code,
+	})
+}
+
 // 성공 메시지 및 데이터 반환
 func Ok(c fiber.Ctx, result interface{}) error {
 	return c.JSON(models.ResponseCommon{
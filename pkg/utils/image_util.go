@@ -1,19 +1,18 @@
 package utils
 
 import (
-	"context"
 	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/h2non/bimg"
-	"github.com/openai/openai-go"
-	"github.com/openai/openai-go/option"
 	"github.com/rwcarlsen/goexif/exif"
 	"github.com/sirini/goapi/internal/configs"
 	"github.com/sirini/goapi/pkg/models"
@@ -25,49 +24,6 @@ import (
 // Ubuntu Linux: sudo apt install libvips-dev                     //
 //                                                                //
 
-// OpenAI의 API를 이용해서 사진에 대한 설명 가져오기
-func AskImageDescription(path string) (string, error) {
-	if len(configs.Env.OpenaiKey) < 1 {
-		return "", fmt.Errorf("api key of openai is empty")
-	}
-	jpgTempPath, err := MakeTempJpeg(path)
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(jpgTempPath)
-	encoded, err := EncodeImage(jpgTempPath)
-	if err != nil {
-		return "", err
-	}
-
-	client := openai.NewClient(option.WithAPIKey(configs.Env.OpenaiKey))
-	result, err := client.Chat.Completions.New(context.TODO(), openai.ChatCompletionNewParams{
-		Model: openai.F("gpt-4o"),
-		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
-			openai.ChatCompletionUserMessageParam{
-				Role: openai.F(openai.ChatCompletionUserMessageParamRoleUser),
-				Content: openai.F([]openai.ChatCompletionContentPartUnionParam{
-					openai.ChatCompletionContentPartTextParam{
-						Type: openai.F(openai.ChatCompletionContentPartTextTypeText),
-						Text: openai.F("Describe the content of this image in Korean."),
-					},
-					openai.ChatCompletionContentPartImageParam{
-						Type: openai.F(openai.ChatCompletionContentPartImageTypeImageURL),
-						ImageURL: openai.F(openai.ChatCompletionContentPartImageImageURLParam{
-							URL:    openai.F(fmt.Sprintf("data:image/jpeg;base64,%s", encoded)),
-							Detail: openai.F(openai.ChatCompletionContentPartImageImageURLDetailLow),
-						}),
-					},
-				}),
-			},
-		}),
-	})
-	if err != nil {
-		return "", err
-	}
-	return result.Choices[0].Message.Content, nil
-}
-
 // URL로부터 이미지 경로를 받아서 지정된 크기로 줄이고 .webp 형식으로 저장
 func DownloadImage(imageUrl string, outputPath string, width uint) error {
 	resp, err := http.Get(imageUrl)
@@ -131,6 +87,8 @@ func ExtractExif(imagePath string) models.BoardExif {
 		return result
 	}
 
+	defer f.Close()
+
 	x, err := exif.Decode(f)
 	if err != nil {
 		return result
@@ -146,6 +104,11 @@ func ExtractExif(imagePath string) models.BoardExif {
 		result.Model, _ = model.StringVal()
 	}
 
+	lens, err := x.Get(exif.LensModel)
+	if err == nil {
+		result.Lens, _ = lens.StringVal()
+	}
+
 	aperture, err := x.Get(exif.FNumber)
 	if err == nil {
 		numerator, denominator, _ := aperture.Rat2(0)
@@ -182,6 +145,18 @@ func ExtractExif(imagePath string) models.BoardExif {
 		result.Height = uint(h)
 	}
 
+	orientation, err := x.Get(exif.Orientation)
+	if err == nil {
+		o, _ := orientation.Int(0)
+		result.Orientation = uint(o)
+	}
+
+	lat, long, err := x.LatLong()
+	if err == nil {
+		result.Latitude = lat
+		result.Longitude = long
+	}
+
 	date, err := x.Get(exif.DateTime)
 	if err == nil {
 		timeStr, _ := date.StringVal()
@@ -199,10 +174,11 @@ func MakeTempJpeg(path string) (string, error) {
 
 	jpgTempPath := strings.ReplaceAll(path, ".webp", ".jpg")
 	options := bimg.Options{
-		Width:   int(configs.SIZE_PROFILE.Number()),
-		Height:  0,
-		Quality: 60,
-		Type:    bimg.JPEG,
+		Width:         int(configs.SIZE_PROFILE.Number()),
+		Height:        0,
+		Quality:       60,
+		Type:          bimg.JPEG,
+		StripMetadata: true,
 	}
 
 	processed, err := bimg.NewImage(buffer).Process(options)
@@ -229,10 +205,11 @@ func ResizeImage(inputPath string, outputPath string, width uint) error {
 // 바이트 버퍼 이미지를 지정된 크기로 줄여서 .webp 형식으로 저장
 func SaveImage(inputBuffer []byte, outputPath string, width uint) error {
 	options := bimg.Options{
-		Width:   int(width),
-		Height:  0,
-		Quality: 90,
-		Type:    bimg.WEBP,
+		Width:         int(width),
+		Height:        0,
+		Quality:       90,
+		Type:          bimg.WEBP,
+		StripMetadata: true,
 	}
 
 	processed, err := bimg.NewImage(inputBuffer).Process(options)
@@ -301,3 +278,89 @@ func SaveThumbnailImage(inputPath string) (models.BoardThumbnail, error) {
 	}
 	return result, nil
 }
+
+// 쉼표로 구분된 너비 목록을 중복 없이 작은 순서대로 변환하기
+func ParseVariantWidths(widths string) []uint {
+	result := make([]uint, 0)
+	added := make(map[uint]bool)
+	for _, token := range strings.Split(widths, ",") {
+		width, err := strconv.ParseUint(strings.TrimSpace(token), 10, 32)
+		if err != nil || width < 1 || added[uint(width)] {
+			continue
+		}
+		added[uint(width)] = true
+		result = append(result, uint(width))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// 게시판별 설정이 있다면 그대로 쓰고, 없다면 사이트 기본 설정으로 변형 이미지 옵션 만들기
+func MergeVariantOption(board models.BoardVariantOption, isCustom bool) models.BoardVariantOption {
+	if isCustom {
+		return board
+	}
+	return models.BoardVariantOption{
+		Widths: ParseVariantWidths(configs.Env.VariantWidths),
+		Avif:   configs.Env.VariantAvif == "true",
+	}
+}
+
+// 반응형 이미지용 변형 이미지들을 너비별로 저장하고 목록 반환 (원본보다 큰 너비는 생략)
+func SaveImageVariants(inputPath string, widths []uint, useAvif bool) ([]models.BoardImageVariant, error) {
+	variants := make([]models.BoardImageVariant, 0)
+	buffer, err := bimg.Read(inputPath)
+	if err != nil {
+		return variants, err
+	}
+	size, err := bimg.NewImage(buffer).Size()
+	if err != nil {
+		return variants, err
+	}
+	savePath, err := MakeSavePath(models.UPLOAD_THUMB)
+	if err != nil {
+		return variants, err
+	}
+
+	types := []bimg.ImageType{bimg.WEBP}
+	if useAvif && bimg.IsTypeSupportedSave(bimg.AVIF) {
+		types = append(types, bimg.AVIF)
+	}
+
+	randName := uuid.New().String()[:8]
+	for _, width := range widths {
+		if width >= uint(size.Width) {
+			continue
+		}
+		for _, imageType := range types {
+			processed, err := bimg.NewImage(buffer).Process(bimg.Options{
+				Width:         int(width),
+				Height:        0,
+				Quality:       80,
+				Type:          imageType,
+				StripMetadata: true,
+			})
+			if err != nil {
+				return variants, err
+			}
+
+			format := bimg.ImageTypeName(imageType)
+			outputPath := fmt.Sprintf("%s/v%s_%d.%s", savePath, randName, width, format)
+			if err := bimg.Write(outputPath, processed); err != nil {
+				return variants, err
+			}
+
+			variant := models.BoardImageVariant{
+				Path:   outputPath[1:],
+				Width:  width,
+				Format: format,
+			}
+			if resized, err := bimg.NewImage(processed).Size(); err == nil {
+				variant.Width = uint(resized.Width)
+				variant.Height = uint(resized.Height)
+			}
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 그누보드5 날짜 형식
+const gnuboardTimeLayout = "2006-01-02 15:04:05"
+
+// 그누보드5 SQL 덤프 파일에서 회원, 게시판, 분류, 게시글, 댓글, 첨부파일 읽기
+func ReadGnuboardDump(path string, prefix string) (models.ImportData, error) {
+	data := models.ImportData{}
+	tables, err := ReadSqlDump(path, prefix)
+	if err != nil {
+		return data, err
+	}
+	if len(tables["board"]) < 1 {
+		return data, fmt.Errorf("unable to find %sboard table in the dump file", prefix)
+	}
+
+	for _, row := range tables["member"] {
+		if len(row["mb_leave_date"]) > 0 {
+			continue
+		}
+		name := row["mb_nick"]
+		if len(name) < 1 {
+			name = row["mb_name"]
+		}
+		data.Users = append(data.Users, models.ImportUser{
+			SourceId:  row["mb_id"],
+			Email:     row["mb_email"],
+			Name:      name,
+			Password:  row["mb_password"],
+			Level:     min(max(atoiOrZero(row["mb_level"])-1, 0), 9),
+			Point:     uint(max(atoiOrZero(row["mb_point"]), 0)),
+			Signature: StripTags(row["mb_signature"]),
+			Signup:    ParseImportTime(gnuboardTimeLayout, row["mb_datetime"]),
+		})
+	}
+
+	for _, board := range tables["board"] {
+		boardId := board["bo_table"]
+		data.Boards = append(data.Boards, models.ImportBoard{
+			SourceId: boardId,
+			Id:       MakeImportBoardId(boardId),
+			Name:     board["bo_subject"],
+		})
+		for _, name := range strings.Split(board["bo_category_list"], "|") {
+			if name = strings.TrimSpace(name); len(name) > 0 {
+				data.Categories = append(data.Categories, models.ImportCategory{
+					SourceId:      boardId + "/" + name,
+					BoardSourceId: boardId,
+					Name:          name,
+				})
+			}
+		}
+
+		notices := make(map[string]bool)
+		for _, wrId := range strings.Split(board["bo_notice"], ",") {
+			notices[strings.TrimSpace(wrId)] = true
+		}
+		readGnuboardWrites(&data, boardId, tables["write_"+boardId], notices)
+	}
+
+	for _, file := range tables["board_file"] {
+		if len(file["bf_file"]) < 1 {
+			continue
+		}
+		boardId := file["bo_table"]
+		data.Files = append(data.Files, models.ImportFile{
+			SourceId:     fmt.Sprintf("%s/%s/%s", boardId, file["wr_id"], file["bf_no"]),
+			PostSourceId: boardId + "/" + file["wr_id"],
+			Name:         file["bf_source"],
+			Path:         fmt.Sprintf("data/file/%s/%s", boardId, file["bf_file"]),
+		})
+	}
+	return data, nil
+}
+
+// 게시판별 write 테이블에서 게시글과 댓글 읽기 (같은 wr_comment 묶음의 첫 댓글에 답글로 연결)
+func readGnuboardWrites(data *models.ImportData, boardId string, rows SqlRows, notices map[string]bool) {
+	threads := make(map[string]string)
+	for _, row := range rows {
+		sourceId := boardId + "/" + row["wr_id"]
+		submitted := ParseImportTime(gnuboardTimeLayout, row["wr_datetime"])
+
+		if row["wr_is_comment"] != "1" {
+			post := models.ImportPost{
+				SourceId:       sourceId,
+				BoardSourceId:  boardId,
+				WriterSourceId: row["mb_id"],
+				Title:          row["wr_subject"],
+				Content:        row["wr_content"],
+				IsNotice:       notices[row["wr_id"]],
+				IsSecret:       strings.Contains(row["wr_option"], "secret"),
+				Hit:            uint(max(atoiOrZero(row["wr_hit"]), 0)),
+				Submitted:      submitted,
+				Modified:       ParseImportTime(gnuboardTimeLayout, row["wr_last"]),
+				Tags:           make([]string, 0),
+			}
+			if !strings.Contains(row["wr_option"], "html") {
+				post.Content = MakeImportPlainText(post.Content)
+			}
+			if len(row["ca_name"]) > 0 {
+				post.CategorySourceId = boardId + "/" + row["ca_name"]
+			}
+			data.Posts = append(data.Posts, post)
+			continue
+		}
+
+		comment := models.ImportComment{
+			SourceId:       sourceId,
+			PostSourceId:   boardId + "/" + row["wr_parent"],
+			WriterSourceId: row["mb_id"],
+			Content:        MakeImportPlainText(row["wr_content"]),
+			Submitted:      submitted,
+		}
+		thread := row["wr_parent"] + "/" + row["wr_comment"]
+		if root, isExist := threads[thread]; isExist && len(row["wr_comment_reply"]) > 0 {
+			comment.ReplySourceId = root
+		} else if !isExist {
+			threads[thread] = sourceId
+		}
+		data.Comments = append(data.Comments, comment)
+	}
+}
@@ -0,0 +1,473 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+var boardIdPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+var insertPattern = regexp.MustCompile(`(?is)^(?:INSERT|REPLACE)\s+(?:IGNORE\s+)?INTO\s+(.+?)\s*VALUES\s*`)
+
+// 덤프 파일 속 한 테이블의 행들 (컬럼명과 값)
+type SqlRows []map[string]string
+
+// SQL 문장 하나를 한 글자씩 읽어나가는 파서
+type sqlDumpParser struct {
+	src string
+	pos int
+}
+
+// SQL 덤프 파일을 전부 불러오지 않고 문장 단위로 읽어주는 리더
+type sqlStatementReader struct {
+	r *bufio.Reader
+	b strings.Builder
+}
+
+// 원본 형식에 맞는 파서로 가져올 데이터 읽기
+func ReadImportData(param models.ImportParameter) (models.ImportData, error) {
+	switch param.Format {
+	case models.IMPORT_GNUBOARD:
+		return ReadGnuboardDump(param.Path, param.Prefix)
+	case models.IMPORT_XE:
+		return ReadXeDump(param.Path, param.Prefix)
+	case models.IMPORT_WXR:
+		return ReadWxr(param.Path)
+	default:
+		return models.ImportData{}, fmt.Errorf("unknown import format")
+	}
+}
+
+// 이름으로 가져오기 원본 형식 찾기
+func ParseImportFormat(name string) (models.ImportFormat, bool) {
+	switch strings.ToLower(name) {
+	case "gnuboard", "gnuboard5", "g5":
+		return models.IMPORT_GNUBOARD, true
+	case "xe", "xpressengine":
+		return models.IMPORT_XE, true
+	case "wxr", "wordpress", "wp":
+		return models.IMPORT_WXR, true
+	default:
+		return models.IMPORT_GNUBOARD, false
+	}
+}
+
+// 원본 사이트의 시각 문자열을 유닉스 밀리초로 변환하기 (비어있거나 잘못된 값이면 0)
+func ParseImportTime(layout string, value string) uint64 {
+	if len(value) < 1 || strings.HasPrefix(value, "0000") {
+		return 0
+	}
+	parsed, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return 0
+	}
+	return uint64(parsed.UnixMilli())
+}
+
+// 원본 게시판 아이디를 게시판 아이디 규칙에 맞게 바꾸기
+func MakeImportBoardId(id string) string {
+	result := boardIdPattern.ReplaceAllString(strings.ToLower(id), "_")
+	result = CutString(strings.Trim(result, "_"), 30)
+	if len(result) < 2 {
+		return "imported"
+	}
+	return result
+}
+
+// 줄바꿈으로 구분된 본문을 문단으로 나누기 (이미 문단 태그가 있다면 그대로 사용)
+func MakeImportParagraphs(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if strings.Contains(text, "<p") || strings.Contains(text, "<div") {
+		return text
+	}
+	paragraphs := make([]string, 0)
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.TrimSpace(block)
+		if len(block) < 1 {
+			continue
+		}
+		paragraphs = append(paragraphs, "<p>"+strings.ReplaceAll(block, "\n", "<br />")+"</p>")
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// 일반 텍스트 본문을 HTML 문단으로 바꾸기
+func MakeImportPlainText(text string) string {
+	return MakeImportParagraphs(html.EscapeString(text))
+}
+
+// 원본 사이트의 파일을 첨부파일 폴더에 복사하고 저장된 경로 반환
+func SaveImportFile(srcPath string, name string) (string, error) {
+	result := ""
+	savePath, err := MakeSavePath(models.UPLOAD_ATTACH)
+	if err != nil {
+		return result, err
+	}
+	result = fmt.Sprintf("%s/%s%s", savePath, uuid.New().String()[:8], filepath.Ext(name))
+
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return result, err
+	}
+	defer srcFile.Close()
+
+	if err = CopyFile(result, srcFile); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// 정수 문자열을 변환하고, 잘못된 값이면 0 반환
+func atoiOrZero(value string) int {
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// SQL 덤프 파일을 읽어서 테이블별 행 목록으로 만들기 (접두사가 붙은 테이블만, 테이블명에서 접두사는 제거)
+func ReadSqlDump(path string, prefix string) (map[string]SqlRows, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tables := make(map[string]SqlRows)
+	columns := make(map[string][]string)
+	reader := &sqlStatementReader{r: bufio.NewReaderSize(file, 64*1024)}
+
+	for {
+		stmt, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		upper := strings.ToUpper(stmt[:min(len(stmt), 20)])
+		switch {
+		case strings.HasPrefix(upper, "CREATE TABLE"):
+			name, cols := parseCreateTable(stmt)
+			columns[name] = cols
+		case strings.HasPrefix(upper, "INSERT") || strings.HasPrefix(upper, "REPLACE"):
+			name, cols, tuples, err := parseInsert(stmt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse insert statement of %s: %s", name, err.Error())
+			}
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if len(cols) < 1 {
+				cols = columns[name]
+			}
+			if len(cols) < 1 {
+				return nil, fmt.Errorf("unable to find columns of %s, dump it with CREATE TABLE or --complete-insert", name)
+			}
+
+			table := strings.TrimPrefix(name, prefix)
+			for _, tuple := range tuples {
+				row := make(map[string]string, len(cols))
+				for i, value := range tuple {
+					if i < len(cols) {
+						row[cols[i]] = value
+					}
+				}
+				tables[table] = append(tables[table], row)
+			}
+		}
+	}
+	return tables, nil
+}
+
+// 주석을 제외하고 세미콜론 단위로 다음 SQL 문장 읽기 (더 이상 없으면 io.EOF)
+func (s *sqlStatementReader) next() (string, error) {
+	s.b.Reset()
+	for {
+		ch, err := s.r.ReadByte()
+		if err == io.EOF {
+			if stmt := strings.TrimSpace(s.b.String()); len(stmt) > 0 {
+				return stmt, nil
+			}
+			return "", io.EOF
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			s.b.WriteByte(ch)
+			err = s.copyQuoted(ch)
+		case ch == '-' && s.isLineComment(), ch == '#':
+			err = s.skipUntil("\n")
+		case ch == '/' && s.startsWith("*"):
+			err = s.skipUntil("*/")
+		case ch == ';':
+			if stmt := strings.TrimSpace(s.b.String()); len(stmt) > 0 {
+				return stmt, nil
+			}
+		default:
+			s.b.WriteByte(ch)
+		}
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+	}
+}
+
+// 따옴표로 감싼 부분을 그대로 옮겨 담기 (백슬래시 이스케이프와 연속된 따옴표 처리)
+func (s *sqlStatementReader) copyQuoted(quote byte) error {
+	for {
+		ch, err := s.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		s.b.WriteByte(ch)
+		if ch == '\\' && quote != '`' {
+			next, err := s.r.ReadByte()
+			if err != nil {
+				return err
+			}
+			s.b.WriteByte(next)
+			continue
+		}
+		if ch == quote {
+			if !s.startsWith(string(quote)) {
+				return nil
+			}
+			next, _ := s.r.ReadByte()
+			s.b.WriteByte(next)
+		}
+	}
+}
+
+// 다음에 읽을 내용이 지정한 문자열로 시작하는지 확인하기
+func (s *sqlStatementReader) startsWith(prefix string) bool {
+	peek, _ := s.r.Peek(len(prefix))
+	return string(peek) == prefix
+}
+
+// 읽은 '-' 다음이 한 줄 주석인지 확인하기 ("--" 뒤에 공백이나 줄바꿈, 또는 파일 끝)
+func (s *sqlStatementReader) isLineComment() bool {
+	peek, _ := s.r.Peek(2)
+	return len(peek) > 0 && peek[0] == '-' && (len(peek) < 2 || strings.IndexByte(" \t\r\n", peek[1]) >= 0)
+}
+
+// 지정한 문자열이 끝날 때까지 건너뛰기
+func (s *sqlStatementReader) skipUntil(end string) error {
+	matched := 0
+	for matched < len(end) {
+		ch, err := s.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case ch == end[matched]:
+			matched++
+		case ch == end[0]:
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+	return nil
+}
+
+// 따옴표로 감싼 부분을 건너뛰기 (백슬래시 이스케이프와 연속된 따옴표 처리)
+func (p *sqlDumpParser) skipQuoted(quote byte) {
+	p.pos++
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		if ch == '\\' && quote != '`' {
+			p.pos += 2
+			continue
+		}
+		p.pos++
+		if ch == quote {
+			if p.pos < len(p.src) && p.src[p.pos] == quote {
+				p.pos++
+				continue
+			}
+			return
+		}
+	}
+}
+
+// CREATE TABLE 문에서 테이블명과 컬럼명들 가져오기
+func parseCreateTable(stmt string) (string, []string) {
+	cols := make([]string, 0)
+	open := strings.Index(stmt, "(")
+	if open < 0 {
+		return "", cols
+	}
+	name := unquoteIdentifier(lastWord(stmt[:open]))
+
+	parser := &sqlDumpParser{src: stmt, pos: open + 1}
+	depth, start := 0, parser.pos
+	for parser.pos < len(stmt) && depth >= 0 {
+		switch ch := stmt[parser.pos]; ch {
+		case '\'', '"', '`':
+			parser.skipQuoted(ch)
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if (stmt[parser.pos] == ',' && depth == 0) || depth < 0 {
+			def := strings.TrimSpace(stmt[start:parser.pos])
+			if strings.HasPrefix(def, "`") {
+				if end := strings.Index(def[1:], "`"); end > 0 {
+					cols = append(cols, def[1:end+1])
+				}
+			}
+			start = parser.pos + 1
+		}
+		parser.pos++
+	}
+	return name, cols
+}
+
+// INSERT 문에서 테이블명, 컬럼명들, 값 묶음들 가져오기
+func parseInsert(stmt string) (string, []string, [][]string, error) {
+	match := insertPattern.FindStringSubmatchIndex(stmt)
+	if match == nil {
+		return "", nil, nil, fmt.Errorf("missing INTO or VALUES")
+	}
+
+	head := strings.TrimSpace(stmt[match[2]:match[3]])
+	cols := make([]string, 0)
+	name := head
+	if open := strings.Index(head, "("); open > 0 {
+		name = strings.TrimSpace(head[:open])
+		for _, col := range strings.Split(strings.TrimSuffix(strings.TrimSpace(head[open+1:]), ")"), ",") {
+			cols = append(cols, unquoteIdentifier(strings.TrimSpace(col)))
+		}
+	}
+	name = unquoteIdentifier(name)
+
+	parser := &sqlDumpParser{src: stmt, pos: match[1]}
+	tuples, err := parser.tuples()
+	return name, cols, tuples, err
+}
+
+// VALUES 뒤의 값 묶음들 읽기
+func (p *sqlDumpParser) tuples() ([][]string, error) {
+	result := make([][]string, 0)
+	for {
+		p.skipSpaces(",")
+		if p.pos >= len(p.src) {
+			return result, nil
+		}
+		if p.src[p.pos] != '(' {
+			return result, fmt.Errorf("unexpected character at %d", p.pos)
+		}
+		p.pos++
+
+		tuple := make([]string, 0)
+		for {
+			p.skipSpaces(",")
+			if p.pos >= len(p.src) {
+				return result, fmt.Errorf("unexpected end of values")
+			}
+			if p.src[p.pos] == ')' {
+				p.pos++
+				break
+			}
+			tuple = append(tuple, p.value())
+		}
+		result = append(result, tuple)
+	}
+}
+
+// 값 하나 읽기 (문자열은 이스케이프를 풀고 NULL 은 빈 문자열로)
+func (p *sqlDumpParser) value() string {
+	if strings.HasPrefix(p.src[p.pos:], "_binary ") {
+		p.pos += len("_binary ")
+	}
+	if p.src[p.pos] != '\'' {
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] != ',' && p.src[p.pos] != ')' {
+			p.pos++
+		}
+		token := strings.TrimSpace(p.src[start:p.pos])
+		if strings.EqualFold(token, "NULL") {
+			return ""
+		}
+		return token
+	}
+
+	var b strings.Builder
+	p.pos++
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		if ch == '\\' && p.pos+1 < len(p.src) {
+			b.WriteString(unescapeSqlChar(p.src[p.pos+1]))
+			p.pos += 2
+			continue
+		}
+		p.pos++
+		if ch == '\'' {
+			if p.pos < len(p.src) && p.src[p.pos] == '\'' {
+				b.WriteByte('\'')
+				p.pos++
+				continue
+			}
+			break
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+// 공백과 지정한 구분자들 건너뛰기
+func (p *sqlDumpParser) skipSpaces(extra string) {
+	for p.pos < len(p.src) && (strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 || strings.IndexByte(extra, p.src[p.pos]) >= 0) {
+		p.pos++
+	}
+}
+
+// MySQL 이스케이프 문자 풀기
+func unescapeSqlChar(ch byte) string {
+	switch ch {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '0':
+		return "\x00"
+	case 'Z':
+		return "\x1a"
+	default:
+		return string(ch)
+	}
+}
+
+// 백틱으로 감싼 식별자 풀기
+func unquoteIdentifier(name string) string {
+	return strings.Trim(strings.TrimSpace(name), "`\"")
+}
+
+// 문자열의 마지막 단어 가져오기
+func lastWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) < 1 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// mysqldump 기본 옵션(--complete-insert 없음)으로 만든 덤프 파일 흉내내기
+const testSqlDump = `-- MySQL dump 10.13
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+--
+-- Table structure for table ` + "`g5_member`" + `
+--
+CREATE TABLE ` + "`g5_member`" + ` (
+  ` + "`mb_no`" + ` int(11) NOT NULL AUTO_INCREMENT,
+  ` + "`mb_id`" + ` varchar(20) NOT NULL DEFAULT '',
+  ` + "`mb_memo`" + ` text NOT NULL COMMENT 'memo, (with comma)',
+  ` + "`mb_key`" + ` varbinary(16) DEFAULT NULL,
+  PRIMARY KEY (` + "`mb_no`" + `),
+  KEY ` + "`mb_id`" + ` (` + "`mb_id`" + `)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+--
+-- Dumping data for table ` + "`g5_member`" + `
+--
+LOCK TABLES ` + "`g5_member`" + ` WRITE;
+INSERT INTO ` + "`g5_member`" + ` VALUES (1,'admin','It\'s a \"test\"; -- not a comment',_binary 'k\0y'),(2,'guest','say ''hi''\nbye\\',NULL);
+UNLOCK TABLES;
+# other tables are skipped
+INSERT INTO ` + "`other_table`" + ` VALUES (1,'x');
+`
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadSqlDump(t *testing.T) {
+	tables, err := ReadSqlDump(writeTestFile(t, "dump.sql", testSqlDump), "g5_")
+	if err != nil {
+		t.Fatalf("ReadSqlDump error = %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("tables = %v, want only member", tables)
+	}
+	rows := tables["member"]
+	if len(rows) != 2 {
+		t.Fatalf("member has %d rows, want 2", len(rows))
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{0, "mb_no", "1"},
+		{0, "mb_id", "admin"},
+		{0, "mb_memo", `It's a "test"; -- not a comment`},
+		{0, "mb_key", "k\x00y"},
+		{1, "mb_no", "2"},
+		{1, "mb_memo", "say 'hi'\nbye\\"},
+		{1, "mb_key", ""},
+	}
+	for _, tt := range tests {
+		if got := rows[tt.row][tt.column]; got != tt.want {
+			t.Errorf("row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestReadSqlDumpCompleteInsert(t *testing.T) {
+	dump := "INSERT INTO `xe_modules` (`module_srl`, `mid`, `browser_title`) VALUES\n" +
+		"(10,'free','Free /* board */'),\n(11,'qna','Q&A');\n" +
+		"REPLACE INTO `xe_modules` (`mid`, `module_srl`) VALUES ('notice',12)"
+	tables, err := ReadSqlDump(writeTestFile(t, "dump.sql", dump), "xe_")
+	if err != nil {
+		t.Fatalf("ReadSqlDump error = %v", err)
+	}
+	mids := make([]string, 0)
+	for _, row := range tables["modules"] {
+		mids = append(mids, row["module_srl"]+":"+row["mid"])
+	}
+	if want := []string{"10:free", "11:qna", "12:notice"}; !slices.Equal(mids, want) {
+		t.Errorf("modules = %v, want %v", mids, want)
+	}
+	if got := tables["modules"][0]["browser_title"]; got != "Free /* board */" {
+		t.Errorf("browser_title = %q", got)
+	}
+}
+
+func TestReadSqlDumpLargeValue(t *testing.T) {
+	content := strings.Repeat("a;'-- ", 40000)
+	escaped := strings.ReplaceAll(content, "'", "\\'")
+	dump := "INSERT INTO `g5_write_free` (`wr_id`, `wr_content`) VALUES (1,'" + escaped + "'),(2,'tail');"
+	tables, err := ReadSqlDump(writeTestFile(t, "dump.sql", dump), "g5_")
+	if err != nil {
+		t.Fatalf("ReadSqlDump error = %v", err)
+	}
+	rows := tables["write_free"]
+	if len(rows) != 2 || rows[0]["wr_content"] != content || rows[1]["wr_content"] != "tail" {
+		t.Errorf("large value was not read back across buffer boundaries")
+	}
+}
+
+func TestReadSqlDumpMissingColumns(t *testing.T) {
+	dump := "INSERT INTO `g5_board` VALUES ('free','Free');"
+	_, err := ReadSqlDump(writeTestFile(t, "dump.sql", dump), "g5_")
+	if err == nil || !strings.Contains(err.Error(), "unable to find columns") {
+		t.Errorf("error = %v, want unable to find columns", err)
+	}
+}
+
+const testWxr = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>My Blog</title>
+	<description>Just another blog</description>
+	<wp:author>
+		<wp:author_id>3</wp:author_id>
+		<wp:author_login><![CDATA[jane]]></wp:author_login>
+		<wp:author_email><![CDATA[jane@example.com]]></wp:author_email>
+		<wp:author_display_name><![CDATA[Jane]]></wp:author_display_name>
+	</wp:author>
+	<item>
+		<title>Hello</title>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[First line
+second line
+
+Next paragraph]]></content:encoded>
+		<wp:post_id>7</wp:post_id>
+		<wp:post_date>2024-03-01 10:20:30</wp:post_date>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<wp:is_sticky>1</wp:is_sticky>
+		<category domain="category" nicename="news"><![CDATA[News]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[go]]></category>
+		<wp:comment>
+			<wp:comment_id>20</wp:comment_id>
+			<wp:comment_content><![CDATA[Nice]]></wp:comment_content>
+			<wp:comment_approved>1</wp:comment_approved>
+			<wp:comment_parent>0</wp:comment_parent>
+			<wp:comment_user_id>3</wp:comment_user_id>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>21</wp:comment_id>
+			<wp:comment_content><![CDATA[spam]]></wp:comment_content>
+			<wp:comment_approved>spam</wp:comment_approved>
+		</wp:comment>
+	</item>
+	<item>
+		<title>Draft</title>
+		<wp:post_id>8</wp:post_id>
+		<wp:status>draft</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>photo</title>
+		<wp:post_id>9</wp:post_id>
+		<wp:post_parent>7</wp:post_parent>
+		<wp:post_type>attachment</wp:post_type>
+		<wp:attachment_url>https://blog.example.com/wp-content/uploads/2024/03/photo.jpg</wp:attachment_url>
+	</item>
+</channel>
+</rss>`
+
+func TestReadWxr(t *testing.T) {
+	data, err := ReadWxr(writeTestFile(t, "export.xml", testWxr))
+	if err != nil {
+		t.Fatalf("ReadWxr error = %v", err)
+	}
+	if len(data.Boards) != 1 || data.Boards[0].Name != "My Blog" {
+		t.Errorf("Boards = %+v", data.Boards)
+	}
+	if len(data.Users) != 1 || data.Users[0].SourceId != "jane" || data.Users[0].Email != "jane@example.com" {
+		t.Errorf("Users = %+v", data.Users)
+	}
+	if len(data.Categories) != 1 || data.Categories[0].SourceId != "news" {
+		t.Errorf("Categories = %+v", data.Categories)
+	}
+
+	if len(data.Posts) != 1 {
+		t.Fatalf("Posts = %+v, want only the published post", data.Posts)
+	}
+	post := data.Posts[0]
+	if post.SourceId != "7" || post.WriterSourceId != "jane" || !post.IsNotice || post.CategorySourceId != "news" {
+		t.Errorf("Post = %+v", post)
+	}
+	if want := "<p>First line<br />second line</p>\n<p>Next paragraph</p>"; post.Content != want {
+		t.Errorf("Content = %q, want %q", post.Content, want)
+	}
+	if !slices.Equal(post.Tags, []string{"go"}) {
+		t.Errorf("Tags = %v", post.Tags)
+	}
+	if want := ParseImportTime(wxrTimeLayout, "2024-03-01 10:20:30"); post.Submitted != want || want == 0 {
+		t.Errorf("Submitted = %d, want %d", post.Submitted, want)
+	}
+
+	if len(data.Comments) != 1 || data.Comments[0].SourceId != "20" || data.Comments[0].WriterSourceId != "jane" {
+		t.Errorf("Comments = %+v, want only the approved comment", data.Comments)
+	}
+	want := models.ImportFile{
+		SourceId:     "9",
+		PostSourceId: "7",
+		Name:         "photo.jpg",
+		Path:         "wp-content/uploads/2024/03/photo.jpg",
+		Url:          "https://blog.example.com/wp-content/uploads/2024/03/photo.jpg",
+	}
+	if len(data.Files) != 1 || data.Files[0] != want {
+		t.Errorf("Files = %+v, want %+v", data.Files, want)
+	}
+}
+
+func TestReadWxrInvalid(t *testing.T) {
+	if _, err := ReadWxr(writeTestFile(t, "export.xml", "<rss><channel>")); err == nil {
+		t.Error("ReadWxr on a broken file succeeded, want error")
+	}
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 워드프레스 날짜 형식
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+// WXR 문서 정의 (워드프레스 버전마다 네임스페이스가 달라 태그 이름으로만 찾음)
+type wxrDocument struct {
+	Channel struct {
+		Title       string      `xml:"title"`
+		Description string      `xml:"description"`
+		Authors     []wxrAuthor `xml:"author"`
+		Items       []wxrItem   `xml:"item"`
+	} `xml:"channel"`
+}
+
+// WXR 글쓴이 정의
+type wxrAuthor struct {
+	Id          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+// WXR 분류 및 태그 정의
+type wxrCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+// WXR 댓글 정의
+type wxrComment struct {
+	Id       string `xml:"comment_id"`
+	Content  string `xml:"comment_content"`
+	Date     string `xml:"comment_date"`
+	Approved string `xml:"comment_approved"`
+	Type     string `xml:"comment_type"`
+	Parent   string `xml:"comment_parent"`
+	UserId   string `xml:"comment_user_id"`
+}
+
+// WXR 항목 정의 (글, 페이지, 첨부파일 등)
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Creator       string        `xml:"creator"`
+	Content       string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostId        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostModified  string        `xml:"post_modified"`
+	Status        string        `xml:"status"`
+	PostParent    string        `xml:"post_parent"`
+	PostType      string        `xml:"post_type"`
+	IsSticky      string        `xml:"is_sticky"`
+	AttachmentUrl string        `xml:"attachment_url"`
+	Categories    []wxrCategory `xml:"category"`
+	Comments      []wxrComment  `xml:"comment"`
+}
+
+// 워드프레스 WXR 파일에서 글쓴이, 분류, 글, 댓글, 첨부파일 읽기 (모든 글은 하나의 게시판으로)
+func ReadWxr(path string) (models.ImportData, error) {
+	data := models.ImportData{}
+	file, err := os.Open(path)
+	if err != nil {
+		return data, err
+	}
+	defer file.Close()
+
+	doc := wxrDocument{}
+	if err := xml.NewDecoder(file).Decode(&doc); err != nil {
+		return data, fmt.Errorf("failed to parse WXR file: %s", err.Error())
+	}
+
+	const boardSourceId = "wxr"
+	data.Boards = append(data.Boards, models.ImportBoard{
+		SourceId: boardSourceId,
+		Id:       models.IMPORT_WXR_BOARD_ID,
+		Name:     doc.Channel.Title,
+		Info:     doc.Channel.Description,
+	})
+
+	logins := make(map[string]string)
+	for _, author := range doc.Channel.Authors {
+		logins[author.Id] = author.Login
+		data.Users = append(data.Users, models.ImportUser{
+			SourceId: author.Login,
+			Email:    author.Email,
+			Name:     author.DisplayName,
+			Level:    1,
+			Signup:   uint64(time.Now().UnixMilli()),
+		})
+	}
+
+	categories := make(map[string]bool)
+	posts := make(map[string]bool)
+	for _, item := range doc.Channel.Items {
+		if item.PostType != "post" || (item.Status != "publish" && item.Status != "private") {
+			continue
+		}
+		posts[item.PostId] = true
+		post := models.ImportPost{
+			SourceId:       item.PostId,
+			BoardSourceId:  boardSourceId,
+			WriterSourceId: item.Creator,
+			Title:          item.Title,
+			Content:        MakeImportParagraphs(item.Content),
+			IsNotice:       item.IsSticky == "1",
+			IsSecret:       item.Status == "private",
+			Submitted:      ParseImportTime(wxrTimeLayout, item.PostDate),
+			Modified:       ParseImportTime(wxrTimeLayout, item.PostModified),
+			Tags:           make([]string, 0),
+		}
+
+		for _, category := range item.Categories {
+			switch category.Domain {
+			case "category":
+				if len(post.CategorySourceId) > 0 || category.Nicename == "uncategorized" {
+					continue
+				}
+				post.CategorySourceId = category.Nicename
+				if !categories[category.Nicename] {
+					categories[category.Nicename] = true
+					data.Categories = append(data.Categories, models.ImportCategory{
+						SourceId:      category.Nicename,
+						BoardSourceId: boardSourceId,
+						Name:          strings.TrimSpace(category.Name),
+					})
+				}
+			case "post_tag":
+				post.Tags = append(post.Tags, strings.TrimSpace(category.Name))
+			}
+		}
+		data.Posts = append(data.Posts, post)
+
+		for _, comment := range item.Comments {
+			if comment.Approved != "1" || comment.Type == "pingback" || comment.Type == "trackback" {
+				continue
+			}
+			imported := models.ImportComment{
+				SourceId:       comment.Id,
+				PostSourceId:   item.PostId,
+				WriterSourceId: logins[comment.UserId],
+				Content:        MakeImportParagraphs(comment.Content),
+				Submitted:      ParseImportTime(wxrTimeLayout, comment.Date),
+			}
+			if comment.Parent != "0" {
+				imported.ReplySourceId = comment.Parent
+			}
+			data.Comments = append(data.Comments, imported)
+		}
+	}
+
+	for _, item := range doc.Channel.Items {
+		if item.PostType != "attachment" || !posts[item.PostParent] || len(item.AttachmentUrl) < 1 {
+			continue
+		}
+		link, err := url.Parse(item.AttachmentUrl)
+		if err != nil {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("invalid attachment url: %s", item.AttachmentUrl))
+			continue
+		}
+		localPath := strings.TrimPrefix(link.Path, "/")
+		if index := strings.Index(link.Path, "/wp-content/"); index >= 0 {
+			localPath = link.Path[index+1:]
+		}
+		data.Files = append(data.Files, models.ImportFile{
+			SourceId:     item.PostId,
+			PostSourceId: item.PostParent,
+			Name:         filepath.Base(link.Path),
+			Path:         localPath,
+			Url:          item.AttachmentUrl,
+		})
+	}
+	return data, nil
+}
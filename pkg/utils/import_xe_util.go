@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// XE 날짜 형식
+const xeTimeLayout = "20060102150405"
+
+// XE SQL 덤프 파일에서 회원, 게시판 모듈, 분류, 문서, 댓글, 첨부파일 읽기
+func ReadXeDump(path string, prefix string) (models.ImportData, error) {
+	data := models.ImportData{}
+	tables, err := ReadSqlDump(path, prefix)
+	if err != nil {
+		return data, err
+	}
+
+	for _, row := range tables["member"] {
+		level := 1
+		if row["is_admin"] == "Y" {
+			level = 9
+		}
+		data.Users = append(data.Users, models.ImportUser{
+			SourceId: row["member_srl"],
+			Email:    row["email_address"],
+			Name:     row["nick_name"],
+			Password: row["password"],
+			Level:    level,
+			Signup:   ParseImportTime(xeTimeLayout, row["regdate"]),
+		})
+	}
+
+	boards := make(map[string]bool)
+	for _, row := range tables["modules"] {
+		if row["module"] != "board" {
+			continue
+		}
+		boards[row["module_srl"]] = true
+		data.Boards = append(data.Boards, models.ImportBoard{
+			SourceId: row["module_srl"],
+			Id:       MakeImportBoardId(row["mid"]),
+			Name:     row["browser_title"],
+		})
+	}
+	if len(boards) < 1 {
+		return data, fmt.Errorf("unable to find any board module in %smodules table", prefix)
+	}
+
+	for _, row := range tables["document_categories"] {
+		if !boards[row["module_srl"]] {
+			continue
+		}
+		category := models.ImportCategory{
+			SourceId:      row["category_srl"],
+			BoardSourceId: row["module_srl"],
+			Name:          row["title"],
+		}
+		if row["parent_srl"] != "0" {
+			category.ParentSourceId = row["parent_srl"]
+		}
+		data.Categories = append(data.Categories, category)
+	}
+
+	documents := make(map[string]bool)
+	for _, row := range tables["documents"] {
+		if !boards[row["module_srl"]] || row["status"] == "TEMP" {
+			continue
+		}
+		documents[row["document_srl"]] = true
+		post := models.ImportPost{
+			SourceId:       row["document_srl"],
+			BoardSourceId:  row["module_srl"],
+			WriterSourceId: row["member_srl"],
+			Title:          row["title"],
+			Content:        row["content"],
+			IsNotice:       row["is_notice"] == "Y",
+			IsSecret:       row["status"] == "SECRET" || row["is_secret"] == "Y",
+			Hit:            uint(max(atoiOrZero(row["readed_count"]), 0)),
+			Submitted:      ParseImportTime(xeTimeLayout, row["regdate"]),
+			Modified:       ParseImportTime(xeTimeLayout, row["last_update"]),
+			Tags:           make([]string, 0),
+		}
+		if row["category_srl"] != "0" {
+			post.CategorySourceId = row["category_srl"]
+		}
+		for _, tag := range strings.Split(row["tags"], ",") {
+			if tag = strings.TrimSpace(tag); len(tag) > 0 {
+				post.Tags = append(post.Tags, tag)
+			}
+		}
+		data.Posts = append(data.Posts, post)
+	}
+
+	for _, row := range tables["comments"] {
+		if !documents[row["document_srl"]] {
+			continue
+		}
+		comment := models.ImportComment{
+			SourceId:       row["comment_srl"],
+			PostSourceId:   row["document_srl"],
+			WriterSourceId: row["member_srl"],
+			Content:        row["content"],
+			Submitted:      ParseImportTime(xeTimeLayout, row["regdate"]),
+			Modified:       ParseImportTime(xeTimeLayout, row["last_update"]),
+		}
+		if row["parent_srl"] != "0" {
+			comment.ReplySourceId = row["parent_srl"]
+		}
+		data.Comments = append(data.Comments, comment)
+	}
+
+	for _, row := range tables["files"] {
+		if !documents[row["upload_target_srl"]] || len(row["uploaded_filename"]) < 1 {
+			continue
+		}
+		path := strings.TrimPrefix(row["uploaded_filename"], "./")
+		data.Files = append(data.Files, models.ImportFile{
+			SourceId:     row["file_srl"],
+			PostSourceId: row["upload_target_srl"],
+			Name:         row["source_filename"],
+			Path:         path,
+			Url:          "/" + path,
+		})
+	}
+	return data, nil
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+)
+
+// 스팸 여부를 판단해주는 분류기 정의 (스팸이면 true 와 이유 반환)
+type SpamClassifier interface {
+	Classify(content string) (bool, string, error)
+}
+
+// OpenAI의 moderation API를 이용하는 분류기
+type OpenaiClassifier struct {
+	key string
+}
+
+// 지정한 주소로 내용을 보내서 판단을 받는 분류기
+type HttpClassifier struct {
+	endpoint string
+	key      string
+	client   *http.Client
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// 설정에 맞는 스팸 분류기 가져오기 (사용하지 않는다면 nil 반환)
+func NewSpamClassifier() SpamClassifier {
+	switch configs.Env.SpamClassifier {
+	case models.SPAM_CLASSIFIER_OPENAI:
+		if len(configs.Env.OpenaiKey) < 1 {
+			return nil
+		}
+		return &OpenaiClassifier{key: configs.Env.OpenaiKey}
+	case models.SPAM_CLASSIFIER_HTTP:
+		if len(configs.Env.SpamEndpoint) < 1 {
+			return nil
+		}
+		return &HttpClassifier{
+			endpoint: configs.Env.SpamEndpoint,
+			key:      configs.Env.SpamKey,
+			client:   &http.Client{Timeout: models.SPAM_CLASSIFY_TIMEOUT},
+		}
+	default:
+		return nil
+	}
+}
+
+// 금지어 규칙을 정규식으로 변환하기 (단어는 대소문자 구분 없이 그대로 찾음)
+func CompileSpamRule(rule models.SpamRule) (*regexp.Regexp, error) {
+	if utf8.RuneCountInString(rule.Pattern) < 1 || len(rule.Pattern) > models.SPAM_MAX_PATTERN {
+		return nil, fmt.Errorf("invalid pattern length")
+	}
+	if rule.Type == models.SPAM_RULE_WORD {
+		return regexp.Compile("(?i)" + regexp.QuoteMeta(rule.Pattern))
+	}
+	return regexp.Compile(rule.Pattern)
+}
+
+// 정규식에 해당하는 부분들을 같은 길이의 가림 문자로 바꾸기
+func MaskMatches(pattern *regexp.Regexp, text string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		return strings.Repeat(models.SPAM_MASK_CHAR, utf8.RuneCountInString(match))
+	})
+}
+
+// 본문에 포함된 서로 다른 링크 개수 세기
+func CountLinks(content string) int {
+	links := make(map[string]bool)
+	for _, match := range urlPattern.FindAllString(html.UnescapeString(content), -1) {
+		links[strings.TrimRight(match, ".,;:!?)]}")] = true
+	}
+	return len(links)
+}
+
+// 본문에서 태그를 지우고 공백을 정리한 글자만 남기기
+func StripTags(content string) string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(content, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// 스팸 검사 기록에 남길 요약문 만들기
+func MakeSpamExcerpt(title string, content string) string {
+	text := StripTags(content)
+	if len(title) > 0 {
+		text = fmt.Sprintf("%s | %s", title, text)
+	}
+	return CutString(text, models.SPAM_MAX_DETAIL)
+}
+
+// OpenAI의 moderation API로 스팸(유해 콘텐츠) 여부 판단하기
+func (c *OpenaiClassifier) Classify(content string) (bool, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), models.SPAM_CLASSIFY_TIMEOUT)
+	defer cancel()
+
+	client := openai.NewClient(option.WithAPIKey(c.key))
+	result, err := client.Moderations.New(ctx, openai.ModerationNewParams{
+		Input: openai.F[openai.ModerationNewParamsInputUnion](openai.ModerationNewParamsInputArray{content}),
+	})
+	if err != nil {
+		return false, "", err
+	}
+	for _, moderation := range result.Results {
+		if moderation.Flagged {
+			return true, "flagged by openai moderation", nil
+		}
+	}
+	return false, "", nil
+}
+
+// 지정한 주소에 {"content": "..."} 를 보내고 {"spam": true, "reason": "..."} 형태로 판단 받기
+func (c *HttpClassifier) Classify(content string) (bool, string, error) {
+	body, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return false, "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.key) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		return false, "", fmt.Errorf("spam classifier returned %d: %s", resp.StatusCode, string(message))
+	}
+
+	var result struct {
+		Spam   bool   `json:"spam"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 65536)).Decode(&result); err != nil {
+		return false, "", err
+	}
+	return result.Spam, CutString(result.Reason, models.SPAM_MAX_DETAIL), nil
+}
@@ -0,0 +1,313 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirini/goapi/internal/configs"
+	"github.com/sirini/goapi/pkg/models"
+	nethtml "golang.org/x/net/html"
+)
+
+var (
+	urlPattern       = regexp.MustCompile(`https?://[^\s"'<>]+`)
+	blockedNetworks  = parseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4", "64:ff9b::/96")
+	unfurlHttpClient = newUnfurlHttpClient(models.UNFURL_TIMEOUT, checkDialAddress)
+)
+
+// 접속 주소 검사 함수와 시간 제한을 지정해서 미리보기용 HTTP 클라이언트 만들기
+func newUnfurlHttpClient(timeout time.Duration, control func(string, string, syscall.RawConn) error) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: nil,
+			DialContext: (&net.Dialer{
+				Timeout: timeout,
+				Control: control,
+			}).DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= models.UNFURL_MAX_REDIRECTS {
+				return fmt.Errorf("too many redirects")
+			}
+			return checkUnfurlUrl(req.URL)
+		},
+	}
+}
+
+// 본문에서 http(s) 링크들을 중복 없이 추출하기 (최대 UNFURL_MAX_LINKS 개)
+func ExtractUrls(content string) []string {
+	urls := make([]string, 0)
+	for _, match := range urlPattern.FindAllString(content, -1) {
+		link := strings.TrimRight(html.UnescapeString(match), ".,;:!?)]}")
+		if _, err := url.ParseRequestURI(link); err != nil {
+			continue
+		}
+		if !slices.Contains(urls, link) {
+			urls = append(urls, link)
+		}
+		if len(urls) >= models.UNFURL_MAX_LINKS {
+			break
+		}
+	}
+	return urls
+}
+
+// 링크의 OpenGraph, 트위터 카드, oEmbed 정보를 가져와서 미리보기 만들기 (이미지는 원격 주소 그대로)
+func FetchLinkPreview(link string) (models.LinkPreview, error) {
+	preview := models.LinkPreview{Url: link}
+	body, finalUrl, contentType, err := fetchUnfurl(link, "text/html,application/xhtml+xml", models.UNFURL_MAX_BYTES)
+	if err != nil {
+		return preview, err
+	}
+	if strings.HasPrefix(contentType, "image/") {
+		preview.Image = finalUrl.String()
+		preview.Type = "image"
+		return preview, nil
+	}
+	if !strings.Contains(contentType, "html") {
+		return preview, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	meta, title, oembedUrl := parseMetaTags(body)
+	preview.Title = firstNonEmpty(meta["og:title"], meta["twitter:title"], title)
+	preview.Description = firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"])
+	preview.SiteName = firstNonEmpty(meta["og:site_name"], meta["twitter:site"])
+	preview.Image = resolveUrl(finalUrl, firstNonEmpty(meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"]))
+	preview.Type = firstNonEmpty(meta["og:type"], "website")
+
+	if oembed := resolveUrl(finalUrl, oembedUrl); len(oembed) > 0 && (len(preview.Title) < 1 || len(preview.Image) < 1) {
+		fillOembed(&preview, oembed)
+	}
+	if len(preview.Title) < 1 {
+		return preview, fmt.Errorf("no metadata found")
+	}
+
+	preview.Title = CutString(Escape(strings.TrimSpace(preview.Title)), 300)
+	preview.Description = CutString(Escape(strings.TrimSpace(preview.Description)), 500)
+	preview.SiteName = CutString(Escape(strings.TrimSpace(preview.SiteName)), 100)
+	preview.Type = CutString(Escape(strings.TrimSpace(preview.Type)), 30)
+	return preview, nil
+}
+
+// 미리보기 이미지를 내려받아 썸네일 크기의 .webp 로 저장하고 경로 반환
+func SavePreviewImage(imageUrl string) (string, error) {
+	buffer, _, contentType, err := fetchUnfurl(imageUrl, "image/*", models.UNFURL_IMAGE_MAX_BYTES)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", fmt.Errorf("not an image: %s", contentType)
+	}
+
+	savePath, err := MakeSavePath(models.UPLOAD_IMAGE)
+	if err != nil {
+		return "", err
+	}
+	result := fmt.Sprintf("%s/%s.webp", savePath, uuid.New().String()[:8])
+	if err := SaveImage(buffer, result, configs.SIZE_THUMBNAIL.Number()); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// 공인 IP 주소인지 확인하기 (사설망, 루프백, 링크 로컬 등은 차단)
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// 실제로 접속하는 주소가 공인 IP 인지 확인하기 (DNS 재바인딩, 리다이렉트 우회 방지)
+func checkDialAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("blocked address: %s", host)
+	}
+	return nil
+}
+
+// 미리보기를 가져올 수 있는 링크인지 확인하기
+func checkUnfurlUrl(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: %s", target.Scheme)
+	}
+	if len(target.Hostname()) < 1 || target.User != nil {
+		return fmt.Errorf("invalid host")
+	}
+	return nil
+}
+
+// 크기, 시간 제한을 두고 원격 자원 가져오기 (최종 주소와 Content-Type 도 반환)
+func fetchUnfurl(link string, accept string, maxBytes int64) ([]byte, *url.URL, string, error) {
+	target, err := url.Parse(link)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := checkUnfurlUrl(target); err != nil {
+		return nil, nil, "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	req.Header.Set("User-Agent", models.UNFURL_USER_AGENT)
+	req.Header.Set("Accept", accept)
+
+	resp, err := unfurlHttpClient.Do(req)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, nil, "", fmt.Errorf("response is too large")
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, nil, "", fmt.Errorf("response is too large")
+	}
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0]))
+	return body, resp.Request.URL, contentType, nil
+}
+
+// oEmbed 응답으로 비어있는 미리보기 항목들 채우기
+func fillOembed(preview *models.LinkPreview, oembedUrl string) {
+	body, _, _, err := fetchUnfurl(oembedUrl, "application/json", models.UNFURL_MAX_BYTES)
+	if err != nil {
+		return
+	}
+	oembed := struct {
+		Type         string `json:"type"`
+		Title        string `json:"title"`
+		AuthorName   string `json:"author_name"`
+		ProviderName string `json:"provider_name"`
+		ThumbnailUrl string `json:"thumbnail_url"`
+	}{}
+	if err := json.Unmarshal(body, &oembed); err != nil {
+		return
+	}
+	preview.Title = firstNonEmpty(preview.Title, oembed.Title)
+	preview.Description = firstNonEmpty(preview.Description, oembed.AuthorName)
+	preview.SiteName = firstNonEmpty(preview.SiteName, oembed.ProviderName)
+	preview.Image = firstNonEmpty(preview.Image, oembed.ThumbnailUrl)
+	if preview.Type == "website" && len(oembed.Type) > 0 {
+		preview.Type = oembed.Type
+	}
+}
+
+// HTML 헤더에서 meta 태그들, title, oEmbed 링크 주소 가져오기
+func parseMetaTags(body []byte) (map[string]string, string, string) {
+	meta := make(map[string]string)
+	var title, oembedUrl string
+	inTitle := false
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(string(body)))
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case nethtml.ErrorToken:
+			return meta, title, oembedUrl
+		case nethtml.TextToken:
+			if inTitle && len(title) < 1 {
+				title = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case nethtml.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				return meta, title, oembedUrl
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(value)
+			}
+
+			switch string(name) {
+			case "title":
+				inTitle = true
+			case "body":
+				return meta, title, oembedUrl
+			case "meta":
+				key := strings.ToLower(firstNonEmpty(attrs["property"], attrs["name"]))
+				if _, exists := meta[key]; len(key) > 0 && !exists {
+					meta[key] = strings.TrimSpace(attrs["content"])
+				}
+			case "link":
+				if strings.Contains(strings.ToLower(attrs["rel"]), "alternate") && attrs["type"] == "application/json+oembed" {
+					oembedUrl = attrs["href"]
+				}
+			}
+		}
+	}
+}
+
+// 상대 경로를 기준 주소에 맞춰 절대 경로로 바꾸기
+func resolveUrl(base *url.URL, ref string) string {
+	if len(ref) < 1 {
+		return ""
+	}
+	target, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil || checkUnfurlUrl(target) != nil {
+		return ""
+	}
+	return target.String()
+}
+
+// 값이 있는 첫 번째 문자열 반환하기
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(strings.TrimSpace(value)) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+// CIDR 목록을 네트워크 목록으로 변환하기
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0)
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}